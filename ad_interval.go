@@ -0,0 +1,85 @@
+package mstypes
+
+import (
+	"fmt"
+	"time"
+)
+
+// ADIntervalKind is the kind of value an AD Interval or Large Integer attribute holds.
+type ADIntervalKind uint8
+
+// AD interval kinds
+const (
+	// ADIntervalNotSet is the value 0. Its meaning depends on the attribute: an accountExpires of 0 never expires,
+	// a pwdLastSet of 0 requires the password to be changed at the next logon.
+	ADIntervalNotSet ADIntervalKind = iota
+	// ADIntervalNever is the value 9223372036854775807, or for an interval -9223372036854775808.
+	ADIntervalNever
+	// ADIntervalTime is a point in time such as a lastLogonTimestamp.
+	ADIntervalTime
+	// ADIntervalDuration is a negative interval such as a maxPwdAge.
+	ADIntervalDuration
+)
+
+// String returns the name of the kind.
+func (k ADIntervalKind) String() string {
+	switch k {
+	case ADIntervalNotSet:
+		return "NotSet"
+	case ADIntervalNever:
+		return "Never"
+	case ADIntervalTime:
+		return "Time"
+	case ADIntervalDuration:
+		return "Duration"
+	}
+	return fmt.Sprintf("ADIntervalKind(%d)", uint8(k))
+}
+
+// ADInterval is the value of an AD attribute holding a FILETIME or a negative 100 nano second interval, such as
+// accountExpires, lastLogonTimestamp, pwdLastSet, maxPwdAge and lockoutDuration.
+type ADInterval struct {
+	Kind     ADIntervalKind
+	FileTime FileTime      // The raw value whatever the Kind.
+	Duration time.Duration // The duration of an ADIntervalDuration.
+}
+
+// ParseADInterval parses the decimal string LDAP returns an AD interval attribute as.
+func ParseADInterval(s string) (ADInterval, error) {
+	ft, err := ParseFileTime(s)
+	if err != nil {
+		return ADInterval{}, err
+	}
+	a := ADInterval{FileTime: ft}
+	switch {
+	case ft.IsZero():
+		a.Kind = ADIntervalNotSet
+	case ft == FileTimeNever || ft.IsNeverInterval():
+		a.Kind = ADIntervalNever
+	case ft.IsInterval():
+		a.Kind = ADIntervalDuration
+		a.Duration = ft.Duration()
+	default:
+		a.Kind = ADIntervalTime
+	}
+	return a, nil
+}
+
+// Time returns the point in time of an ADIntervalTime and the zero time.Time for other kinds.
+func (a ADInterval) Time() time.Time {
+	if a.Kind != ADIntervalTime {
+		return time.Time{}
+	}
+	return a.FileTime.Time()
+}
+
+// String returns the time in RFC 3339 form, the duration or the name of the kind.
+func (a ADInterval) String() string {
+	switch a.Kind {
+	case ADIntervalTime:
+		return a.Time().Format(time.RFC3339)
+	case ADIntervalDuration:
+		return a.Duration.String()
+	}
+	return a.Kind.String()
+}
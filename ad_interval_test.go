@@ -0,0 +1,35 @@
+package mstypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseADInterval(t *testing.T) {
+	var tests = []struct {
+		LDAP   string
+		Kind   ADIntervalKind
+		String string
+	}{
+		{"0", ADIntervalNotSet, "NotSet"},
+		{"9223372036854775807", ADIntervalNever, "Never"},
+		{"-9223372036854775808", ADIntervalNever, "Never"},
+		{"133485408000000000", ADIntervalTime, "2024-01-01T00:00:00Z"},
+		{"-36288000000000", ADIntervalDuration, "1008h0m0s"},
+	}
+	for _, test := range tests {
+		a, err := ParseADInterval(test.LDAP)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Kind, a.Kind, "kind of %s not as expected", test.LDAP)
+		assert.Equal(t, test.String, a.String())
+	}
+	a, _ := ParseADInterval("133485408000000000")
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), a.Time())
+	a, _ = ParseADInterval("-18000000000")
+	assert.Equal(t, 30*time.Minute, a.Duration)
+	assert.True(t, a.Time().IsZero())
+	_, err := ParseADInterval("")
+	assert.Error(t, err)
+}
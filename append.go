@@ -0,0 +1,70 @@
+package mstypes
+
+import (
+	"sync"
+)
+
+// writerPool holds appending Writers, with their buffers, for reuse by AcquireWriter.
+var writerPool = sync.Pool{
+	New: func() interface{} {
+		return NewAppendWriter(make([]byte, 0, 512))
+	},
+}
+
+// maxPooledBuffer is the capacity above which the buffer of a released Writer is not kept in the pool.
+const maxPooledBuffer = 64 << 10
+
+// NewAppendWriter creates a Writer appending the data written to dst rather than writing it to an io.Writer. The
+// alignment of the data is relative to the end of dst so dst can hold unrelated data such as a PDU header.
+func NewAppendWriter(dst []byte, opts ...Option) *Writer {
+	w := NewWriter(nil, opts...)
+	w.buf = dst
+	return w
+}
+
+// Bytes returns the buffer of a Writer created with NewAppendWriter or AcquireWriter, including the data written.
+func (w *Writer) Bytes() []byte {
+	return w.buf
+}
+
+// Reset clears the buffer of an appending Writer and the pointers it has written so it can be reused.
+func (w *Writer) Reset() {
+	w.buf = w.buf[:0]
+	w.n = 0
+	w.nextRef = firstReferentID
+	w.refs = nil
+	w.deferred = nil
+}
+
+// AcquireWriter returns an appending Writer with an empty buffer from a pool. It should be returned with
+// ReleaseWriter once its Bytes are no longer used.
+func AcquireWriter(opts ...Option) *Writer {
+	w := writerPool.Get().(*Writer)
+	w.opts = newOptions(opts)
+	return w
+}
+
+// ReleaseWriter returns a Writer obtained from AcquireWriter to the pool. Neither the Writer nor its Bytes may be used
+// afterwards.
+func ReleaseWriter(w *Writer) {
+	if cap(w.buf) > maxPooledBuffer {
+		return
+	}
+	w.Reset()
+	writerPool.Put(w)
+}
+
+// AppendMarshal appends the NDR representation of the top-level type v, including the referents of its embedded
+// pointers, to dst and returns the extended buffer.
+func AppendMarshal(dst []byte, v NDRType, opts ...Option) ([]byte, error) {
+	w := NewAppendWriter(dst, opts...)
+	err := v.ToWriter(w)
+	if err != nil {
+		return dst, err
+	}
+	err = w.Deferred()
+	if err != nil {
+		return dst, err
+	}
+	return w.Bytes(), nil
+}
@@ -0,0 +1,46 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendMarshal(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-21-3167651404-3865080224-2280184895-1114")
+	want, err := Marshal(sid)
+	assert.NoError(t, err)
+
+	prefix := []byte{0xaa, 0xbb}
+	b, err := AppendMarshal(prefix, sid)
+	assert.NoError(t, err)
+	assert.Equal(t, prefix, b[:2], "prefix not kept")
+	assert.Equal(t, want, b[2:], "appended encoding not as expected")
+}
+
+func Test_AcquireWriter(t *testing.T) {
+	w := AcquireWriter()
+	assert.NoError(t, w.Uint8(1))
+	assert.NoError(t, w.Align(SizeUint32))
+	assert.NoError(t, w.Uint32(2))
+	assert.Equal(t, []byte{1, 0, 0, 0, 2, 0, 0, 0}, w.Bytes())
+	ReleaseWriter(w)
+
+	w = AcquireWriter()
+	assert.Empty(t, w.Bytes(), "pooled writer not reset")
+	assert.Equal(t, 0, w.Offset(), "pooled writer offset not reset")
+	ReleaseWriter(w)
+}
+
+func Test_AppendWriterAllocs(t *testing.T) {
+	w := NewAppendWriter(make([]byte, 0, 64))
+	allocs := testing.AllocsPerRun(100, func() {
+		w.Reset()
+		w.Uint8(1)
+		w.Align(SizeUint16)
+		w.Uint16(2)
+		w.Uint32(3)
+		w.Uint64(4)
+	})
+	assert.Equal(t, float64(0), allocs, "writing integers allocates")
+}
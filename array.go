@@ -0,0 +1,305 @@
+package mstypes
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Conformant arrays are preceded by their maximum element count and varying arrays by their offset and actual element
+count. Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_03_03
+The counts are 32bit unsigned integers aligned to the conformance alignment of the Reader or Writer.
+A multi-dimensional conformant array is preceded by the maximum counts of all its dimensions, followed by its
+elements in row-major order (the index of the last dimension varies fastest).
+*/
+
+// Conformance reads the maximum element count of a conformant array.
+func (r *Reader) Conformance() (max uint32, err error) {
+	err = r.Align(r.opts.conformanceAlign)
+	if err != nil {
+		return
+	}
+	max, err = r.Uint32()
+	if err != nil {
+		err = fmt.Errorf("could not read conformance max count: %w", err)
+		return
+	}
+	err = r.checkCount(max)
+	return
+}
+
+// Variance reads the offset and actual element count of a varying array.
+func (r *Reader) Variance() (offset, actual uint32, err error) {
+	err = r.Align(r.opts.conformanceAlign)
+	if err != nil {
+		return
+	}
+	offset, err = r.Uint32()
+	if err != nil {
+		err = fmt.Errorf("could not read variance offset: %w", err)
+		return
+	}
+	actual, err = r.Uint32()
+	if err != nil {
+		err = fmt.Errorf("could not read variance actual count: %w", err)
+		return
+	}
+	err = r.checkCount(actual)
+	return
+}
+
+// Conformance writes the maximum element count of a conformant array.
+func (w *Writer) Conformance(max uint32) error {
+	err := w.Align(w.opts.conformanceAlign)
+	if err != nil {
+		return err
+	}
+	return w.Uint32(max)
+}
+
+// Variance writes the offset and actual element count of a varying array.
+func (w *Writer) Variance(offset, actual uint32) error {
+	err := w.Align(w.opts.conformanceAlign)
+	if err != nil {
+		return err
+	}
+	err = w.Uint32(offset)
+	if err != nil {
+		return err
+	}
+	return w.Uint32(actual)
+}
+
+// checkArrayCount returns an error if count, the value of the field name sizing an array, is not n, the number of
+// elements of the array.
+func checkArrayCount(name string, count uint32, n int) error {
+	if uint64(count) != uint64(n) {
+		return fmt.Errorf("%s %d does not match the %d array elements", name, count, n)
+	}
+	return nil
+}
+
+// readCountedPointer reads the pointer of the field name to the array fn reads into v, checking countName, the field
+// holding count, matches the number of elements of the array, 0 for a null pointer. The array is read when
+// r.Deferred is called.
+func readCountedPointer[S ~[]E, E any](r *Reader, name, countName string, count uint32, v *S, fn func() (S, error)) error {
+	*v = nil
+	ok, err := r.fieldPointer(name, func() (err error) {
+		*v, err = fn()
+		if err != nil {
+			return
+		}
+		return checkArrayCount(countName, count, len(*v))
+	})
+	if err == nil && !ok {
+		err = checkArrayCount(countName, count, 0)
+	}
+	return err
+}
+
+// readConformantBytes reads a conformant array of bytes.
+func (r *Reader) readConformantBytes() ([]byte, error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return nil, err
+	}
+	n, err := r.Allocate(max, SizeUint8)
+	if err != nil {
+		return nil, err
+	}
+	return r.ReadBytes(n)
+}
+
+// writeConformantBytes writes b as a conformant array of bytes.
+func (w *Writer) writeConformantBytes(b []byte) error {
+	err := w.Conformance(uint32(len(b)))
+	if err != nil {
+		return err
+	}
+	return w.WriteBytes(b)
+}
+
+// readConformantUint32s reads a conformant array of 32bit integers.
+func (r *Reader) readConformantUint32s() ([]uint32, error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return nil, err
+	}
+	n, err := r.Allocate(max, SizeUint32)
+	if err != nil {
+		return nil, err
+	}
+	v := make([]uint32, n)
+	for i := range v {
+		v[i], err = r.Uint32()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// writeConformantUint32s writes v as a conformant array of 32bit integers.
+func (w *Writer) writeConformantUint32s(v []uint32) error {
+	err := w.Conformance(uint32(len(v)))
+	if err != nil {
+		return err
+	}
+	for _, u := range v {
+		err = w.Uint32(u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readConformantUint64s reads a conformant array of 64bit integers.
+func (r *Reader) readConformantUint64s() ([]uint64, error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return nil, err
+	}
+	n, err := r.Allocate(max, SizeUint64)
+	if err != nil {
+		return nil, err
+	}
+	err = r.Align(SizeUint64)
+	if err != nil {
+		return nil, err
+	}
+	v := make([]uint64, n)
+	for i := range v {
+		v[i], err = r.Uint64()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// writeConformantUint64s writes v as a conformant array of 64bit integers.
+func (w *Writer) writeConformantUint64s(v []uint64) error {
+	err := w.Conformance(uint32(len(v)))
+	if err != nil {
+		return err
+	}
+	err = w.Align(SizeUint64)
+	if err != nil {
+		return err
+	}
+	for _, u := range v {
+		err = w.Uint64(u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Conformances reads the maximum element counts of the n dimensions of a multi-dimensional conformant array.
+func (r *Reader) Conformances(n int) (max []uint32, err error) {
+	max = make([]uint32, n)
+	for i := range max {
+		max[i], err = r.Conformance()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Conformances writes the maximum element counts of the dimensions of a multi-dimensional conformant array.
+func (w *Writer) Conformances(max ...uint32) error {
+	for _, m := range max {
+		err := w.Conformance(m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadConformantArray reads a conformant array calling fn to read each element. elemSize is the size of an element
+// used to check the array against the decode limits of r.
+func ReadConformantArray[T any](r *Reader, elemSize int, fn func(*T) error) ([]T, error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return nil, err
+	}
+	return ReadArray(r, max, elemSize, fn)
+}
+
+// ReadMultiDimensionalArray reads a multi-dimensional conformant array with dims dimensions calling fn to read each
+// element. The elements are returned in row-major order along with the maximum count of each dimension.
+func ReadMultiDimensionalArray[T any](r *Reader, dims int, elemSize int, fn func(*T) error) ([]T, []uint32, error) {
+	max, err := r.Conformances(dims)
+	if err != nil {
+		return nil, nil, err
+	}
+	count := uint64(1)
+	for _, m := range max {
+		count *= uint64(m)
+		if count > math.MaxUint32 {
+			return nil, nil, fmt.Errorf("multi-dimensional array dimensions %v overflow the element count: %w", max, ErrDecodeLimit)
+		}
+	}
+	v, err := ReadArray(r, uint32(count), elemSize, fn)
+	return v, max, err
+}
+
+// ReadArray reads an array of count elements calling fn to read each element, for example once the count of a
+// conformant array has been read from the start of the enclosing structure.
+func ReadArray[T any](r *Reader, count uint32, elemSize int, fn func(*T) error) ([]T, error) {
+	n, err := r.Allocate(count, elemSize)
+	if err != nil {
+		return nil, err
+	}
+	v := make([]T, n)
+	for i := range v {
+		err = r.Element(i, func() error {
+			return fn(&v[i])
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// WriteConformantArray writes v as a conformant array calling fn to write each element.
+func WriteConformantArray[T any](w *Writer, v []T, fn func(*T) error) error {
+	err := w.Conformance(uint32(len(v)))
+	if err != nil {
+		return err
+	}
+	return WriteArray(w, v, fn)
+}
+
+// WriteMultiDimensionalArray writes v, in row-major order, as a multi-dimensional conformant array with the maximum
+// counts max calling fn to write each element.
+func WriteMultiDimensionalArray[T any](w *Writer, max []uint32, v []T, fn func(*T) error) error {
+	count := uint64(1)
+	for _, m := range max {
+		count *= uint64(m)
+	}
+	if count != uint64(len(v)) {
+		return fmt.Errorf("multi-dimensional array dimensions %v do not match its %d elements", max, len(v))
+	}
+	err := w.Conformances(max...)
+	if err != nil {
+		return err
+	}
+	return WriteArray(w, v, fn)
+}
+
+// WriteArray writes the elements of v calling fn to write each element.
+func WriteArray[T any](w *Writer, v []T, fn func(*T) error) error {
+	for i := range v {
+		err := fn(&v[i])
+		if err != nil {
+			return fmt.Errorf("could not write array element %d: %v", i, err)
+		}
+	}
+	return nil
+}
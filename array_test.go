@@ -0,0 +1,67 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MultiDimensionalArray(t *testing.T) {
+	v := []uint16{1, 2, 3, 4, 5, 6}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	err := WriteMultiDimensionalArray(w, []uint32{2, 3}, v, func(e *uint16) error {
+		return w.Uint16(*e)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "0200000003000000"+"010002000300040005000600", hex.EncodeToString(buf.Bytes()))
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	got, max, err := ReadMultiDimensionalArray(r, 2, SizeUint16, func(e *uint16) (err error) {
+		*e, err = r.Uint16()
+		return
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 3}, max)
+	assert.Equal(t, v, got)
+
+	err = WriteMultiDimensionalArray(w, []uint32{2, 2}, v, func(e *uint16) error {
+		return w.Uint16(*e)
+	})
+	assert.Error(t, err, "dimensions not matching the elements not detected")
+}
+
+func Test_MultiDimensionalArrayLimits(t *testing.T) {
+	b, _ := hex.DecodeString("0000100000001000")
+	r := NewReader(bytes.NewReader(b))
+	_, _, err := ReadMultiDimensionalArray(r, 2, SizeUint8, func(e *uint8) (err error) {
+		*e, err = r.Uint8()
+		return
+	})
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "element count product not limited: %v", err)
+}
+
+func Test_ConformantArray(t *testing.T) {
+	v := []uint32{7, 8}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, w.Uint8(1))
+	err := WriteConformantArray(w, v, func(e *uint32) error {
+		return w.Uint32(*e)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "01000000"+"02000000"+"0700000008000000", hex.EncodeToString(buf.Bytes()))
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	_, err = r.Uint8()
+	assert.NoError(t, err)
+	got, err := ReadConformantArray(r, SizeUint32, func(e *uint32) (err error) {
+		*e, err = r.Uint32()
+		return
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, v, got)
+}
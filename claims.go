@@ -1,13 +1,9 @@
 package mstypes
 
 import (
-	"bytes"
-	"encoding/hex"
 	"errors"
 	"fmt"
-
-	"github.com/jfjallid/ndr"
-	"golang.org/x/net/http2/hpack"
+	"io"
 )
 
 // Compression format assigned numbers. https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-xca/a8b7cb0a-92a6-4187-a23b-5e14273b96f8
@@ -18,8 +14,42 @@ const (
 	CompressionFormatXPressHuff uint16 = 4 // LZ77+Huffman - The Huffman variant of the XPRESS compression format uses LZ77-style dictionary compression combined with Huffman coding.
 )
 
-// ClaimsSourceTypeAD https://msdn.microsoft.com/en-us/library/hh553809.aspx
-const ClaimsSourceTypeAD uint16 = 1
+// ClaimsSourceType is the CLAIMS_SOURCE_TYPE of a claims array, the source of its claims.
+// https://msdn.microsoft.com/en-us/library/hh553809.aspx
+type ClaimsSourceType uint16
+
+// Claims source types
+const (
+	ClaimsSourceTypeAD          ClaimsSourceType = 1 // claims from Active Directory
+	ClaimsSourceTypeCertificate ClaimsSourceType = 2 // claims from a certificate
+)
+
+func (t ClaimsSourceType) String() string {
+	switch t {
+	case ClaimsSourceTypeAD:
+		return "AD"
+	case ClaimsSourceTypeCertificate:
+		return "CERTIFICATE"
+	}
+	return fmt.Sprintf("ClaimsSourceType(%d)", uint16(t))
+}
+
+// Well-known claim IDs. The IDs of the claim types defined in Active Directory are ad://ext/ followed by the name of
+// the claim type, and for claim types sourced from an attribute a suffix made unique by the domain controller.
+const (
+	ClaimIDAuthenticationSilo = "ad://ext/AuthenticationSilo" // name of the authentication silo of the principal
+)
+
+// wellKnownClaimIDs are the descriptions of the well-known claim IDs.
+var wellKnownClaimIDs = map[string]string{
+	ClaimIDAuthenticationSilo: "Authentication silo",
+}
+
+// ClaimIDDescription returns the description of the well-known claim ID id, false if it is not one.
+func ClaimIDDescription(id string) (string, bool) {
+	d, ok := wellKnownClaimIDs[id]
+	return d, ok
+}
 
 // Claim Type assigned numbers
 const (
@@ -29,6 +59,12 @@ const (
 	ClaimsTypeIDBoolean uint16 = 6
 )
 
+// ErrUnknownClaimType is returned, wrapped, decoding or encoding a claim entry of a type other than the claim types
+// above. The layout of the values of a claim depends on its type, so the claims set cannot be read past the entry.
+// ClaimsSetMetadata holds the encoded claims set as it is, so a PAC or claims blob holding it is still re-emitted
+// unchanged; only its ClaimsSet cannot be decoded.
+var ErrUnknownClaimType = errors.New("unknown claim type")
+
 // ClaimsBlob implements https://msdn.microsoft.com/en-us/library/hh554119.aspx
 type ClaimsBlob struct {
 	Size        uint32
@@ -55,37 +91,84 @@ type ClaimsSetMetadata struct {
 	ReservedField             []byte `ndr:"pointer,conformant"`
 }
 
+// ClaimsCompressionThreshold is the size, in bytes, from which a domain controller compresses an encoded claims set.
+const ClaimsCompressionThreshold = 384
+
+// NewClaimsSetMetadata returns the uncompressed ClaimsSetMetadata holding the claims set c.
+func NewClaimsSetMetadata(c *ClaimsSet) (m ClaimsSetMetadata, err error) {
+	err = m.SetClaimsSet(c)
+	return
+}
+
+// NewCompressedClaimsSetMetadata returns the ClaimsSetMetadata holding the claims set c compressed with format if its
+// encoding is at least ClaimsCompressionThreshold bytes. CompressionFormatXPressHuff matches the claims a domain
+// controller emits.
+func NewCompressedClaimsSetMetadata(c *ClaimsSet, format uint16) (m ClaimsSetMetadata, err error) {
+	err = m.SetCompressedClaimsSet(c, format)
+	return
+}
+
+// SetClaimsSet stores the claims set c uncompressed in the ClaimsSetMetadata, setting its sizes and compression
+// format.
+func (m *ClaimsSetMetadata) SetClaimsSet(c *ClaimsSet) error {
+	return m.SetCompressedClaimsSet(c, CompressionFormatNone)
+}
+
+// SetCompressedClaimsSet stores the claims set c in the ClaimsSetMetadata, compressed with format if its encoding is
+// at least ClaimsCompressionThreshold bytes and uncompressed otherwise, setting its sizes and compression format.
+func (m *ClaimsSetMetadata) SetCompressedClaimsSet(c *ClaimsSet, format uint16) error {
+	b, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	m.UncompressedClaimsSetSize = uint32(len(b))
+	if len(b) < ClaimsCompressionThreshold {
+		format = CompressionFormatNone
+	}
+	b, err = Compress(format, b)
+	if err != nil {
+		return err
+	}
+	m.ClaimsSetSize = uint32(len(b))
+	m.ClaimsSetBytes = b
+	m.CompressionFormat = format
+	return nil
+}
+
 // ClaimsSet reads the ClaimsSet type from the NDR encoded ClaimsSetBytes in the ClaimsSetMetadata
 func (m *ClaimsSetMetadata) ClaimsSet() (c ClaimsSet, err error) {
 	if len(m.ClaimsSetBytes) < 1 {
 		err = errors.New("no bytes available for ClaimsSet")
 		return
 	}
-	// TODO switch statement to decompress ClaimsSetBytes
+	b := m.ClaimsSetBytes
 	switch m.CompressionFormat {
-	case CompressionFormatLZNT1:
-		s := hex.EncodeToString(m.ClaimsSetBytes)
-		err = fmt.Errorf("ClaimsSet compressed, format LZNT1 not currently supported: %s", s)
-		return
-	case CompressionFormatXPress:
-		s := hex.EncodeToString(m.ClaimsSetBytes)
-		err = fmt.Errorf("ClaimsSet compressed, format XPress not currently supported: %s", s)
-		return
-	case CompressionFormatXPressHuff:
-		var b []byte
-		buff := bytes.NewBuffer(b)
-		_, e := hpack.HuffmanDecode(buff, m.ClaimsSetBytes)
-		if e != nil {
-			err = fmt.Errorf("error deflating: %v", e)
+	case CompressionFormatNone:
+	case CompressionFormatLZNT1, CompressionFormatXPress, CompressionFormatXPressHuff:
+		b, err = Decompress(m.CompressionFormat, m.ClaimsSetBytes, int(m.UncompressedClaimsSetSize))
+		if err != nil {
+			err = fmt.Errorf("decompressing ClaimsSet: %w", err)
 			return
 		}
-		m.ClaimsSetBytes = buff.Bytes()
+	default:
+		err = fmt.Errorf("unknown ClaimsSet compression format %d", m.CompressionFormat)
+		return
 	}
-	dec := ndr.NewDecoder(bytes.NewReader(m.ClaimsSetBytes), true)
-	err = dec.Decode(&c)
+	err = c.UnmarshalBinary(b)
 	return
 }
 
+// UnmarshalBinary reads the ClaimsSetMetadata from the type serialized b, the data of a PAC claims buffer or the
+// EncodedBlob of a ClaimsBlob.
+func (m *ClaimsSetMetadata) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, m)
+}
+
+// MarshalBinary returns the ClaimsSetMetadata type serialized.
+func (m *ClaimsSetMetadata) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(m)
+}
+
 // ClaimsSet implements https://msdn.microsoft.com/en-us/library/hh554122.aspx
 type ClaimsSet struct {
 	ClaimsArrayCount  uint32
@@ -97,7 +180,7 @@ type ClaimsSet struct {
 
 // ClaimsArray implements https://msdn.microsoft.com/en-us/library/hh536458.aspx
 type ClaimsArray struct {
-	ClaimsSourceType uint16
+	ClaimsSourceType ClaimsSourceType
 	ClaimsCount      uint32
 	ClaimEntries     []ClaimEntry `ndr:"pointer,conformant"`
 }
@@ -150,3 +233,621 @@ type ClaimTypeBoolean struct {
 	ValueCount uint32
 	Value      []bool `ndr:"pointer,conformant"`
 }
+
+// FromReader reads the ClaimsBlob from r.
+func (b *ClaimsBlob) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	b.Size, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	n, err := r.Allocate(b.Size, SizeUint8)
+	if err != nil {
+		return
+	}
+	b.EncodedBlob, err = r.ReadBytes(n)
+	return
+}
+
+// ToWriter writes the ClaimsBlob to w.
+func (b *ClaimsBlob) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(uint32(len(b.EncodedBlob)))
+	if err != nil {
+		return
+	}
+	return nw.WriteBytes(b.EncodedBlob)
+}
+
+// NewClaimsBlob returns the ClaimsBlob encoding the ClaimsSetMetadata m.
+func NewClaimsBlob(m *ClaimsSetMetadata) (ClaimsBlob, error) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return ClaimsBlob{}, err
+	}
+	return ClaimsBlob{Size: uint32(len(b)), EncodedBlob: b}, nil
+}
+
+// ClaimsSetMetadata returns the ClaimsSetMetadata encoded in the EncodedBlob.
+func (b *ClaimsBlob) ClaimsSetMetadata() (m ClaimsSetMetadata, err error) {
+	err = m.UnmarshalBinary(b.EncodedBlob)
+	return
+}
+
+// ClaimsBlobSize returns the number of bytes of the NDR representation of ClaimsBlob. It is not named Size as the
+// EncodedBlob field already uses that name.
+func (b *ClaimsBlob) ClaimsBlobSize() int {
+	return SizeUint32 + len(b.EncodedBlob)
+}
+
+// FromReader reads the ClaimsSetMetadata from r. The referents of its pointers are read when r.Deferred is called.
+func (m *ClaimsSetMetadata) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	m.ClaimsSetSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("ClaimsSetBytes", func() (err error) {
+		m.ClaimsSetBytes, err = r.readConformantBytes()
+		return
+	})
+	if err != nil {
+		return
+	}
+	m.CompressionFormat, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	m.UncompressedClaimsSetSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	m.ReservedType, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	m.ReservedFieldSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("ReservedField", func() (err error) {
+		m.ReservedField, err = r.readConformantBytes()
+		return
+	})
+	return
+}
+
+// ToWriter writes the ClaimsSetMetadata to w.
+func (m *ClaimsSetMetadata) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(m.ClaimsSetSize)
+	if err != nil {
+		return
+	}
+	err = nw.Pointer(conformantBytesFn(nw, m.ClaimsSetBytes))
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(m.CompressionFormat)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(m.UncompressedClaimsSetSize)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(m.ReservedType)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(m.ReservedFieldSize)
+	if err != nil {
+		return
+	}
+	err = nw.Pointer(conformantBytesFn(nw, m.ReservedField))
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimsSetMetadata.
+func (m *ClaimsSetMetadata) Size() int {
+	return ndrSize(m)
+}
+
+// conformantBytesFn returns the function writing b as the referent of a pointer, or nil if b is nil.
+func conformantBytesFn(w *Writer, b []byte) func() error {
+	if b == nil {
+		return nil
+	}
+	return func() error {
+		return w.writeConformantBytes(b)
+	}
+}
+
+// FromReader reads the ClaimsSet from r. The referents of its pointers are read when r.Deferred is called.
+func (c *ClaimsSet) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ClaimsArrayCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("ClaimsArrays", func() (err error) {
+		c.ClaimsArrays, err = ReadConformantArray(r, 12, func(a *ClaimsArray) error {
+			return a.FromReader(r)
+		})
+		return
+	})
+	if err != nil {
+		return
+	}
+	c.ReservedType, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ReservedFieldSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("ReservedField", func() (err error) {
+		c.ReservedField, err = r.readConformantBytes()
+		return
+	})
+	return
+}
+
+// ToWriter writes the ClaimsSet to w.
+func (c *ClaimsSet) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(c.ClaimsArrayCount)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if c.ClaimsArrays != nil {
+		fn = func() error {
+			err := nw.Conformance(uint32(len(c.ClaimsArrays)))
+			if err != nil {
+				return err
+			}
+			for i := range c.ClaimsArrays {
+				err = c.ClaimsArrays[i].ToWriter(nw)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(c.ReservedType)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(c.ReservedFieldSize)
+	if err != nil {
+		return
+	}
+	err = nw.Pointer(conformantBytesFn(nw, c.ReservedField))
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimsSet.
+func (c *ClaimsSet) Size() int {
+	return ndrSize(c)
+}
+
+// UnmarshalBinary reads the ClaimsSet from the type serialized b, the uncompressed ClaimsSetBytes of a
+// ClaimsSetMetadata.
+func (c *ClaimsSet) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, c)
+}
+
+// MarshalBinary returns the ClaimsSet type serialized.
+func (c *ClaimsSet) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(c)
+}
+
+// FromReader reads the ClaimsArray from r. The claim entries are read when r.Deferred is called.
+func (a *ClaimsArray) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	var t uint16
+	t, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	a.ClaimsSourceType = ClaimsSourceType(t)
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	a.ClaimsCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("ClaimEntries", func() (err error) {
+		a.ClaimEntries, err = ReadConformantArray(r, 16, func(e *ClaimEntry) error {
+			return e.FromReader(r)
+		})
+		return
+	})
+	return
+}
+
+// ToWriter writes the ClaimsArray to w.
+func (a *ClaimsArray) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(uint16(a.ClaimsSourceType))
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(a.ClaimsCount)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if a.ClaimEntries != nil {
+		fn = func() error {
+			err := nw.Conformance(uint32(len(a.ClaimEntries)))
+			if err != nil {
+				return err
+			}
+			for i := range a.ClaimEntries {
+				err = a.ClaimEntries[i].ToWriter(nw)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimsArray.
+func (a *ClaimsArray) Size() int {
+	return ndrSize(a)
+}
+
+// arm returns the union field selected by the claim type tag.
+func (u *ClaimEntry) arm(tag uint32) (NDRType, error) {
+	switch uint16(tag) {
+	case ClaimTypeIDInt64:
+		return &u.TypeInt64, nil
+	case ClaimTypeIDUInt64:
+		return &u.TypeUInt64, nil
+	case ClaimTypeIDString:
+		return &u.TypeString, nil
+	case ClaimsTypeIDBoolean:
+		return &u.TypeBool, nil
+	}
+	return nil, fmt.Errorf("%w %d", ErrUnknownClaimType, tag)
+}
+
+// Values returns the values of the claim as a Go slice of the type selected by Type: []int64, []uint64, []string or
+// []bool. It returns nil for an unknown claim type.
+func (u *ClaimEntry) Values() interface{} {
+	switch u.Type {
+	case ClaimTypeIDInt64:
+		return u.TypeInt64.Value
+	case ClaimTypeIDUInt64:
+		return u.TypeUInt64.Value
+	case ClaimTypeIDString:
+		return u.TypeString.Strings()
+	case ClaimsTypeIDBoolean:
+		return u.TypeBool.Value
+	}
+	return nil
+}
+
+// FromReader reads the ClaimEntry from r. The referents of its pointers are read when r.Deferred is called.
+func (u *ClaimEntry) FromReader(r *Reader) (err error) {
+	_, err = r.fieldPointer("ID", func() (err error) {
+		u.ID, err = r.ConformantVaryingString(true)
+		return
+	})
+	if err != nil {
+		return
+	}
+	u.Type, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	return r.NonEncapsulatedUnion(SizeUint16, uint32(u.Type), u.arm)
+}
+
+// ToWriter writes the ClaimEntry to w.
+func (u *ClaimEntry) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	_, err = u.arm(uint32(u.Type))
+	if err != nil {
+		return
+	}
+	err = nw.Pointer(func() error {
+		return nw.ConformantVaryingString(u.ID, true)
+	})
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(u.Type)
+	if err != nil {
+		return
+	}
+	err = nw.NonEncapsulatedUnion(SizeUint16, uint32(u.Type), u.arm)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimEntry.
+func (u *ClaimEntry) Size() int {
+	return ndrSize(u)
+}
+
+// FromReader reads the ClaimTypeInt64 from r. The values are read when r.Deferred is called.
+func (c *ClaimTypeInt64) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ValueCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Value", func() error {
+		v, err := r.readConformantUint64s()
+		if err != nil {
+			return err
+		}
+		c.Value = make([]int64, len(v))
+		for i := range v {
+			c.Value[i] = int64(v[i])
+		}
+		return nil
+	})
+	return
+}
+
+// ToWriter writes the ClaimTypeInt64 to w.
+func (c *ClaimTypeInt64) ToWriter(w io.Writer) (err error) {
+	v := make([]uint64, len(c.Value))
+	for i := range c.Value {
+		v[i] = uint64(c.Value[i])
+	}
+	return writeClaimValues(w, c.ValueCount, v)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimTypeInt64.
+func (c *ClaimTypeInt64) Size() int {
+	return ndrSize(c)
+}
+
+// FromReader reads the ClaimTypeUInt64 from r. The values are read when r.Deferred is called.
+func (c *ClaimTypeUInt64) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ValueCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Value", func() (err error) {
+		c.Value, err = r.readConformantUint64s()
+		return
+	})
+	return
+}
+
+// ToWriter writes the ClaimTypeUInt64 to w.
+func (c *ClaimTypeUInt64) ToWriter(w io.Writer) (err error) {
+	return writeClaimValues(w, c.ValueCount, c.Value)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimTypeUInt64.
+func (c *ClaimTypeUInt64) Size() int {
+	return ndrSize(c)
+}
+
+// FromReader reads the ClaimTypeBoolean from r. The values, transmitted as 64bit integers, are read when r.Deferred
+// is called.
+func (c *ClaimTypeBoolean) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ValueCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Value", func() error {
+		v, err := r.readConformantUint64s()
+		if err != nil {
+			return err
+		}
+		c.Value = make([]bool, len(v))
+		for i := range v {
+			c.Value[i] = v[i] != 0
+		}
+		return nil
+	})
+	return
+}
+
+// ToWriter writes the ClaimTypeBoolean to w.
+func (c *ClaimTypeBoolean) ToWriter(w io.Writer) (err error) {
+	v := make([]uint64, len(c.Value))
+	for i := range c.Value {
+		if c.Value[i] {
+			v[i] = 1
+		}
+	}
+	return writeClaimValues(w, c.ValueCount, v)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimTypeBoolean.
+func (c *ClaimTypeBoolean) Size() int {
+	return ndrSize(c)
+}
+
+// writeClaimValues writes the value count and pointer to the conformant array of 64bit claim values.
+func writeClaimValues(w io.Writer, count uint32, v []uint64) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(count)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if v != nil {
+		fn = func() error {
+			return nw.writeConformantUint64s(v)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// FromReader reads the ClaimTypeString from r. The values are read when r.Deferred is called.
+func (c *ClaimTypeString) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	c.ValueCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Value", func() (err error) {
+		c.Value, err = ReadConformantArray(r, SizePtr, func(s *LPWSTR) error {
+			return s.FromReader(r)
+		})
+		return
+	})
+	return
+}
+
+// Strings returns the values of the claim.
+func (c *ClaimTypeString) Strings() []string {
+	if c.Value == nil {
+		return nil
+	}
+	v := make([]string, len(c.Value))
+	for i := range c.Value {
+		v[i] = c.Value[i].Value
+	}
+	return v
+}
+
+// ToWriter writes the ClaimTypeString to w.
+func (c *ClaimTypeString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(c.ValueCount)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if c.Value != nil {
+		fn = func() error {
+			err := nw.Conformance(uint32(len(c.Value)))
+			if err != nil {
+				return err
+			}
+			for i := range c.Value {
+				err = c.Value[i].ToWriter(nw)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of ClaimTypeString.
+func (c *ClaimTypeString) Size() int {
+	return ndrSize(c)
+}
@@ -0,0 +1,121 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned, wrapped, by GetClaim
+var (
+	ErrClaimNotFound = errors.New("claim not found")
+	ErrClaimType     = errors.New("claim type mismatch")
+)
+
+// ClaimValue is the Go type of the values of a claim type.
+type ClaimValue interface {
+	int64 | uint64 | string | bool
+}
+
+// Entry returns the first claim entry with the ID id in any of the claims arrays, nil if there is none.
+func (c *ClaimsSet) Entry(id string) *ClaimEntry {
+	for i := range c.ClaimsArrays {
+		a := &c.ClaimsArrays[i]
+		for j := range a.ClaimEntries {
+			if a.ClaimEntries[j].ID == id {
+				return &a.ClaimEntries[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ToMap returns the values of the claims by claim ID, each an int64, uint64, string or bool. Like Entry, an ID held
+// more than once, in one claims array or in several, maps to the values of its first entry; the later entries are
+// dropped whatever their type. Claims of an unknown type have no values.
+func (c *ClaimsSet) ToMap() map[string][]interface{} {
+	m := make(map[string][]interface{})
+	for i := range c.ClaimsArrays {
+		a := &c.ClaimsArrays[i]
+		for j := range a.ClaimEntries {
+			e := &a.ClaimEntries[j]
+			if _, ok := m[e.ID]; ok {
+				continue
+			}
+			var v []interface{}
+			switch e.Type {
+			case ClaimTypeIDInt64:
+				v = appendValues(v, e.TypeInt64.Value)
+			case ClaimTypeIDUInt64:
+				v = appendValues(v, e.TypeUInt64.Value)
+			case ClaimTypeIDString:
+				v = appendValues(v, e.TypeString.Strings())
+			case ClaimsTypeIDBoolean:
+				v = appendValues(v, e.TypeBool.Value)
+			}
+			m[e.ID] = v
+		}
+	}
+	return m
+}
+
+// appendValues appends the values of s to v.
+func appendValues[T any](v []interface{}, s []T) []interface{} {
+	for _, e := range s {
+		v = append(v, e)
+	}
+	return v
+}
+
+// GetString returns the values of the first claim id, false if there is none or it is not of type string.
+func (c *ClaimsSet) GetString(id string) ([]string, bool) {
+	v, err := GetClaim[string](c, id)
+	return v, err == nil
+}
+
+// GetInt64 returns the values of the first claim id, false if there is none or it is not of type int64.
+func (c *ClaimsSet) GetInt64(id string) ([]int64, bool) {
+	v, err := GetClaim[int64](c, id)
+	return v, err == nil
+}
+
+// GetUint64 returns the values of the first claim id, false if there is none or it is not of type uint64.
+func (c *ClaimsSet) GetUint64(id string) ([]uint64, bool) {
+	v, err := GetClaim[uint64](c, id)
+	return v, err == nil
+}
+
+// GetBool returns the values of the first claim id, false if there is none or it is not of type boolean.
+func (c *ClaimsSet) GetBool(id string) ([]bool, bool) {
+	v, err := GetClaim[bool](c, id)
+	return v, err == nil
+}
+
+// GetClaim returns the values of the first claim id of c as T, failing with ErrClaimNotFound if there is none and
+// with ErrClaimType if its values are not of type T.
+func GetClaim[T ClaimValue](c *ClaimsSet, id string) ([]T, error) {
+	e := c.Entry(id)
+	if e == nil {
+		return nil, fmt.Errorf("%w: %s", ErrClaimNotFound, id)
+	}
+	v, ok := e.Values().([]T)
+	if !ok {
+		return nil, fmt.Errorf("%w: claim %s holds %s values, not %T", ErrClaimType, id, claimTypeName(e.Type),
+			*new(T))
+	}
+	return v, nil
+}
+
+// claimTypeName returns the name of the claim type t.
+func claimTypeName(t uint16) string {
+	switch t {
+	case ClaimTypeIDInt64:
+		return "int64"
+	case ClaimTypeIDUInt64:
+		return "uint64"
+	case ClaimTypeIDString:
+		return "string"
+	case ClaimsTypeIDBoolean:
+		return "bool"
+	}
+	return fmt.Sprintf("unknown type %d", t)
+}
@@ -0,0 +1,90 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClaimsSetToMap(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoMulti)
+	var m ClaimsSetMetadata
+	err := m.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string][]interface{}{
+		ClaimsEntryIDInt64: {ClaimsEntryValueInt64},
+		ClaimsEntryIDStr:   {ClaimsEntryValueStr},
+	}, c.ToMap(), "claims map not as expected")
+
+	s, ok := c.GetString(ClaimsEntryIDStr)
+	assert.True(t, ok, "string claim not found")
+	assert.Equal(t, []string{ClaimsEntryValueStr}, s, "string claim not as expected")
+	i, ok := c.GetInt64(ClaimsEntryIDInt64)
+	assert.True(t, ok, "int64 claim not found")
+	assert.Equal(t, []int64{ClaimsEntryValueInt64}, i, "int64 claim not as expected")
+	_, ok = c.GetInt64(ClaimsEntryIDStr)
+	assert.False(t, ok, "string claim found as int64")
+	_, ok = c.GetBool("ad://ext/missing")
+	assert.False(t, ok, "missing claim found")
+	assert.Nil(t, c.Entry("ad://ext/missing"), "missing entry found")
+}
+
+func Test_ClaimsSetGet(t *testing.T) {
+	c := ClaimsSet{
+		ClaimsArrays: []ClaimsArray{
+			{ClaimEntries: []ClaimEntry{
+				{ID: "a", Type: ClaimsTypeIDBoolean, TypeBool: ClaimTypeBoolean{ValueCount: 1, Value: []bool{true}}},
+				{ID: "u", Type: ClaimTypeIDUInt64, TypeUInt64: ClaimTypeUInt64{ValueCount: 2, Value: []uint64{1, 2}}},
+				{ID: "x", Type: 9},
+			}},
+			{ClaimEntries: []ClaimEntry{
+				{ID: "a", Type: ClaimsTypeIDBoolean, TypeBool: ClaimTypeBoolean{ValueCount: 1, Value: []bool{false}}},
+				{ID: "u", Type: ClaimTypeIDInt64, TypeInt64: ClaimTypeInt64{ValueCount: 1, Value: []int64{3}}},
+			}},
+		},
+	}
+	assert.Equal(t, map[string][]interface{}{
+		"a": {true},
+		"u": {uint64(1), uint64(2)},
+		"x": nil,
+	}, c.ToMap(), "claims map not as expected")
+	v, ok := c.GetBool("a")
+	assert.True(t, ok, "boolean claim not found")
+	assert.Equal(t, []bool{true}, v, "boolean claim not as expected")
+	u, ok := c.GetUint64("u")
+	assert.True(t, ok, "uint64 claim not found")
+	assert.Equal(t, []uint64{1, 2}, u, "uint64 claim not as expected")
+}
+
+func Test_GetClaim(t *testing.T) {
+	c, err := NewClaimsSet(map[string]interface{}{
+		ClaimsEntryIDStr:   ClaimsEntryValueStr,
+		ClaimsEntryIDInt64: ClaimsEntryValueInt64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := GetClaim[string](&c, ClaimsEntryIDStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{ClaimsEntryValueStr}, s, "string claim not as expected")
+	i, err := GetClaim[int64](&c, ClaimsEntryIDInt64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []int64{ClaimsEntryValueInt64}, i, "int64 claim not as expected")
+
+	_, err = GetClaim[int64](&c, ClaimsEntryIDStr)
+	assert.ErrorIs(t, err, ErrClaimType)
+	assert.EqualError(t, err, "claim type mismatch: claim "+ClaimsEntryIDStr+" holds string values, not int64")
+	_, err = GetClaim[bool](&c, "ad://ext/missing")
+	assert.ErrorIs(t, err, ErrClaimNotFound)
+}
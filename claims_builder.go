@@ -0,0 +1,167 @@
+package mstypes
+
+import (
+	"fmt"
+	"slices"
+)
+
+// NewClaimEntry returns the claim entry id holding values, whose type selects the claim type: a signed integer or
+// slice of them an int64 claim, an unsigned integer or slice of them a uint64 claim, a string or []string a string
+// claim and a bool or []bool a boolean claim.
+func NewClaimEntry(id string, values interface{}) (ClaimEntry, error) {
+	e := ClaimEntry{ID: id}
+	switch v := values.(type) {
+	case int, int8, int16, int32, int64, []int, []int8, []int16, []int32, []int64:
+		e.Type = ClaimTypeIDInt64
+		e.TypeInt64.Value = int64Values(v)
+		e.TypeInt64.ValueCount = uint32(len(e.TypeInt64.Value))
+	case uint, uint8, uint16, uint32, uint64, []uint, []uint16, []uint32, []uint64:
+		e.Type = ClaimTypeIDUInt64
+		e.TypeUInt64.Value = uint64Values(v)
+		e.TypeUInt64.ValueCount = uint32(len(e.TypeUInt64.Value))
+	case string, []string:
+		s, ok := v.([]string)
+		if !ok {
+			s = []string{v.(string)}
+		}
+		e.Type = ClaimTypeIDString
+		e.TypeString.Value = make([]LPWSTR, len(s))
+		for i := range s {
+			e.TypeString.Value[i] = LPWSTR{s[i]}
+		}
+		e.TypeString.ValueCount = uint32(len(s))
+	case bool:
+		e.Type = ClaimsTypeIDBoolean
+		e.TypeBool = ClaimTypeBoolean{ValueCount: 1, Value: []bool{v}}
+	case []bool:
+		e.Type = ClaimsTypeIDBoolean
+		e.TypeBool = ClaimTypeBoolean{ValueCount: uint32(len(v)), Value: v}
+	default:
+		return e, fmt.Errorf("claim %s: values of type %T are not a claim type", id, values)
+	}
+	return e, nil
+}
+
+// int64Values returns the signed integer or slice of signed integers v as []int64.
+func int64Values(v interface{}) []int64 {
+	switch v := v.(type) {
+	case int:
+		return []int64{int64(v)}
+	case int8:
+		return []int64{int64(v)}
+	case int16:
+		return []int64{int64(v)}
+	case int32:
+		return []int64{int64(v)}
+	case int64:
+		return []int64{v}
+	case []int:
+		return convertValues[int, int64](v)
+	case []int8:
+		return convertValues[int8, int64](v)
+	case []int16:
+		return convertValues[int16, int64](v)
+	case []int32:
+		return convertValues[int32, int64](v)
+	case []int64:
+		return v
+	}
+	return nil
+}
+
+// uint64Values returns the unsigned integer or slice of unsigned integers v as []uint64.
+func uint64Values(v interface{}) []uint64 {
+	switch v := v.(type) {
+	case uint:
+		return []uint64{uint64(v)}
+	case uint8:
+		return []uint64{uint64(v)}
+	case uint16:
+		return []uint64{uint64(v)}
+	case uint32:
+		return []uint64{uint64(v)}
+	case uint64:
+		return []uint64{v}
+	case []uint:
+		return convertValues[uint, uint64](v)
+	case []uint16:
+		return convertValues[uint16, uint64](v)
+	case []uint32:
+		return convertValues[uint32, uint64](v)
+	case []uint64:
+		return v
+	}
+	return nil
+}
+
+// convertValues returns the integers of s converted to T.
+func convertValues[S, T int | int8 | int16 | int32 | int64 | uint | uint16 | uint32 | uint64](s []S) []T {
+	v := make([]T, len(s))
+	for i := range s {
+		v[i] = T(s[i])
+	}
+	return v
+}
+
+// NewClaimsSet returns the claims set of the claims of source type ClaimsSourceTypeAD with the values of the map by
+// claim ID, of the types NewClaimEntry accepts. The entries are sorted by ID.
+func NewClaimsSet(claims map[string]interface{}) (ClaimsSet, error) {
+	var b ClaimsSetBuilder
+	ids := make([]string, 0, len(claims))
+	for id := range claims {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		b.Add(ClaimsSourceTypeAD, id, claims[id])
+	}
+	return b.ClaimsSet()
+}
+
+// ClaimsSetBuilder builds a claims set, grouping the claims added into a claims array for each source type in the
+// order the source types are first added. The zero value is an empty builder.
+type ClaimsSetBuilder struct {
+	arrays []ClaimsArray
+	err    error
+}
+
+// Add adds the claim id of the source type holding values, of the types NewClaimEntry accepts. It returns b so calls
+// can be chained; the first error is returned by ClaimsSet.
+func (b *ClaimsSetBuilder) Add(sourceType ClaimsSourceType, id string, values interface{}) *ClaimsSetBuilder {
+	if b.err != nil {
+		return b
+	}
+	e, err := NewClaimEntry(id, values)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	i := slices.IndexFunc(b.arrays, func(a ClaimsArray) bool {
+		return a.ClaimsSourceType == sourceType
+	})
+	if i < 0 {
+		b.arrays = append(b.arrays, ClaimsArray{ClaimsSourceType: sourceType})
+		i = len(b.arrays) - 1
+	}
+	a := &b.arrays[i]
+	if slices.ContainsFunc(a.ClaimEntries, func(e ClaimEntry) bool {
+		return e.ID == id
+	}) {
+		b.err = fmt.Errorf("claim %s of source type %s added twice", id, sourceType)
+		return b
+	}
+	a.ClaimEntries = append(a.ClaimEntries, e)
+	a.ClaimsCount = uint32(len(a.ClaimEntries))
+	return b
+}
+
+// ClaimsSet returns the claims set of the claims added, or the first error adding them.
+func (b *ClaimsSetBuilder) ClaimsSet() (ClaimsSet, error) {
+	if b.err != nil {
+		return ClaimsSet{}, b.err
+	}
+	return ClaimsSet{
+		ClaimsArrayCount: uint32(len(b.arrays)),
+		ClaimsArrays:     slices.Clone(b.arrays),
+	}, nil
+}
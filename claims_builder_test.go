@@ -0,0 +1,83 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewClaimEntry(t *testing.T) {
+	var tests = []struct {
+		values interface{}
+		typ    uint16
+		want   interface{}
+	}{
+		{28, ClaimTypeIDInt64, []int64{28}},
+		{[]int32{-1, 2}, ClaimTypeIDInt64, []int64{-1, 2}},
+		{uint16(7), ClaimTypeIDUInt64, []uint64{7}},
+		{[]uint64{655369, 65543}, ClaimTypeIDUInt64, []uint64{655369, 65543}},
+		{"testuser1", ClaimTypeIDString, []string{"testuser1"}},
+		{[]string{"a", "b"}, ClaimTypeIDString, []string{"a", "b"}},
+		{true, ClaimsTypeIDBoolean, []bool{true}},
+	}
+	for _, test := range tests {
+		e, err := NewClaimEntry("ad://ext/claim", test.values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, test.typ, e.Type, "type of %v not as expected", test.values)
+		assert.Equal(t, test.want, e.Values(), "values of %v not as expected", test.values)
+	}
+	_, err := NewClaimEntry("ad://ext/claim", 1.5)
+	assert.EqualError(t, err, "claim ad://ext/claim: values of type float64 are not a claim type")
+}
+
+func Test_NewClaimsSet(t *testing.T) {
+	c, err := NewClaimsSet(map[string]interface{}{
+		ClaimsEntryIDStr:   ClaimsEntryValueStr,
+		ClaimsEntryIDInt64: ClaimsEntryValueInt64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(1), c.ClaimsArrayCount, "claims array count not as expected")
+	assert.Equal(t, ClaimsSourceTypeAD, c.ClaimsArrays[0].ClaimsSourceType, "claims source type not as expected")
+	assert.Equal(t, uint32(2), c.ClaimsArrays[0].ClaimsCount, "claims count not as expected")
+	assert.Equal(t, ClaimsEntryIDInt64, c.ClaimsArrays[0].ClaimEntries[0].ID, "entries not sorted")
+
+	// The claims set round trips through the claims set metadata of a PAC claims buffer.
+	m, err := NewClaimsSetMetadata(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c, d, "claims set not as expected")
+	assert.Equal(t, map[string][]interface{}{
+		ClaimsEntryIDStr:   {ClaimsEntryValueStr},
+		ClaimsEntryIDInt64: {ClaimsEntryValueInt64},
+	}, d.ToMap(), "claims not as expected")
+}
+
+func Test_ClaimsSetBuilder(t *testing.T) {
+	c, err := new(ClaimsSetBuilder).
+		Add(ClaimsSourceTypeAD, "ad://ext/a", "x").
+		Add(ClaimsSourceTypeCertificate, "ad://ext/b", []bool{true, false}).
+		Add(ClaimsSourceTypeAD, "ad://ext/c", uint32(1)).
+		ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(2), c.ClaimsArrayCount, "claims array count not as expected")
+	assert.Equal(t, ClaimsSourceTypeAD, c.ClaimsArrays[0].ClaimsSourceType, "claims source type not as expected")
+	assert.Equal(t, uint32(2), c.ClaimsArrays[0].ClaimsCount, "claims count not as expected")
+	assert.Equal(t, ClaimsSourceTypeCertificate, c.ClaimsArrays[1].ClaimsSourceType, "claims source type not as expected")
+
+	_, err = new(ClaimsSetBuilder).Add(ClaimsSourceTypeAD, "ad://ext/a", "x").
+		Add(ClaimsSourceTypeAD, "ad://ext/a", "y").ClaimsSet()
+	assert.EqualError(t, err, "claim ad://ext/a of source type AD added twice")
+	_, err = new(ClaimsSetBuilder).Add(ClaimsSourceTypeAD, "ad://ext/a", struct{}{}).ClaimsSet()
+	assert.Error(t, err, "invalid values not reported")
+}
@@ -0,0 +1,88 @@
+package mstypes
+
+import "strings"
+
+// Attribute prefixes of the claims of a ClaimsContext in conditional expressions
+const (
+	ClaimsPrefixUser   = "@User."
+	ClaimsPrefixDevice = "@Device."
+)
+
+// ClaimsContext holds the user and device claims of a security context the way conditional ACEs and central access
+// policies evaluate them: user claims are referenced as @User. attributes and device claims as @Device. attributes,
+// so a claim ID held by both the user and the device does not conflict.
+//
+// Claim IDs are compared case insensitively. A claim ID held more than once in a claims set, in one claims array or
+// in several, resolves to its first entry in the order of the claims arrays and their entries; the later entries are
+// dropped whatever their type.
+type ClaimsContext struct {
+	User   []ClaimEntry
+	Device []ClaimEntry
+}
+
+// NewClaimsContext returns the claims context of the user claims user and the device claims device, either of which
+// may be nil. Device claims are only present for a compound identity.
+func NewClaimsContext(user, device *ClaimsSet) ClaimsContext {
+	return ClaimsContext{
+		User:   mergeClaimEntries(user),
+		Device: mergeClaimEntries(device),
+	}
+}
+
+// mergeClaimEntries returns the claim entries of the claims arrays of c, keeping the first entry of each claim ID.
+func mergeClaimEntries(c *ClaimsSet) []ClaimEntry {
+	if c == nil {
+		return nil
+	}
+	var entries []ClaimEntry
+	seen := make(map[string]bool)
+	for i := range c.ClaimsArrays {
+		for _, e := range c.ClaimsArrays[i].ClaimEntries {
+			id := ToUpperWindows(e.ID)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// UserClaim returns the user claim id, nil if there is none.
+func (c *ClaimsContext) UserClaim(id string) *ClaimEntry {
+	return findClaimEntry(c.User, id)
+}
+
+// DeviceClaim returns the device claim id, nil if there is none.
+func (c *ClaimsContext) DeviceClaim(id string) *ClaimEntry {
+	return findClaimEntry(c.Device, id)
+}
+
+// Lookup returns the claim referenced by the attribute name, a claim ID prefixed with @User. or @Device. matched case
+// insensitively, false if there is none. Names with another prefix, such as @Resource., are not claims of the context.
+func (c *ClaimsContext) Lookup(name string) (*ClaimEntry, bool) {
+	var e *ClaimEntry
+	switch {
+	case hasPrefixFold(name, ClaimsPrefixUser):
+		e = c.UserClaim(name[len(ClaimsPrefixUser):])
+	case hasPrefixFold(name, ClaimsPrefixDevice):
+		e = c.DeviceClaim(name[len(ClaimsPrefixDevice):])
+	}
+	return e, e != nil
+}
+
+// findClaimEntry returns the entry of entries with the claim ID id compared case insensitively, nil if there is none.
+func findClaimEntry(entries []ClaimEntry, id string) *ClaimEntry {
+	for i := range entries {
+		if EqualFoldWindows(entries[i].ID, id) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// hasPrefixFold reports whether s begins with the ASCII prefix compared case insensitively.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
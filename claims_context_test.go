@@ -0,0 +1,54 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClaimsContext(t *testing.T) {
+	user, err := new(ClaimsSetBuilder).
+		Add(ClaimsSourceTypeAD, "ad://ext/department", "Sales").
+		Add(ClaimsSourceTypeAD, "ad://ext/clearance", int64(3)).
+		Add(ClaimsSourceTypeCertificate, "AD://EXT/Department", "Finance").
+		ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	device, err := new(ClaimsSetBuilder).
+		Add(ClaimsSourceTypeAD, "ad://ext/department", "Engineering").
+		Add(ClaimsSourceTypeAD, "ad://ext/managed", true).
+		ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClaimsContext(&user, &device)
+	assert.Len(t, c.User, 2, "duplicate user claim not dropped")
+	assert.Len(t, c.Device, 2, "device claims not as expected")
+
+	var tests = []struct {
+		name string
+		want interface{}
+	}{
+		{"@User.ad://ext/department", []string{"Sales"}},
+		{"@user.AD://EXT/DEPARTMENT", []string{"Sales"}},
+		{"@User.ad://ext/clearance", []int64{3}},
+		{"@Device.ad://ext/department", []string{"Engineering"}},
+		{"@DEVICE.ad://ext/managed", []bool{true}},
+	}
+	for _, test := range tests {
+		e, ok := c.Lookup(test.name)
+		if !assert.True(t, ok, "claim %s not found", test.name) {
+			continue
+		}
+		assert.Equal(t, test.want, e.Values(), "values of claim %s not as expected", test.name)
+	}
+	for _, name := range []string{"@User.ad://ext/managed", "@Resource.ad://ext/department", "ad://ext/department", "@Device."} {
+		_, ok := c.Lookup(name)
+		assert.False(t, ok, "claim %s found", name)
+	}
+
+	c = NewClaimsContext(&user, nil)
+	assert.Nil(t, c.DeviceClaim("ad://ext/department"), "device claim found without device claims")
+	assert.NotNil(t, c.UserClaim("ad://ext/clearance"), "user claim not found")
+}
@@ -42,7 +42,7 @@ func Test_TMP(t *testing.T) {
 func Test_ClientClaimsInfoStr_Unmarshal(t *testing.T) {
 	b, _ := hex.DecodeString(ClientClaimsInfoStr)
 	m := new(ClaimsSetMetadata)
-	dec := ndr.NewDecoder(bytes.NewReader(b))
+	dec := ndr.NewDecoder(bytes.NewReader(b), true)
 	err := dec.Decode(m)
 	if err != nil {
 		t.Errorf("error decoding ClaimsSetMetadata %v", err)
@@ -64,7 +64,7 @@ func Test_ClientClaimsInfoStr_Unmarshal(t *testing.T) {
 func Test_ClientClaimsMultiValueUint_Unmarshal(t *testing.T) {
 	b, _ := hex.DecodeString(ClientClaimsInfoMultiUint)
 	m := new(ClaimsSetMetadata)
-	dec := ndr.NewDecoder(bytes.NewReader(b))
+	dec := ndr.NewDecoder(bytes.NewReader(b), true)
 	err := dec.Decode(m)
 	if err != nil {
 		t.Errorf("error decoding ClaimsSetMetadata %v", err)
@@ -87,7 +87,7 @@ func Test_ClientClaimsMultiValueUint_Unmarshal(t *testing.T) {
 func Test_ClientClaimsInt_Unmarshal(t *testing.T) {
 	b, _ := hex.DecodeString(ClientClaimsInfoInt)
 	m := new(ClaimsSetMetadata)
-	dec := ndr.NewDecoder(bytes.NewReader(b))
+	dec := ndr.NewDecoder(bytes.NewReader(b), true)
 	err := dec.Decode(m)
 	if err != nil {
 		t.Errorf("error decoding ClaimsSetMetadata %v", err)
@@ -110,7 +110,7 @@ func Test_ClientClaimsInt_Unmarshal(t *testing.T) {
 func Test_ClientClaimsMultiValueStr_Unmarshal(t *testing.T) {
 	b, _ := hex.DecodeString(ClientClaimsInfoMultiStr)
 	m := new(ClaimsSetMetadata)
-	dec := ndr.NewDecoder(bytes.NewReader(b))
+	dec := ndr.NewDecoder(bytes.NewReader(b), true)
 	err := dec.Decode(m)
 	if err != nil {
 		t.Errorf("error decoding ClaimsSetMetadata %v", err)
@@ -133,7 +133,7 @@ func Test_ClientClaimsMultiValueStr_Unmarshal(t *testing.T) {
 func Test_ClientClaimsInfoMultiEntry_Unmarshal(t *testing.T) {
 	b, _ := hex.DecodeString(ClientClaimsInfoMulti)
 	m := new(ClaimsSetMetadata)
-	dec := ndr.NewDecoder(bytes.NewReader(b))
+	dec := ndr.NewDecoder(bytes.NewReader(b), true)
 	err := dec.Decode(m)
 	if err != nil {
 		t.Errorf("error decoding ClaimsSetMetadata %v", err)
@@ -156,3 +156,195 @@ func Test_ClientClaimsInfoMultiEntry_Unmarshal(t *testing.T) {
 	assert.Equal(t, []LPWSTR{{ClaimsEntryValueStr}}, k.ClaimsArrays[0].ClaimEntries[1].TypeString.Value, "claims value not as expected")
 	assert.Equal(t, CompressionFormatNone, m.CompressionFormat, "compression format not as expected")
 }
+
+func Test_ClaimEntryValues(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoMulti)
+	var m ClaimsSetMetadata
+	err := UnmarshalTypeSerialized(b, &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := c.ClaimsArrays[0].ClaimEntries
+	assert.Equal(t, []int64{ClaimsEntryValueInt64}, e[0].Values(), "int64 values not as expected")
+	assert.Equal(t, []string{ClaimsEntryValueStr}, e[1].Values(), "string values not as expected")
+
+	u := ClaimEntry{Type: ClaimTypeIDUInt64, TypeUInt64: ClaimTypeUInt64{ValueCount: 1, Value: []uint64{7}}}
+	assert.Equal(t, []uint64{7}, u.Values(), "uint64 values not as expected")
+	v := ClaimEntry{Type: ClaimsTypeIDBoolean, TypeBool: ClaimTypeBoolean{ValueCount: 2, Value: []bool{true, false}}}
+	assert.Equal(t, []bool{true, false}, v.Values(), "boolean values not as expected")
+	assert.Nil(t, (&ClaimEntry{Type: 5}).Values(), "values of an unknown type not as expected")
+}
+
+func Test_ClaimsSetBinary(t *testing.T) {
+	c := ClaimsSet{
+		ClaimsArrayCount: 1,
+		ClaimsArrays: []ClaimsArray{{
+			ClaimsSourceType: ClaimsSourceTypeAD,
+			ClaimsCount:      2,
+			ClaimEntries: []ClaimEntry{
+				{ID: "ad://ext/flag", Type: ClaimsTypeIDBoolean, TypeBool: ClaimTypeBoolean{ValueCount: 1, Value: []bool{true}}},
+				{ID: "ad://ext/name", Type: ClaimTypeIDString, TypeString: ClaimTypeString{ValueCount: 2, Value: []LPWSTR{{"a"}, {"b"}}}},
+			},
+		}},
+	}
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d ClaimsSet
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c, d, "claims set not as expected")
+	assert.Equal(t, []string{"a", "b"}, d.ClaimsArrays[0].ClaimEntries[1].TypeString.Strings(), "strings not as expected")
+}
+
+func Test_ClaimsSetMetadata(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoInt)
+	var m ClaimsSetMetadata
+	err := m.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ClientClaimsInfoInt, hex.EncodeToString(enc), "encoding not as expected")
+	c, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := NewClaimsSetMetadata(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, CompressionFormatNone, n.CompressionFormat, "compression format not as expected")
+	assert.Equal(t, uint32(len(n.ClaimsSetBytes)), n.ClaimsSetSize, "claims set size not as expected")
+	assert.Equal(t, n.ClaimsSetSize, n.UncompressedClaimsSetSize, "uncompressed claims set size not as expected")
+	d, err := n.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c, d, "claims set not as expected")
+
+	blob, err := NewClaimsBlob(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(len(blob.EncodedBlob)), blob.Size, "blob size not as expected")
+	bm, err := blob.ClaimsSetMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, n, bm, "blob claims set metadata not as expected")
+
+	n.CompressionFormat = 1
+	_, err = n.ClaimsSet()
+	assert.EqualError(t, err, "unknown ClaimsSet compression format 1")
+}
+
+func Test_ClaimsSetMetadataCompressed(t *testing.T) {
+	values := make([]LPWSTR, 40)
+	for i := range values {
+		values[i] = LPWSTR{"CN=Group,OU=Groups,DC=test,DC=gokrb5"}
+	}
+	c := ClaimsSet{
+		ClaimsArrayCount: 1,
+		ClaimsArrays: []ClaimsArray{{
+			ClaimsSourceType: ClaimsSourceTypeAD,
+			ClaimsCount:      1,
+			ClaimEntries: []ClaimEntry{{
+				ID:         "ad://ext/memberOf",
+				Type:       ClaimTypeIDString,
+				TypeString: ClaimTypeString{ValueCount: uint32(len(values)), Value: values},
+			}},
+		}},
+	}
+	for _, format := range []uint16{CompressionFormatLZNT1, CompressionFormatXPress, CompressionFormatXPressHuff} {
+		m, err := NewCompressedClaimsSetMetadata(&c, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, format, m.CompressionFormat, "compression format not as expected")
+		assert.Equal(t, uint32(len(m.ClaimsSetBytes)), m.ClaimsSetSize, "claims set size not as expected")
+		assert.Less(t, m.ClaimsSetSize, m.UncompressedClaimsSetSize, "claims set not compressed")
+		d, err := m.ClaimsSet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, c, d, "claims set of format %d not as expected", format)
+	}
+
+	// Below the threshold the claims set is not compressed.
+	c.ClaimsArrays[0].ClaimEntries[0].TypeString = ClaimTypeString{ValueCount: 1, Value: values[:1]}
+	m, err := NewCompressedClaimsSetMetadata(&c, CompressionFormatXPressHuff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, CompressionFormatNone, m.CompressionFormat, "compression format not as expected")
+	assert.Equal(t, m.ClaimsSetSize, m.UncompressedClaimsSetSize, "claims set size not as expected")
+}
+
+func Test_ClaimsSourceType(t *testing.T) {
+	assert.Equal(t, "AD", ClaimsSourceTypeAD.String(), "source type name not as expected")
+	assert.Equal(t, "CERTIFICATE", ClaimsSourceTypeCertificate.String(), "source type name not as expected")
+	assert.Equal(t, "ClaimsSourceType(7)", ClaimsSourceType(7).String(), "unknown source type not as expected")
+	d, ok := ClaimIDDescription(ClaimIDAuthenticationSilo)
+	assert.True(t, ok, "authentication silo claim not well-known")
+	assert.Equal(t, "Authentication silo", d, "claim description not as expected")
+	_, ok = ClaimIDDescription(ClaimsEntryIDStr)
+	assert.False(t, ok, "claim %s reported well-known", ClaimsEntryIDStr)
+}
+
+func Test_ClaimsSetUnknownType(t *testing.T) {
+	c := ClaimsSet{
+		ClaimsArrayCount: 1,
+		ClaimsArrays: []ClaimsArray{{
+			ClaimsSourceType: ClaimsSourceTypeAD,
+			ClaimsCount:      1,
+			ClaimEntries: []ClaimEntry{{
+				ID:        "ad://ext/future",
+				Type:      ClaimTypeIDInt64,
+				TypeInt64: ClaimTypeInt64{ValueCount: 1, Value: []int64{ClaimsEntryValueInt64}},
+			}},
+		}},
+	}
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Retag the entry with a claim type unknown to this package
+	i := bytes.Index(b, []byte{1, 0, 1, 0, 1, 0, 0, 0})
+	if i < 0 {
+		t.Fatal("claim type not found")
+	}
+	b[i], b[i+2] = 9, 9
+	var d ClaimsSet
+	err = d.UnmarshalBinary(b)
+	assert.ErrorIs(t, err, ErrUnknownClaimType, "unknown claim type not rejected")
+	c.ClaimsArrays[0].ClaimEntries[0].Type = 9
+	_, err = c.MarshalBinary()
+	assert.ErrorIs(t, err, ErrUnknownClaimType, "unknown claim type encoded")
+
+	// The claims set metadata holding it is re-emitted unchanged
+	m := ClaimsSetMetadata{ClaimsSetSize: uint32(len(b)), ClaimsSetBytes: b}
+	e, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n ClaimsSetMetadata
+	err = n.UnmarshalBinary(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, n.ClaimsSetBytes, "claims set bytes not as expected")
+	_, err = n.ClaimsSet()
+	assert.ErrorIs(t, err, ErrUnknownClaimType, "unknown claim type not rejected")
+}
@@ -0,0 +1,632 @@
+/*
+Ndrgen generates reflection free NDR marshaling methods for Go struct types.
+
+For each struct type named with -type the methods FromReader, ToWriter and Size are generated using the
+mstypes.Reader and mstypes.Writer so hot types can avoid the reflection based encoder while keeping their definition
+declarative.
+
+Usage:
+
+	ndrgen -type GroupMembership[,OtherType] [-o output.go] file.go
+
+The generated code is written to <file>_ndr.go unless -o is given.
+
+Supported field types are the fixed size integer types, bool, named types based on them, fixed size arrays, other
+struct types implementing the generated methods and conformant slices. A conformant slice must be tagged with the
+field holding its element count, mirroring the IDL size_is attribute:
+
+	SubAuthority []uint32 `ndr:"conformant,size_is:SubAuthorityCount"`
+
+As NDR requires, the maximum count of a conformant slice is moved to the start of the structure. Pointers and
+unions are not supported.
+
+Unsigned integer fields can be restricted to an inclusive range, mirroring the IDL range attribute. The range is
+enforced when decoding and validated before encoding:
+
+	SubAuthorityCount uint8 `ndr:"range:0,15"`
+*/
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	mstypesPackage = "mstypes"
+	mstypesImport  = "github.com/jfjallid/mstypes"
+)
+
+// primitive describes how a fixed size integer or bool is read and written.
+type primitive struct {
+	size   int
+	method string // Reader and Writer method used for the value
+	bool   bool
+}
+
+var primitives = map[string]primitive{
+	"uint8":  {size: 1, method: "Uint8"},
+	"byte":   {size: 1, method: "Uint8"},
+	"uint16": {size: 2, method: "Uint16"},
+	"uint32": {size: 4, method: "Uint32"},
+	"uint64": {size: 8, method: "Uint64"},
+	"int8":   {size: 1, method: "Uint8"},
+	"int16":  {size: 2, method: "Uint16"},
+	"int32":  {size: 4, method: "Uint32"},
+	"int64":  {size: 8, method: "Uint64"},
+	"bool":   {size: 1, method: "Uint8", bool: true},
+}
+
+// generator holds the state of the code generation for a single source file.
+type generator struct {
+	buf   bytes.Buffer
+	pkg   string                     // package name of the source file
+	qual  string                     // qualifier used for the mstypes package
+	types map[string]ast.Expr        // type declarations in the source file
+	done  map[string]*ast.StructType // struct types methods are generated for
+	mod   int                        // the offset is known to be off modulo mod
+	off   int
+}
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output := flag.String("o", "", "output file name; default <file>_ndr.go")
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: ndrgen -type T[,T] [-o output.go] file.go")
+		os.Exit(2)
+	}
+	src := flag.Arg(0)
+	b, err := os.ReadFile(src)
+	if err != nil {
+		fatal(err)
+	}
+	out, err := generate(src, b, strings.Split(*typeNames, ","))
+	if err != nil {
+		fatal(err)
+	}
+	name := *output
+	if name == "" {
+		name = strings.TrimSuffix(src, ".go") + "_ndr.go"
+	}
+	err = os.WriteFile(name, out, 0644)
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "ndrgen: %v\n", err)
+	os.Exit(1)
+}
+
+// generate returns the formatted source of the methods for the named struct types declared in src.
+func generate(filename string, src []byte, typeNames []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		pkg:   f.Name.Name,
+		types: make(map[string]ast.Expr),
+		done:  make(map[string]*ast.StructType),
+	}
+	if g.pkg != mstypesPackage {
+		g.qual = mstypesPackage + "."
+	}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			g.types[ts.Name.Name] = ts.Type
+		}
+	}
+	for _, name := range typeNames {
+		st, ok := g.types[name].(*ast.StructType)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct type declared in %s", name, filename)
+		}
+		g.done[name] = st
+	}
+
+	for _, name := range typeNames {
+		err = g.structMethods(name, g.done[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by ndrgen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	if bytes.Contains(g.buf.Bytes(), []byte("fmt.")) {
+		out.WriteString("\t\"fmt\"\n")
+	}
+	out.WriteString("\t\"io\"\n")
+	if g.qual != "" {
+		fmt.Fprintf(&out, "\n\t%q\n", mstypesImport)
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// field is a struct field with its NDR attributes resolved.
+type field struct {
+	name   string
+	typ    ast.Expr
+	sizeIs string     // field holding the element count of a conformant slice
+	rng    *[2]uint64 // inclusive range the value is restricted to
+}
+
+func (g *generator) fields(st *ast.StructType) (fs []field, err error) {
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+		var sizeIs string
+		var rng *[2]uint64
+		conformant := false
+		vs := strings.Split(tag.Get("ndr"), ",")
+		for i := 0; i < len(vs); i++ {
+			switch v := vs[i]; {
+			case v == "conformant":
+				conformant = true
+			case strings.HasPrefix(v, "size_is:"):
+				sizeIs = strings.TrimPrefix(v, "size_is:")
+			case strings.HasPrefix(v, "range:"):
+				// The bounds of the range are separated by the tag separator
+				if i+1 == len(vs) {
+					return nil, fmt.Errorf("range tag %q must have a minimum and maximum", v)
+				}
+				rng, err = parseRange(strings.TrimPrefix(v, "range:"), vs[i+1])
+				if err != nil {
+					return nil, err
+				}
+				i++
+			case v == "pointer":
+				return nil, errors.New("pointers are not supported")
+			}
+		}
+		if len(f.Names) == 0 {
+			return nil, errors.New("embedded fields are not supported")
+		}
+		for _, n := range f.Names {
+			fd := field{name: n.Name, typ: f.Type, sizeIs: sizeIs, rng: rng}
+			if rng != nil && !g.unsigned(f.Type) {
+				return nil, fmt.Errorf("range field %s must be an unsigned integer", n.Name)
+			}
+			if _, ok := f.Type.(*ast.ArrayType); ok && f.Type.(*ast.ArrayType).Len == nil {
+				if !conformant || sizeIs == "" {
+					return nil, fmt.Errorf("slice field %s must be tagged conformant with a size_is field", n.Name)
+				}
+			}
+			fs = append(fs, fd)
+		}
+	}
+	for i, f := range fs {
+		if f.sizeIs != "" && i != len(fs)-1 {
+			return nil, fmt.Errorf("conformant field %s must be the last field", f.name)
+		}
+	}
+	return
+}
+
+// parseRange parses the minimum and maximum of a range tag.
+func parseRange(min, max string) (*[2]uint64, error) {
+	lo, err := strconv.ParseUint(min, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range minimum %q: %v", min, err)
+	}
+	hi, err := strconv.ParseUint(max, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range maximum %q: %v", max, err)
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("range minimum %d exceeds the maximum %d", lo, hi)
+	}
+	return &[2]uint64{lo, hi}, nil
+}
+
+// alignment returns the NDR alignment of the type expression.
+func (g *generator) alignment(e ast.Expr) int {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if p, ok := primitives[t.Name]; ok {
+			return p.size
+		}
+		if u, ok := g.types[t.Name]; ok {
+			return g.alignment(u)
+		}
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return 4
+		}
+		return g.alignment(t.Elt)
+	case *ast.StructType:
+		a := 1
+		for _, f := range t.Fields.List {
+			a = max(a, g.alignment(f.Type))
+		}
+		return a
+	}
+	return 4
+}
+
+// basic returns the primitive a type expression resolves to, if any.
+func (g *generator) basic(e ast.Expr) (primitive, bool) {
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return primitive{}, false
+	}
+	if p, ok := primitives[id.Name]; ok {
+		return p, true
+	}
+	if u, ok := g.types[id.Name]; ok {
+		return g.basic(u)
+	}
+	return primitive{}, false
+}
+
+// unsigned reports if the type expression resolves to an unsigned integer type.
+func (g *generator) unsigned(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if p, ok := primitives[id.Name]; ok {
+		return !p.bool && !strings.HasPrefix(id.Name, "int")
+	}
+	if u, ok := g.types[id.Name]; ok {
+		return g.unsigned(u)
+	}
+	return false
+}
+
+func (g *generator) structMethods(name string, st *ast.StructType) error {
+	fs, err := g.fields(st)
+	if err != nil {
+		return err
+	}
+	align := g.alignment(st)
+	var conformant *field
+	if len(fs) > 0 && fs[len(fs)-1].sizeIs != "" {
+		conformant = &fs[len(fs)-1]
+		align = max(align, 4)
+	}
+
+	// FromReader
+	fmt.Fprintf(&g.buf, "\n// FromReader reads the NDR representation of %s from r.\n", name)
+	fmt.Fprintf(&g.buf, "func (s *%s) FromReader(r *%sReader) (err error) {\n", name, g.qual)
+	if conformant != nil {
+		g.buf.WriteString("count, err := r.Conformance()\nif err != nil {\nreturn\n}\n")
+	}
+	g.start("r", align)
+	for _, f := range fs {
+		// Each field is read within Reader.Field so decoding errors carry the path of the field
+		fmt.Fprintf(&g.buf, "err = r.Field(%q, func() (err error) {\n", f.name)
+		err = g.readField(f)
+		if err != nil {
+			return err
+		}
+		g.buf.WriteString("return\n})\nif err != nil {\nreturn\n}\n")
+	}
+	g.buf.WriteString("return\n}\n")
+
+	// ToWriter
+	fmt.Fprintf(&g.buf, "\n// ToWriter writes the NDR representation of %s to w.\n", name)
+	fmt.Fprintf(&g.buf, "func (s *%s) ToWriter(w io.Writer) (err error) {\n", name)
+	fmt.Fprintf(&g.buf, "nw := %sAsWriter(w)\n", g.qual)
+	if conformant != nil {
+		fmt.Fprintf(&g.buf, "err = nw.Conformance(uint32(s.%s))\nif err != nil {\nreturn\n}\n", conformant.sizeIs)
+	}
+	g.start("nw", align)
+	for _, f := range fs {
+		err = g.writeField(f)
+		if err != nil {
+			return err
+		}
+	}
+	g.buf.WriteString("return\n}\n")
+
+	// Size
+	fmt.Fprintf(&g.buf, "\n// Size returns the number of bytes in the NDR representation of %s.\n", name)
+	if n, ok := g.staticSize(st, 0); ok {
+		fmt.Fprintf(&g.buf, "func (s *%s) Size() int {\nreturn %d\n}\n", name, n)
+		return nil
+	}
+	fmt.Fprintf(&g.buf, "func (s *%s) Size() int {\nn := 0\n", name)
+	if conformant != nil {
+		g.buf.WriteString("n += 4\n")
+	}
+	for _, f := range fs {
+		g.sizeValue("s."+f.name, f.typ)
+	}
+	g.buf.WriteString("return n\n}\n")
+	return nil
+}
+
+// start writes the alignment of a structure and resets the alignment tracking.
+func (g *generator) start(v string, align int) {
+	g.mod, g.off = 1, 0
+	g.align(v, align)
+}
+
+// align writes a call aligning the Reader or Writer v to n bytes unless the current offset is known to be aligned.
+// The offset is tracked as off modulo mod while it is known statically.
+func (g *generator) align(v string, n int) {
+	if n <= 1 || (g.mod%n == 0 && g.off%n == 0) {
+		return
+	}
+	fmt.Fprintf(&g.buf, "err = %s.Align(%d)\nif err != nil {\nreturn\n}\n", v, n)
+	g.mod, g.off = n, 0
+}
+
+// advance tracks the offset moving forward by n bytes.
+func (g *generator) advance(n int) {
+	g.off = (g.off + n) % g.mod
+}
+
+// unknown marks the offset as no longer known statically.
+func (g *generator) unknown() {
+	g.mod, g.off = 1, 0
+}
+
+// isBytes reports if the type expression is a fixed size byte array.
+func isBytes(e ast.Expr) bool {
+	t, ok := e.(*ast.ArrayType)
+	if !ok || t.Len == nil {
+		return false
+	}
+	id, ok := t.Elt.(*ast.Ident)
+	return ok && (id.Name == "byte" || id.Name == "uint8")
+}
+
+// arrayLen returns the length of a fixed size array if it is a literal.
+func arrayLen(t *ast.ArrayType) (int, bool) {
+	lit, ok := t.Len.(*ast.BasicLit)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(lit.Value)
+	return n, err == nil
+}
+
+// readValue writes the statements reading a single value of type e into the expression dst.
+func (g *generator) readValue(dst string, e ast.Expr) error {
+	if p, ok := g.basic(e); ok {
+		g.align("r", p.size)
+		fmt.Fprintf(&g.buf, "{\nv, err := r.%s()\nif err != nil {\nreturn err\n}\n", p.method)
+		switch name := types(e); {
+		case p.bool:
+			fmt.Fprintf(&g.buf, "%s = %s(v != 0)\n", dst, name)
+		case primitives[name].method == p.method && name != "byte" && !strings.HasPrefix(name, "int"):
+			fmt.Fprintf(&g.buf, "%s = v\n", dst)
+		default:
+			fmt.Fprintf(&g.buf, "%s = %s(v)\n", dst, name)
+		}
+		g.buf.WriteString("}\n")
+		g.advance(p.size)
+		return nil
+	}
+	switch t := e.(type) {
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return errors.New("nested slices are not supported")
+		}
+		if isBytes(t) {
+			fmt.Fprintf(&g.buf, "{\nb, err := r.ReadBytes(len(%s))\nif err != nil {\nreturn err\n}\ncopy(%s[:], b)\n}\n", dst, dst)
+			g.arrayDone(t)
+			return nil
+		}
+		fmt.Fprintf(&g.buf, "for i := range %s {\n", dst)
+		g.unknown()
+		err := g.readValue(dst+"[i]", t.Elt)
+		if err != nil {
+			return err
+		}
+		g.buf.WriteString("}\n")
+		g.unknown()
+		return nil
+	case *ast.Ident, *ast.SelectorExpr:
+		fmt.Fprintf(&g.buf, "err = %s.FromReader(r)\nif err != nil {\nreturn\n}\n", dst)
+		g.unknown()
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", types(e))
+}
+
+// arrayDone tracks the offset after a fixed size byte array.
+func (g *generator) arrayDone(t *ast.ArrayType) {
+	if n, ok := arrayLen(t); ok {
+		g.advance(n)
+		return
+	}
+	g.unknown()
+}
+
+func (g *generator) readField(f field) error {
+	dst := "s." + f.name
+	if f.sizeIs == "" {
+		err := g.readValue(dst, f.typ)
+		if err != nil {
+			return err
+		}
+		g.checkRange(f)
+		return nil
+	}
+	elt := f.typ.(*ast.ArrayType).Elt
+	fmt.Fprintf(&g.buf, "if uint64(s.%s) != uint64(count) {\n", f.sizeIs)
+	fmt.Fprintf(&g.buf, "return fmt.Errorf(\"conformance max count %%d does not match %s %%d\", count, s.%s)\n}\n", f.sizeIs, f.sizeIs)
+	fmt.Fprintf(&g.buf, "{\nn, err := r.Allocate(count, %d)\nif err != nil {\nreturn err\n}\n", g.elemSize(elt))
+	fmt.Fprintf(&g.buf, "%s = make(%s, n)\n}\n", dst, types(f.typ))
+	fmt.Fprintf(&g.buf, "for i := range %s {\nerr = r.Element(i, func() (err error) {\n", dst)
+	g.unknown()
+	err := g.readValue(dst+"[i]", elt)
+	if err != nil {
+		return err
+	}
+	g.buf.WriteString("return\n})\nif err != nil {\nreturn\n}\n}\n")
+	g.unknown()
+	return nil
+}
+
+// writeValue writes the statements writing a single value of type e from the expression src.
+func (g *generator) writeValue(src string, e ast.Expr) error {
+	if p, ok := g.basic(e); ok {
+		g.align("nw", p.size)
+		switch name := types(e); {
+		case p.bool:
+			fmt.Fprintf(&g.buf, "{\nv := uint8(0)\nif %s {\nv = 1\n}\nerr = nw.Uint8(v)\n}\n", src)
+		case primitives[name].method == p.method && name != "byte" && !strings.HasPrefix(name, "int"):
+			fmt.Fprintf(&g.buf, "err = nw.%s(%s)\n", p.method, src)
+		default:
+			fmt.Fprintf(&g.buf, "err = nw.%s(%s(%s))\n", p.method, strings.ToLower(p.method), src)
+		}
+		g.buf.WriteString("if err != nil {\nreturn\n}\n")
+		g.advance(p.size)
+		return nil
+	}
+	switch t := e.(type) {
+	case *ast.ArrayType:
+		if isBytes(t) {
+			fmt.Fprintf(&g.buf, "err = nw.WriteBytes(%s[:])\nif err != nil {\nreturn\n}\n", src)
+			g.arrayDone(t)
+			return nil
+		}
+		fmt.Fprintf(&g.buf, "for i := range %s {\n", src)
+		g.unknown()
+		err := g.writeValue(src+"[i]", t.Elt)
+		if err != nil {
+			return err
+		}
+		g.buf.WriteString("}\n")
+		g.unknown()
+		return nil
+	case *ast.Ident, *ast.SelectorExpr:
+		fmt.Fprintf(&g.buf, "err = %s.ToWriter(nw)\nif err != nil {\nreturn\n}\n", src)
+		g.unknown()
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", types(e))
+}
+
+func (g *generator) writeField(f field) error {
+	src := "s." + f.name
+	if f.sizeIs != "" {
+		fmt.Fprintf(&g.buf, "if len(%s) != int(s.%s) {\n", src, f.sizeIs)
+		fmt.Fprintf(&g.buf, "return fmt.Errorf(\"%s has %%d elements but %s is %%d\", len(%s), s.%s)\n}\n", f.name, f.sizeIs, src, f.sizeIs)
+	}
+	g.checkRange(f)
+	return g.writeValue(src, f.typ)
+}
+
+// checkRange writes the statements validating a field restricted to a range.
+func (g *generator) checkRange(f field) {
+	if f.rng == nil {
+		return
+	}
+	fmt.Fprintf(&g.buf, "err = %sCheckRange(%q, uint64(s.%s), %d, %d)\nif err != nil {\nreturn\n}\n", g.qual, f.name, f.name, f.rng[0], f.rng[1])
+}
+
+// elemSize returns the size used to account for the allocation of a slice element.
+func (g *generator) elemSize(e ast.Expr) int {
+	if p, ok := g.basic(e); ok {
+		return p.size
+	}
+	return 1
+}
+
+// staticSize returns the size of a type expression starting at offset off if it is known at generation time.
+func (g *generator) staticSize(e ast.Expr, off int) (int, bool) {
+	if p, ok := g.basic(e); ok {
+		return (p.size-off%p.size)%p.size + p.size, true
+	}
+	switch t := e.(type) {
+	case *ast.Ident:
+		if u, ok := g.types[t.Name]; ok {
+			return g.staticSize(u, off)
+		}
+	case *ast.ArrayType:
+		l, ok := arrayLen(t)
+		if !ok {
+			return 0, false
+		}
+		n := 0
+		for i := 0; i < l; i++ {
+			m, ok := g.staticSize(t.Elt, off+n)
+			if !ok {
+				return 0, false
+			}
+			n += m
+		}
+		return n, true
+	case *ast.StructType:
+		a := g.alignment(t)
+		n := (a - off%a) % a
+		for _, f := range t.Fields.List {
+			for range max(len(f.Names), 1) {
+				if at, ok := f.Type.(*ast.ArrayType); ok && at.Len == nil {
+					return 0, false
+				}
+				m, ok := g.staticSize(f.Type, off+n)
+				if !ok {
+					return 0, false
+				}
+				n += m
+			}
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// sizeValue writes the statements adding the size of a single value of type e to n.
+func (g *generator) sizeValue(src string, e ast.Expr) {
+	if p, ok := g.basic(e); ok {
+		if p.size > 1 {
+			fmt.Fprintf(&g.buf, "n += (%d - n%%%d) %% %d\n", p.size, p.size, p.size)
+		}
+		fmt.Fprintf(&g.buf, "n += %d\n", p.size)
+		return
+	}
+	if t, ok := e.(*ast.ArrayType); ok {
+		if p, ok := g.basic(t.Elt); ok {
+			if p.size > 1 {
+				fmt.Fprintf(&g.buf, "n += (%d - n%%%d) %% %d\n", p.size, p.size, p.size)
+			}
+			fmt.Fprintf(&g.buf, "n += len(%s) * %d\n", src, p.size)
+			return
+		}
+		fmt.Fprintf(&g.buf, "for i := range %s {\n", src)
+		g.sizeValue(src+"[i]", t.Elt)
+		g.buf.WriteString("}\n")
+		return
+	}
+	a := g.alignment(e)
+	if a > 1 {
+		fmt.Fprintf(&g.buf, "n += (%d - n%%%d) %% %d\n", a, a, a)
+	}
+	fmt.Fprintf(&g.buf, "n += %s.Size()\n", src)
+}
+
+// types returns the Go source of a type expression.
+func types(e ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), e)
+	return buf.String()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSource = `package example
+
+type Flags uint16
+
+type Inner struct {
+	A uint8
+	B int32
+}
+
+type Outer struct {
+	Enabled  bool
+	Flags    Flags
+	Level    uint16 ` + "`" + `ndr:"range:1,3"` + "`" + `
+	Inner    Inner
+	Key      [16]byte
+	Count    uint32
+	Elements []uint64 ` + "`" + `ndr:"conformant,size_is:Count"` + "`" + `
+}
+
+type WithPointer struct {
+	P *Inner ` + "`" + `ndr:"pointer"` + "`" + `
+}
+
+type SignedRange struct {
+	Level int16 ` + "`" + `ndr:"range:1,3"` + "`" + `
+}
+
+type InvertedRange struct {
+	Level uint16 ` + "`" + `ndr:"range:3,1"` + "`" + `
+}
+
+type Unsized struct {
+	Elements []uint32
+}
+`
+
+func Test_Generate(t *testing.T) {
+	b, err := generate("example.go", []byte(testSource), []string{"Inner", "Outer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	for _, s := range []string{
+		"// Code generated by ndrgen; DO NOT EDIT.",
+		`"github.com/jfjallid/mstypes"`,
+		"func (s *Inner) FromReader(r *mstypes.Reader) (err error) {",
+		"func (s *Inner) ToWriter(w io.Writer) (err error) {",
+		"func (s *Inner) Size() int {\n\treturn 8\n}",
+		"s.B = int32(v)",
+		"err = nw.Uint32(uint32(s.B))",
+		"s.Flags = Flags(v)",
+		"s.Enabled = bool(v != 0)",
+		"count, err := r.Conformance()",
+		"err = nw.Conformance(uint32(s.Count))",
+		"n, err := r.Allocate(count, 8)",
+		"err = s.Inner.FromReader(r)",
+		"n += s.Inner.Size()",
+		`err = mstypes.CheckRange("Level", uint64(s.Level), 1, 3)`,
+	} {
+		assert.True(t, strings.Contains(out, s), "generated code does not contain %q", s)
+	}
+}
+
+func Test_GenerateUnsupported(t *testing.T) {
+	for _, name := range []string{"WithPointer", "Unsized", "SignedRange", "InvertedRange", "Flags", "Missing"} {
+		_, err := generate("example.go", []byte(testSource), []string{name})
+		assert.Error(t, err, "unsupported type %s not rejected", name)
+	}
+}
@@ -0,0 +1,77 @@
+package mstypes
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Codepage converts between Go strings and the single byte characters of an ANSI or OEM codepage used by the counted
+// ANSI string types.
+type Codepage interface {
+	Decode(b []byte) (string, error)
+	Encode(s string) ([]byte, error)
+}
+
+// CodepageLatin1 is the ISO 8859-1 codepage, mapping each byte to the Unicode code point of the same value. It is the
+// default codepage of the ANSI string types.
+var CodepageLatin1 Codepage = latin1{}
+
+// Codepages used by the OEM and ANSI string types of legacy structures, such as the LM data of SAMR and Netlogon.
+var (
+	CodepageOEM437      Codepage = textCodepage{charmap.CodePage437} // OEM United States
+	CodepageOEM850      Codepage = textCodepage{charmap.CodePage850} // OEM Multilingual Latin 1
+	CodepageWindows1252 Codepage = textCodepage{charmap.Windows1252} // ANSI Latin 1
+)
+
+// CodepageByID returns the Codepage with the Windows code page identifier id.
+func CodepageByID(id int) (Codepage, error) {
+	switch id {
+	case 437:
+		return CodepageOEM437, nil
+	case 850:
+		return CodepageOEM850, nil
+	case 1252:
+		return CodepageWindows1252, nil
+	case 28591:
+		return CodepageLatin1, nil
+	}
+	return nil, fmt.Errorf("unsupported code page %d", id)
+}
+
+// textCodepage is a Codepage implemented by an x/text encoding.
+type textCodepage struct {
+	e encoding.Encoding
+}
+
+func (c textCodepage) Decode(b []byte) (string, error) {
+	return c.e.NewDecoder().String(string(b))
+}
+
+func (c textCodepage) Encode(s string) ([]byte, error) {
+	return c.e.NewEncoder().Bytes([]byte(s))
+}
+
+type latin1 struct{}
+
+func (latin1) Decode(b []byte) (string, error) {
+	var s strings.Builder
+	s.Grow(len(b))
+	for _, c := range b {
+		s.WriteRune(rune(c))
+	}
+	return s.String(), nil
+}
+
+func (latin1) Encode(s string) ([]byte, error) {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			return nil, fmt.Errorf("character %q can not be represented in ISO 8859-1", r)
+		}
+		b = append(b, byte(r))
+	}
+	return b, nil
+}
@@ -0,0 +1,42 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Codepages(t *testing.T) {
+	var tests = []struct {
+		ID    int
+		Value string
+		Bytes []byte
+	}{
+		{437, "Åsa", []byte{0x8f, 's', 'a'}},
+		{850, "Ø", []byte{0x9d}},
+		{1252, "€uro", []byte{0x80, 'u', 'r', 'o'}},
+		{28591, "é", []byte{0xe9}},
+	}
+	for i, test := range tests {
+		cp, err := CodepageByID(test.ID)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		b, err := cp.Encode(test.Value)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Bytes, b, "encoding not as expected for test %d", i+1)
+		s, err := cp.Decode(b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Value, s, "decoding not as expected for test %d", i+1)
+	}
+
+	_, err := CodepageOEM437.Encode("€")
+	assert.Error(t, err, "character outside the codepage not detected")
+	_, err = CodepageByID(65001)
+	assert.Error(t, err, "unsupported code page not detected")
+
+	s := OEMString{Length: 3, MaximumLength: 3, Buffer: []byte{0x8f, 's', 'a'}}
+	v, err := s.Decode(CodepageOEM437)
+	assert.NoError(t, err)
+	assert.Equal(t, "Åsa", v)
+}
@@ -1,6 +1,10 @@
 // Package mstypes provides implemnations of some Microsoft data types [MS-DTYP] https://msdn.microsoft.com/en-us/library/cc230283.aspx
 package mstypes
 
+import (
+	"io"
+)
+
 // LPWSTR implements https://msdn.microsoft.com/en-us/library/cc230355.aspx
 type LPWSTR struct {
 	Value string `ndr:"pointer,conformant,varying"`
@@ -10,3 +14,33 @@ type LPWSTR struct {
 func (s *LPWSTR) String() string {
 	return s.Value
 }
+
+// FromReader reads the pointer to the null terminated string. The string is read when r.Deferred is called.
+func (s *LPWSTR) FromReader(r *Reader) error {
+	_, err := r.Pointer(func() (err error) {
+		s.Value, err = r.ConformantVaryingString(true)
+		return
+	})
+	return err
+}
+
+// ToWriter writes the pointer to the null terminated string. An empty string is written as a null pointer.
+func (s *LPWSTR) ToWriter(w io.Writer) error {
+	nw := AsWriter(w)
+	var fn func() error
+	if s.Value != "" {
+		fn = func() error {
+			return nw.ConformantVaryingString(s.Value, true)
+		}
+	}
+	err := nw.Pointer(fn)
+	if err != nil {
+		return err
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LPWSTR.
+func (s *LPWSTR) Size() int {
+	return ndrSize(s)
+}
@@ -0,0 +1,158 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+The compression formats of MS-XCA compress the claims sets of PAC and Active Directory claims.
+Ref: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-xca/
+The decompressors are bounded by the uncompressed size the container records, so a corrupt or hostile stream can
+neither write past it nor expand without limit.
+*/
+
+// ErrCompressedData is returned, wrapped, when compressed data is malformed or does not decompress to the expected
+// size.
+var ErrCompressedData = errors.New("invalid compressed data")
+
+// Decompress returns the size bytes that b, compressed with format, one of the CompressionFormat constants,
+// decompresses to. CompressionFormatNone returns b itself, which must be size bytes long.
+func Decompress(format uint16, b []byte, size int) ([]byte, error) {
+	if size < 0 || size > DefaultMaxAlloc {
+		return nil, fmt.Errorf("%w: uncompressed size %d", ErrDecodeLimit, size)
+	}
+	var out []byte
+	var err error
+	switch format {
+	case CompressionFormatNone:
+		out = b
+	case CompressionFormatLZNT1:
+		out, err = decompressLZNT1(b, size)
+	case CompressionFormatXPress:
+		out, err = decompressXPress(b, size)
+	case CompressionFormatXPressHuff:
+		out, err = decompressXPressHuffman(b, size)
+	default:
+		return nil, fmt.Errorf("unknown compression format %d", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != size {
+		return nil, fmt.Errorf("%w: decompressed %d bytes, not %d", ErrCompressedData, len(out), size)
+	}
+	return out, nil
+}
+
+// newDecompressBuffer returns the buffer a decompressor appends the size bytes of output to. It grows as the output is
+// written, so an uncompressed size that is a lie does not allocate up front.
+func newDecompressBuffer(size int) []byte {
+	return make([]byte, 0, min(size, 1<<16))
+}
+
+// copyMatch appends the length bytes starting offset bytes back from the end of out, which may overlap the bytes
+// being appended, failing rather than growing out past size.
+func copyMatch(out []byte, offset, length, size int) ([]byte, error) {
+	if offset <= 0 || offset > len(out) {
+		return out, fmt.Errorf("%w: match offset %d at output position %d", ErrCompressedData, offset, len(out))
+	}
+	if length > size-len(out) {
+		return out, fmt.Errorf("%w: match of %d bytes at output position %d exceeds %d bytes", ErrCompressedData,
+			length, len(out), size)
+	}
+	for i := 0; i < length; i++ {
+		out = append(out, out[len(out)-offset])
+	}
+	return out, nil
+}
+
+// Compress returns b compressed with format, one of the CompressionFormat constants. CompressionFormatNone returns b
+// itself.
+func Compress(format uint16, b []byte) ([]byte, error) {
+	switch format {
+	case CompressionFormatNone:
+		return b, nil
+	case CompressionFormatLZNT1:
+		return compressLZNT1(b), nil
+	case CompressionFormatXPress:
+		return compressXPress(b), nil
+	case CompressionFormatXPressHuff:
+		return compressXPressHuffman(b), nil
+	}
+	return nil, fmt.Errorf("unknown compression format %d", format)
+}
+
+// LZ77 match finder
+const (
+	lz77MinMatch   = 3
+	lz77HashBits   = 14
+	lz77ChainDepth = 32 // candidates tried for a match
+)
+
+// lz77Matcher finds the longest earlier occurrence of the bytes at a position of b with hash chains of the 3 byte
+// prefixes of the positions inserted.
+type lz77Matcher struct {
+	b    []byte
+	head []int32 // last position inserted of each hash, -1 for none
+	prev []int32 // previous position inserted with the hash of each position
+}
+
+func newLZ77Matcher(b []byte) *lz77Matcher {
+	m := &lz77Matcher{
+		b:    b,
+		head: make([]int32, 1<<lz77HashBits),
+		prev: make([]int32, len(b)),
+	}
+	for i := range m.head {
+		m.head[i] = -1
+	}
+	return m
+}
+
+func (m *lz77Matcher) hash(pos int) uint32 {
+	v := uint32(m.b[pos]) | uint32(m.b[pos+1])<<8 | uint32(m.b[pos+2])<<16
+	return (v * 2654435761) >> (32 - lz77HashBits)
+}
+
+// insert adds the position pos to the chains.
+func (m *lz77Matcher) insert(pos int) {
+	if pos+lz77MinMatch > len(m.b) {
+		return
+	}
+	h := m.hash(pos)
+	m.prev[pos] = m.head[h]
+	m.head[h] = int32(pos)
+}
+
+// find returns the offset and length of the longest match of at most maxLength bytes at pos starting at most
+// maxOffset bytes back and not before minPos, a length of 0 if there is none of at least 3 bytes. The positions
+// before pos must have been inserted.
+func (m *lz77Matcher) find(pos, minPos, maxOffset, maxLength int) (offset, length int) {
+	maxLength = min(maxLength, len(m.b)-pos)
+	if maxLength < lz77MinMatch {
+		return 0, 0
+	}
+	cand := m.head[m.hash(pos)]
+	for depth := 0; cand >= 0 && depth < lz77ChainDepth; depth++ {
+		c := int(cand)
+		if c < minPos || pos-c > maxOffset {
+			break
+		}
+		n := 0
+		for n < maxLength && m.b[c+n] == m.b[pos+n] {
+			n++
+		}
+		if n > length {
+			offset, length = pos-c, n
+			if n == maxLength {
+				break
+			}
+		}
+		cand = m.prev[c]
+	}
+	if length < lz77MinMatch {
+		return 0, 0
+	}
+	return offset, length
+}
@@ -0,0 +1,107 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xpressLiterals returns b as a Plain LZ77 stream of literals only.
+func xpressLiterals(b []byte) []byte {
+	var out []byte
+	for i := 0; i < len(b); i += 32 {
+		out = append(out, 0, 0, 0, 0)
+		out = append(out, b[i:min(i+32, len(b))]...)
+	}
+	return out
+}
+
+func Test_Decompress(t *testing.T) {
+	out, err := Decompress(CompressionFormatNone, []byte("abc"), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "abc", string(out), "uncompressed data not as expected")
+	out, err = Decompress(CompressionFormatXPress, xpressLiterals([]byte("abc")), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "abc", string(out), "decompressed data not as expected")
+
+	_, err = Decompress(CompressionFormatNone, []byte("abc"), 4)
+	assert.True(t, errors.Is(err, ErrCompressedData), "error of a size mismatch not as expected: %v", err)
+	_, err = Decompress(CompressionFormatLZNT1, []byte{2, 0x30, 'x', 'y', 'z'}, 4)
+	assert.True(t, errors.Is(err, ErrCompressedData), "error of a short output not as expected: %v", err)
+	_, err = Decompress(CompressionFormatXPress, nil, DefaultMaxAlloc+1)
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "error of a size over the limit not as expected: %v", err)
+	_, err = Decompress(1, nil, 0)
+	assert.EqualError(t, err, "unknown compression format 1")
+}
+
+func Test_ClaimsSetCompressed(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoMulti)
+	var m ClaimsSetMetadata
+	err := m.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.CompressionFormat = CompressionFormatXPress
+	m.UncompressedClaimsSetSize = uint32(len(m.ClaimsSetBytes))
+	m.ClaimsSetBytes = xpressLiterals(m.ClaimsSetBytes)
+	m.ClaimsSetSize = uint32(len(m.ClaimsSetBytes))
+	c, err := m.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want, c, "claims set not as expected")
+
+	m.UncompressedClaimsSetSize++
+	_, err = m.ClaimsSet()
+	assert.True(t, errors.Is(err, ErrCompressedData), "error of a wrong uncompressed size not as expected: %v", err)
+}
+
+func Test_CompressRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := make([]byte, 20000)
+	rnd.Read(random)
+	text := []byte(strings.Repeat("ad://ext/department:88d5d9085ea5c0c0 Engineering, Sales; ", 2000))
+	words := make([]byte, 0, 200000)
+	for len(words) < cap(words) {
+		words = append(words, []string{"claim ", "value ", "S-1-5-21-", "ad://ext/", "\x00\x00\x00\x00"}[rnd.Intn(5)]...)
+	}
+	inputs := map[string][]byte{
+		"empty":  {},
+		"short":  []byte("ab"),
+		"random": random,
+		"text":   text,
+		"words":  words,
+		"zeros":  make([]byte, 300000),
+	}
+	for _, format := range []uint16{CompressionFormatLZNT1, CompressionFormatXPress, CompressionFormatXPressHuff} {
+		for name, in := range inputs {
+			c, err := Compress(format, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := Decompress(format, c, len(in))
+			if err != nil {
+				t.Fatalf("format %d: %s: %v", format, name, err)
+			}
+			assert.True(t, bytes.Equal(in, out), "format %d: %s: round trip not as expected", format, name)
+			if name == "text" || name == "zeros" {
+				assert.Less(t, len(c), len(in)/10, "format %d: %s: not compressed", format, name)
+			}
+		}
+	}
+	_, err := Compress(1, nil)
+	assert.EqualError(t, err, "unknown compression format 1")
+}
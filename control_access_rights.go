@@ -0,0 +1,104 @@
+package mstypes
+
+/*
+The GUIDs of the control access rights, validated writes and property sets of the AD schema, as found in the
+ObjectType of object ACEs. Ref: https://learn.microsoft.com/en-us/windows/win32/adschema/extended-rights
+*/
+
+// Extended rights
+var (
+	GUIDDSReplicationGetChanges              = mustParseGUID("1131f6aa-9c07-11d1-f79f-00c04fc2dcd2")
+	GUIDDSReplicationGetChangesAll           = mustParseGUID("1131f6ad-9c07-11d1-f79f-00c04fc2dcd2")
+	GUIDDSReplicationGetChangesInFilteredSet = mustParseGUID("89e95b76-444d-4c62-991a-0facbeda640c")
+	GUIDDSReplicationManageTopology          = mustParseGUID("1131f6ac-9c07-11d1-f79f-00c04fc2dcd2")
+	GUIDDSReplicationSynchronize             = mustParseGUID("1131f6ab-9c07-11d1-f79f-00c04fc2dcd2")
+	GUIDDSInstallReplica                     = mustParseGUID("9923a32a-3607-11d2-b9be-0000f87a36b2")
+	GUIDUserForceChangePassword              = mustParseGUID("00299570-246d-11d0-a768-00aa006e0529")
+	GUIDUserChangePassword                   = mustParseGUID("ab721a53-1e2f-11d0-9819-00aa0040529b")
+	GUIDSendAs                               = mustParseGUID("ab721a54-1e2f-11d0-9819-00aa0040529b")
+	GUIDReceiveAs                            = mustParseGUID("ab721a56-1e2f-11d0-9819-00aa0040529b")
+	GUIDAllowedToAuthenticate                = mustParseGUID("68b1d179-0d15-4d4f-ab71-46152e79a7bc")
+	GUIDApplyGroupPolicy                     = mustParseGUID("edacfd8f-ffb3-11d1-b41d-00a0c968f939")
+	GUIDCertificateEnrollment                = mustParseGUID("0e10c968-78fb-11d2-90d4-00c04f79dc55")
+	GUIDCertificateAutoEnrollment            = mustParseGUID("a05b8cc2-17bc-4802-a710-e7c15ab866a2")
+	GUIDReanimateTombstones                  = mustParseGUID("45ec5156-db7e-47bb-b53f-dbeb2d03c40f")
+	GUIDUnexpirePassword                     = mustParseGUID("ccc2dc7d-a6ad-4a7a-8846-c04e3cc53501")
+	GUIDUpdatePasswordNotRequiredBit         = mustParseGUID("280f369c-67c7-438e-ae98-1d46f3c6f541")
+	GUIDMigrateSIDHistory                    = mustParseGUID("ba33815a-4f93-4c76-87f3-57574bff8109")
+)
+
+// Validated writes
+var (
+	GUIDSelfMembership                     = mustParseGUID("bf9679c0-0de6-11d0-a285-00aa003049e2")
+	GUIDValidatedSPN                       = mustParseGUID("f3a64788-5306-11d1-a9c5-0000f80367c1")
+	GUIDValidatedDNSHostName               = mustParseGUID("72e39547-7b18-11d1-adef-00c04fd8d5cd")
+	GUIDValidatedMSDSAdditionalDNSHostName = mustParseGUID("80863791-dbe9-4eb8-837e-7f0ab55d9ac7")
+	GUIDValidatedMSDSBehaviorVersion       = mustParseGUID("d31a8757-2447-4545-8081-3bb610cacbf2")
+)
+
+// Property sets
+var (
+	GUIDUserAccountRestrictions = mustParseGUID("4c164200-20c0-11d0-a768-00aa006e0529")
+	GUIDUserLogon               = mustParseGUID("5f202010-79a5-11d0-9020-00c04fc2d4cf")
+	GUIDPersonalInformation     = mustParseGUID("77b5b886-944a-11d1-aebd-0000f80367c1")
+	GUIDPublicInformation       = mustParseGUID("e48d0154-bcf8-11d1-8702-00c04fb96050")
+	GUIDGeneralInformation      = mustParseGUID("59ba2f42-79a2-11d0-9020-00c04fc2d3cf")
+	GUIDMembership              = mustParseGUID("bc0ac240-79a9-11d0-9020-00c04fc2d4cf")
+	GUIDWebInformation          = mustParseGUID("e45795b3-9455-11d1-aebd-0000f80367c1")
+	GUIDEmailInformation        = mustParseGUID("e45795b2-9455-11d1-aebd-0000f80367c1")
+	GUIDPrivateInformation      = mustParseGUID("91e647de-d96f-4b70-9557-d63ff4f3ccd8")
+)
+
+// ControlAccessRights maps the GUIDs of the well known control access rights, validated writes and property sets to
+// their display names.
+var ControlAccessRights = map[GUID]string{
+	GUIDDSReplicationGetChanges:              "DS-Replication-Get-Changes",
+	GUIDDSReplicationGetChangesAll:           "DS-Replication-Get-Changes-All",
+	GUIDDSReplicationGetChangesInFilteredSet: "DS-Replication-Get-Changes-In-Filtered-Set",
+	GUIDDSReplicationManageTopology:          "DS-Replication-Manage-Topology",
+	GUIDDSReplicationSynchronize:             "DS-Replication-Synchronize",
+	GUIDDSInstallReplica:                     "DS-Install-Replica",
+	GUIDUserForceChangePassword:              "User-Force-Change-Password",
+	GUIDUserChangePassword:                   "User-Change-Password",
+	GUIDSendAs:                               "Send-As",
+	GUIDReceiveAs:                            "Receive-As",
+	GUIDAllowedToAuthenticate:                "Allowed-To-Authenticate",
+	GUIDApplyGroupPolicy:                     "Apply-Group-Policy",
+	GUIDCertificateEnrollment:                "Certificate-Enrollment",
+	GUIDCertificateAutoEnrollment:            "Certificate-AutoEnrollment",
+	GUIDReanimateTombstones:                  "Reanimate-Tombstones",
+	GUIDUnexpirePassword:                     "Unexpire-Password",
+	GUIDUpdatePasswordNotRequiredBit:         "Update-Password-Not-Required-Bit",
+	GUIDMigrateSIDHistory:                    "Migrate-SID-History",
+	GUIDSelfMembership:                       "Self-Membership",
+	GUIDValidatedSPN:                         "Validated-SPN",
+	GUIDValidatedDNSHostName:                 "Validated-DNS-Host-Name",
+	GUIDValidatedMSDSAdditionalDNSHostName:   "Validated-MS-DS-Additional-DNS-Host-Name",
+	GUIDValidatedMSDSBehaviorVersion:         "Validated-MS-DS-Behavior-Version",
+	GUIDUserAccountRestrictions:              "User-Account-Restrictions",
+	GUIDUserLogon:                            "User-Logon",
+	GUIDPersonalInformation:                  "Personal-Information",
+	GUIDPublicInformation:                    "Public-Information",
+	GUIDGeneralInformation:                   "General-Information",
+	GUIDMembership:                           "Membership",
+	GUIDWebInformation:                       "Web-Information",
+	GUIDEmailInformation:                     "Email-Information",
+	GUIDPrivateInformation:                   "Private-Information",
+}
+
+// ControlAccessRightName returns the display name of the control access right, validated write or property set g and
+// whether it is known.
+func ControlAccessRightName(g GUID) (string, bool) {
+	name, ok := ControlAccessRights[g]
+	return name, ok
+}
+
+// mustParseGUID returns the GUID of the string form s, panicking if it is invalid. It is meant for package level
+// variables.
+func mustParseGUID(s string) GUID {
+	g, err := ParseGUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
@@ -0,0 +1,25 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ControlAccessRights(t *testing.T) {
+	assert.Equal(t, TestGUID, GUIDDSReplicationGetChanges)
+	name, ok := ControlAccessRightName(GUIDDSReplicationGetChangesAll)
+	assert.True(t, ok)
+	assert.Equal(t, "DS-Replication-Get-Changes-All", name)
+	_, ok = ControlAccessRightName(GUID{})
+	assert.False(t, ok)
+
+	assert.Len(t, ControlAccessRights, 32, "GUID repeated in the table")
+	names := make(map[string]bool)
+	for g, name := range ControlAccessRights {
+		assert.False(t, g.IsZero(), name)
+		assert.False(t, names[name], "%s repeated", name)
+		names[name] = true
+	}
+	assert.Panics(t, func() { mustParseGUID("invalid") })
+}
@@ -0,0 +1,108 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeError locates a decoding failure by the path of the field being read and the stream offset it started at,
+// for example "KerbValidationInfo.GroupIDs[3].RelativeID at offset 0x1a4: unexpected EOF".
+type DecodeError struct {
+	Path   string // path of the field, from the top-level type, that failed to decode
+	Offset int    // stream offset the field started at
+	Err    error  // underlying error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("offset 0x%x: %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("%s at offset 0x%x: %v", e.Path, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Field calls fn to read the field name of the structure being read. An error returned by fn is wrapped in a
+// DecodeError carrying the path of the innermost field that failed and the offset it started at.
+func (r *Reader) Field(name string, fn func() error) error {
+	r.path = append(r.path, name)
+	err := r.locate(r.n, fn)
+	r.path = r.path[:len(r.path)-1]
+	return err
+}
+
+// Element calls fn to read element i of the array being read, wrapping an error returned by fn the same way as Field.
+func (r *Reader) Element(i int, fn func() error) error {
+	return r.Field("["+strconv.Itoa(i)+"]", fn)
+}
+
+// locate calls fn and wraps the error it returns in a DecodeError for the current path unless it already is one.
+func (r *Reader) locate(offset int, fn func() error) error {
+	err := fn()
+	if err == nil {
+		return nil
+	}
+	var de *DecodeError
+	if errors.As(err, &de) {
+		return err
+	}
+	if err == io.EOF {
+		// The stream ended in the middle of the value being read
+		err = io.ErrUnexpectedEOF
+	}
+	return &DecodeError{Path: r.Path(), Offset: offset, Err: err}
+}
+
+// Path returns the path of the field being read.
+func (r *Reader) Path() string {
+	var s strings.Builder
+	for i, p := range r.path {
+		if i > 0 && !strings.HasPrefix(p, "[") {
+			s.WriteByte('.')
+		}
+		s.WriteString(p)
+	}
+	return s.String()
+}
+
+// fieldPointer reads a unique pointer held by the field name so its referent is read with the path of the field.
+func (r *Reader) fieldPointer(name string, fn func() error) (ok bool, err error) {
+	err = r.Field(name, func() (err error) {
+		ok, err = r.Pointer(fn)
+		return
+	})
+	return
+}
+
+// deferPath wraps fn, the reader of a pointer referent, so it is called with the path of the pointer.
+func (r *Reader) deferPath(fn func() error) func() error {
+	if len(r.path) == 0 {
+		return fn
+	}
+	path := append([]string(nil), r.path...)
+	return func() error {
+		saved := r.path
+		r.path = path
+		err := r.locate(r.n, fn)
+		r.path = saved
+		return err
+	}
+}
+
+// typeName returns the name of the type of v used as the root of the field paths.
+func typeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
@@ -0,0 +1,40 @@
+package mstypes
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeErrorPath(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-21-3167651404-3865080224-2280184895")
+	d := DomainGroupMembership{
+		DomainID:   *sid,
+		GroupCount: 2,
+		GroupIDs:   []GroupMembership{{RelativeID: 513, Attributes: 7}, {RelativeID: 512, Attributes: 7}},
+	}
+	b, err := Marshal(&d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got DomainGroupMembership
+	err = Unmarshal(b[:len(b)-2], &got)
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("error is not a DecodeError: %v", err)
+	}
+	assert.Equal(t, "DomainGroupMembership.GroupIDs[1].Attributes", de.Path)
+	assert.Equal(t, len(b)-4, de.Offset)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "underlying error not unwrapped: %v", err)
+	assert.Equal(t, "DomainGroupMembership.GroupIDs[1].Attributes at offset 0x38: error reading bytes from stream: unexpected EOF", err.Error())
+
+	// The referent of a pointer is located by the path of the pointer
+	b[17] = 0x10
+	err = Unmarshal(b, &got)
+	assert.True(t, errors.As(err, &de), "error is not a DecodeError: %v", err)
+	assert.Equal(t, "DomainGroupMembership.DomainID", de.Path)
+	assert.True(t, errors.Is(err, ErrOutOfRange), "underlying error not unwrapped: %v", err)
+}
@@ -0,0 +1,69 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// DOSDateTime implements the 16 bit DOS date and time pair, the SMB_DATE and SMB_TIME of MS-CIFS.
+//
+// The date holds the day in bits 0 to 4, the month in bits 5 to 8 and the years since 1980 in bits 9 to 15. The time
+// holds the seconds divided by 2 in bits 0 to 4, the minutes in bits 5 to 10 and the hours in bits 11 to 15. The pair
+// carries no time zone and is the local time of the host that set it.
+type DOSDateTime struct {
+	Date uint16
+	Time uint16
+}
+
+// DOS date range
+const (
+	dosEpochYear = 1980
+	dosMaxYear   = dosEpochYear + 0x7f
+)
+
+// NewDOSDateTime returns the DOSDateTime of the wall clock of t, truncated to 2 seconds. An error is returned if t is
+// outside the years 1980 to 2107.
+func NewDOSDateTime(t time.Time) (DOSDateTime, error) {
+	if t.Year() < dosEpochYear || t.Year() > dosMaxYear {
+		return DOSDateTime{}, fmt.Errorf("year %d outside the DOS date range %d to %d", t.Year(), dosEpochYear, dosMaxYear)
+	}
+	return DOSDateTime{
+		Date: uint16(t.Year()-dosEpochYear)<<9 | uint16(t.Month())<<5 | uint16(t.Day()),
+		Time: uint16(t.Hour())<<11 | uint16(t.Minute())<<5 | uint16(t.Second()/2),
+	}, nil
+}
+
+// IsZero reports whether the date and time are zero, which is used for a time that is not set.
+func (d DOSDateTime) IsZero() bool {
+	return d.Date == 0 && d.Time == 0
+}
+
+// TimeIn returns the DOSDateTime as a time.Time in the location loc, the time zone of the host that set it. A zero
+// DOSDateTime is returned as the zero time.Time. Fields out of range are normalized as time.Date does.
+func (d DOSDateTime) TimeIn(loc *time.Location) time.Time {
+	if d.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(
+		int(d.Date>>9)+dosEpochYear, time.Month(d.Date>>5&0xf), int(d.Date&0x1f),
+		int(d.Time>>11), int(d.Time>>5&0x3f), int(d.Time&0x1f)*2, 0, loc)
+}
+
+// MarshalBinary returns the 4 byte little-endian encoding of the date followed by the time.
+func (d DOSDateTime) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2*SizeUint16)
+	binary.LittleEndian.PutUint16(b, d.Date)
+	binary.LittleEndian.PutUint16(b[SizeUint16:], d.Time)
+	return b, nil
+}
+
+// UnmarshalBinary sets the DOSDateTime from the 4 byte little-endian encoding of the date followed by the time.
+func (d *DOSDateTime) UnmarshalBinary(b []byte) error {
+	if len(b) != 2*SizeUint16 {
+		return fmt.Errorf("DOS date and time encoding is %d bytes, not %d", len(b), 2*SizeUint16)
+	}
+	d.Date = binary.LittleEndian.Uint16(b)
+	d.Time = binary.LittleEndian.Uint16(b[SizeUint16:])
+	return nil
+}
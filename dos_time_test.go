@@ -0,0 +1,37 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DOSDateTime(t *testing.T) {
+	tt := time.Date(2007, 2, 22, 17, 0, 3, 500, time.UTC)
+	d, err := NewDOSDateTime(tt)
+	assert.NoError(t, err)
+	assert.Equal(t, DOSDateTime{Date: 27<<9 | 2<<5 | 22, Time: 17<<11 | 0<<5 | 1}, d)
+	assert.Equal(t, time.Date(2007, 2, 22, 17, 0, 2, 0, time.UTC), d.TimeIn(time.UTC))
+
+	b, err := d.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "5636"+"0188", hex.EncodeToString(b))
+	var got DOSDateTime
+	assert.NoError(t, got.UnmarshalBinary(b))
+	assert.Equal(t, d, got)
+	assert.Error(t, got.UnmarshalBinary(b[:3]), "short encoding not detected")
+
+	loc := time.FixedZone("CET", 3600)
+	assert.Equal(t, time.Date(2007, 2, 22, 17, 0, 2, 0, loc), d.TimeIn(loc))
+	assert.True(t, DOSDateTime{}.TimeIn(time.UTC).IsZero())
+
+	_, err = NewDOSDateTime(time.Date(1979, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err, "year before 1980 not detected")
+	_, err = NewDOSDateTime(time.Date(2108, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err, "year after 2107 not detected")
+	d, err = NewDOSDateTime(time.Date(2107, 12, 31, 23, 59, 59, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, DOSDateTime{Date: 0xff9f, Time: 0xbf7d}, d)
+}
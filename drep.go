@@ -0,0 +1,123 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+/*
+The data representation format label, or drep, of a DCE RPC PDU describes the representation of integers, characters
+and floating point numbers in the NDR data that follows. Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_01
+It is 4 bytes: the integer representation in the high and the character representation in the low nibble of the
+first byte, the floating point representation in the second byte and two reserved bytes.
+*/
+
+// Data representation format label values
+const (
+	DrepBigEndian    uint8 = 0
+	DrepLittleEndian uint8 = 1
+	DrepASCII        uint8 = 0
+	DrepEBCDIC       uint8 = 1
+	DrepIEEE         uint8 = 0
+	DrepVAX          uint8 = 1
+	DrepCray         uint8 = 2
+	DrepIBM          uint8 = 3
+	DrepLength             = 4
+)
+
+// DataRepresentation is the data representation format label of a DCE RPC PDU.
+type DataRepresentation struct {
+	IntegerRep   uint8 // DrepBigEndian or DrepLittleEndian
+	CharacterRep uint8 // DrepASCII or DrepEBCDIC
+	FloatRep     uint8 // DrepIEEE, DrepVAX, DrepCray or DrepIBM
+}
+
+// NewDataRepresentation returns the little-endian, ASCII and IEEE data representation used by Windows.
+func NewDataRepresentation() DataRepresentation {
+	return DataRepresentation{IntegerRep: DrepLittleEndian, CharacterRep: DrepASCII, FloatRep: DrepIEEE}
+}
+
+// ParseDataRepresentation parses the data representation format label at the start of b.
+func ParseDataRepresentation(b []byte) (d DataRepresentation, err error) {
+	if len(b) < DrepLength {
+		err = fmt.Errorf("data representation format label needs %d bytes, %d available", DrepLength, len(b))
+		return
+	}
+	d.IntegerRep = b[0] >> 4
+	d.CharacterRep = b[0] & 0x0f
+	d.FloatRep = b[1]
+	if d.IntegerRep > DrepLittleEndian {
+		err = fmt.Errorf("invalid data representation integer format %d", d.IntegerRep)
+		return
+	}
+	if d.CharacterRep > DrepEBCDIC {
+		err = fmt.Errorf("invalid data representation character format %d", d.CharacterRep)
+		return
+	}
+	if d.FloatRep > DrepIBM {
+		err = fmt.Errorf("invalid data representation floating point format %d", d.FloatRep)
+	}
+	return
+}
+
+// Bytes returns the 4 byte data representation format label.
+func (d DataRepresentation) Bytes() []byte {
+	return []byte{d.IntegerRep<<4 | d.CharacterRep&0x0f, d.FloatRep, 0, 0}
+}
+
+// ByteOrder returns the byte order of integers indicated by the data representation.
+func (d DataRepresentation) ByteOrder() binary.ByteOrder {
+	if d.IntegerRep == DrepBigEndian {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// Option returns the Option configuring a Reader or Writer for the data representation.
+func (d DataRepresentation) Option() Option {
+	return WithByteOrder(d.ByteOrder())
+}
+
+func (d DataRepresentation) String() string {
+	integer := "little-endian"
+	if d.IntegerRep == DrepBigEndian {
+		integer = "big-endian"
+	}
+	character := "ASCII"
+	if d.CharacterRep == DrepEBCDIC {
+		character = "EBCDIC"
+	}
+	float := fmt.Sprintf("unknown(%d)", d.FloatRep)
+	switch d.FloatRep {
+	case DrepIEEE:
+		float = "IEEE"
+	case DrepVAX:
+		float = "VAX"
+	case DrepCray:
+		float = "Cray"
+	case DrepIBM:
+		float = "IBM"
+	}
+	return fmt.Sprintf("%s, %s, %s", integer, character, float)
+}
+
+// DataRepresentation reads a data representation format label. The byte order of the Reader is set to the byte order
+// it indicates so the NDR data that follows is decoded accordingly.
+func (r *Reader) DataRepresentation() (d DataRepresentation, err error) {
+	b, err := r.ReadBytes(DrepLength)
+	if err != nil {
+		err = fmt.Errorf("could not read data representation format label: %w", err)
+		return
+	}
+	d, err = ParseDataRepresentation(b)
+	if err != nil {
+		return
+	}
+	r.SetByteOrder(d.ByteOrder())
+	return
+}
+
+// DataRepresentation writes the data representation format label d.
+func (w *Writer) DataRepresentation(d DataRepresentation) error {
+	return w.WriteBytes(d.Bytes())
+}
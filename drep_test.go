@@ -0,0 +1,49 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DataRepresentation(t *testing.T) {
+	var tests = []struct {
+		Hex    string
+		Order  binary.ByteOrder
+		String string
+	}{
+		{"10000000", binary.LittleEndian, "little-endian, ASCII, IEEE"},
+		{"00000000", binary.BigEndian, "big-endian, ASCII, IEEE"},
+		{"11010000", binary.LittleEndian, "little-endian, EBCDIC, VAX"},
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test.Hex)
+		d, err := ParseDataRepresentation(b)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		assert.Equal(t, test.Order, d.ByteOrder(), "byte order not as expected for test %d", i+1)
+		assert.Equal(t, test.String, d.String(), "string not as expected for test %d", i+1)
+		assert.Equal(t, b, d.Bytes(), "format label not as expected for test %d", i+1)
+	}
+	assert.Equal(t, "10000000", hex.EncodeToString(NewDataRepresentation().Bytes()))
+
+	for _, s := range []string{"20000000", "12000000", "10040000", "1000"} {
+		b, _ := hex.DecodeString(s)
+		_, err := ParseDataRepresentation(b)
+		assert.Error(t, err, "invalid format label %s not detected", s)
+	}
+}
+
+func Test_ReaderDataRepresentation(t *testing.T) {
+	b, _ := hex.DecodeString("00000000" + "01020304")
+	r := NewReader(bytes.NewReader(b))
+	_, err := r.DataRepresentation()
+	assert.NoError(t, err)
+	u, err := r.Uint32()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x01020304), u, "byte order not taken from the format label")
+}
@@ -2,6 +2,11 @@
 package mstypes
 
 import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
 	"time"
 )
 
@@ -15,7 +20,11 @@ We need to combine these two into one 64bit integer.
 This gives the number of 100 nano second period from January 1, 1601, Coordinated Universal Time (UTC)
 */
 
-const unixEpochDiff = 116444736000000000
+const (
+	unixEpochDiff    = 116444736000000000 // 100 nano second periods from January 1, 1601 to January 1, 1970
+	unixEpochSeconds = 11644473600        // seconds from January 1, 1601 to January 1, 1970
+	ticksPerSecond   = 10000000           // 100 nano second periods in a second
+)
 
 // FileTime implements the Microsoft FILETIME type https://msdn.microsoft.com/en-us/library/cc230324.aspx
 type FileTime struct {
@@ -23,10 +32,36 @@ type FileTime struct {
 	HighDateTime uint32
 }
 
-// Time return a golang Time type from the FileTime
+// FileTime values with a special meaning
+var (
+	// FileTimeNever is the largest FILETIME, used by Windows for times that never occur such as the expiry of an
+	// account that never expires.
+	FileTimeNever = FileTime{LowDateTime: 0xffffffff, HighDateTime: 0x7fffffff}
+	// FileTimeNeverInt32 is the FILETIME with both parts set to the largest int32, used for the same purpose as
+	// FileTimeNever by the PAC and SAMR.
+	FileTimeNeverInt32 = FileTime{LowDateTime: 0x7fffffff, HighDateTime: 0x7fffffff}
+)
+
+// IsZero reports whether the FileTime is zero, which Windows uses for a time that is not set.
+func (ft FileTime) IsZero() bool {
+	return ft.LowDateTime == 0 && ft.HighDateTime == 0
+}
+
+// IsNever reports whether the FileTime is FileTimeNever or FileTimeNeverInt32.
+func (ft FileTime) IsNever() bool {
+	return ft == FileTimeNever || ft == FileTimeNeverInt32
+}
+
+// Time return a golang Time type from the FileTime. A zero FileTime, or one that IsNever, is returned as the zero
+// time.Time rather than January 1, 1601 or a time in the year 30828; use IsZero and IsNever to tell them apart.
 func (ft FileTime) Time() time.Time {
-	ns := (ft.MSEpoch() - unixEpochDiff) * 100
-	return time.Unix(0, int64(ns)).UTC()
+	if ft.IsZero() || ft.IsNever() {
+		return time.Time{}
+	}
+	ticks := uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+	sec := int64(ticks/ticksPerSecond) - unixEpochSeconds
+	nsec := int64(ticks%ticksPerSecond) * 100
+	return time.Unix(sec, nsec).UTC()
 }
 
 // MSEpoch returns the FileTime as a Microsoft epoch, the number of 100 nano second periods elapsed from January 1, 1601 UTC.
@@ -41,12 +76,257 @@ func (ft FileTime) Unix() int64 {
 
 // GetFileTime returns a FileTime type from the provided Golang Time type.
 func GetFileTime(t time.Time) FileTime {
-	ns := t.UnixNano()
-	fp := (ns / 100) + unixEpochDiff
-	hd := fp >> 32
-	ld := fp - (hd << 32)
-	return FileTime{
-		LowDateTime:  uint32(ld),
-		HighDateTime: uint32(hd),
+	var ft FileTime
+	ft.FromTime(t)
+	return ft
+}
+
+// FromTime sets the FileTime to the time t, truncated to 100 nano seconds. The conversion does not go through
+// nanoseconds since the Unix epoch, so times outside the years 1678 to 2262 are converted too. Times before
+// January 1, 1601 are clamped to the zero FileTime and times after the largest FILETIME, in the year 30828, to
+// FileTimeNever.
+func (ft *FileTime) FromTime(t time.Time) {
+	unix := t.Unix()
+	switch {
+	case unix < -unixEpochSeconds:
+		*ft = FileTime{}
+		return
+	case unix > math.MaxInt64/ticksPerSecond-unixEpochSeconds:
+		*ft = FileTimeNever
+		return
+	}
+	secs := unix + unixEpochSeconds
+	ticks := secs*ticksPerSecond + int64(t.Nanosecond()/100)
+	if ticks < secs*ticksPerSecond {
+		*ft = FileTimeNever
+		return
+	}
+	*ft = fileTimeFromTicks(ticks)
+}
+
+// FileTimeFromInterval returns the negative FILETIME interval, as used by AD attributes such as maxPwdAge and
+// lockoutDuration, representing the duration d.
+func FileTimeFromInterval(d time.Duration) FileTime {
+	return fileTimeFromTicks(-TicksFromDuration(d))
+}
+
+// TicksFromDuration returns the number of 100 nano second periods, the unit of a FILETIME, in d truncated toward
+// zero.
+func TicksFromDuration(d time.Duration) int64 {
+	return int64(d / 100)
+}
+
+// DurationFromTicks returns the duration of n 100 nano second periods. A count too large for a time.Duration is
+// returned as the largest, or for a negative count the smallest, Duration.
+func DurationFromTicks(n int64) time.Duration {
+	switch {
+	case n > math.MaxInt64/100:
+		return math.MaxInt64
+	case n < math.MinInt64/100:
+		return math.MinInt64
+	}
+	return time.Duration(n * 100)
+}
+
+// AddTicks returns the FileTime n 100 nano second periods after ft, or before it if n is negative. A FileTime that
+// IsZero or IsNever is returned unchanged, as Windows does not move a time that is not set or never occurs. The
+// result is kept between zero and FileTimeNever rather than wrapping around.
+func (ft FileTime) AddTicks(n int64) FileTime {
+	if ft.IsZero() || ft.IsNever() {
+		return ft
+	}
+	ticks := ft.Interval()
+	switch {
+	case n > 0 && ticks > math.MaxInt64-n:
+		return FileTimeNever
+	case ticks+n < 0:
+		return FileTime{}
+	}
+	return fileTimeFromTicks(ticks + n)
+}
+
+// Add returns the FileTime d after ft, truncated to 100 nano seconds, as AddTicks does.
+func (ft FileTime) Add(d time.Duration) FileTime {
+	return ft.AddTicks(TicksFromDuration(d))
+}
+
+// Sub returns the duration ft - u. A result too long for a time.Duration is returned as the largest or smallest
+// Duration.
+func (ft FileTime) Sub(u FileTime) time.Duration {
+	a, b := ft.Interval(), u.Interval()
+	d := a - b
+	if (b < 0 && d < a) || (b > 0 && d > a) {
+		if b < 0 {
+			return math.MaxInt64
+		}
+		return math.MinInt64
+	}
+	return DurationFromTicks(d)
+}
+
+// DefaultClockSkew is the maximum clock skew Windows KDCs and services accept by default.
+const DefaultClockSkew = 5 * time.Minute
+
+// WithinSkew reports whether the times a and b are at most skew apart, as a KDC checks the time of a request. Times
+// that are not set or never occur are only within skew of an identical time.
+func WithinSkew(a, b FileTime, skew time.Duration) bool {
+	if a.IsZero() || a.IsNever() || b.IsZero() || b.IsNever() {
+		return a == b
+	}
+	d := a.Sub(b)
+	return d <= skew && d >= -skew
+}
+
+// Truncate returns ft rounded down to a multiple of d since January 1, 1601. A d of less than 100 nano seconds, or a
+// FileTime that IsZero, IsNever or is an interval, is returned unchanged.
+func (ft FileTime) Truncate(d time.Duration) FileTime {
+	n := TicksFromDuration(d)
+	if n <= 0 || ft.IsZero() || ft.IsNever() || ft.IsInterval() {
+		return ft
+	}
+	ticks := ft.Interval()
+	return fileTimeFromTicks(ticks - ticks%n)
+}
+
+// KerberosTime returns ft truncated to whole seconds, the resolution of the KerberosTime of tickets. The ClientID of
+// the PAC_CLIENT_INFO buffer is the authtime of the ticket and so has this resolution.
+func (ft FileTime) KerberosTime() FileTime {
+	return ft.Truncate(time.Second)
+}
+
+// fileTimeFromTicks returns the FileTime holding the signed 100 nano second period count n.
+func fileTimeFromTicks(n int64) FileTime {
+	return FileTime{LowDateTime: uint32(n), HighDateTime: uint32(uint64(n) >> 32)}
+}
+
+// Interval returns the FileTime as a signed number of 100 nano second periods. Negative values are relative
+// intervals rather than points in time.
+func (ft FileTime) Interval() int64 {
+	return int64(uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime))
+}
+
+// IsInterval reports whether the FileTime is a negative relative interval.
+func (ft FileTime) IsInterval() bool {
+	return ft.Interval() < 0
+}
+
+// IsNeverInterval reports whether the FileTime is the most negative interval, which AD uses for intervals that never
+// elapse, such as a maxPwdAge of passwords that never expire.
+func (ft FileTime) IsNeverInterval() bool {
+	return ft.Interval() == math.MinInt64
+}
+
+// Duration returns the duration of the negative FILETIME interval, so an interval of -10000000 is one second. An
+// interval too long for a time.Duration, including one that IsNeverInterval, is returned as the largest Duration.
+func (ft FileTime) Duration() time.Duration {
+	ticks := ft.Interval()
+	if ticks == math.MinInt64 {
+		return math.MaxInt64
+	}
+	return DurationFromTicks(-ticks)
+}
+
+// ParseFileTime parses the decimal integer string form LDAP returns FILETIME attributes in, such as "-36288000000000"
+// for maxPwdAge or "9223372036854775807" for an accountExpires that never expires.
+func ParseFileTime(s string) (FileTime, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		u, uerr := strconv.ParseUint(s, 10, 64)
+		if uerr != nil {
+			return FileTime{}, fmt.Errorf("could not parse FILETIME %q: %w", s, err)
+		}
+		v = int64(u)
+	}
+	return fileTimeFromTicks(v), nil
+}
+
+// MarshalBinary returns the 8 byte little-endian encoding of the FileTime used outside of NDR, for example in the
+// PAC_CLIENT_INFO buffer.
+func (ft FileTime) MarshalBinary() ([]byte, error) {
+	b := make([]byte, SizeUint64)
+	binary.LittleEndian.PutUint32(b, ft.LowDateTime)
+	binary.LittleEndian.PutUint32(b[SizeUint32:], ft.HighDateTime)
+	return b, nil
+}
+
+// UnmarshalBinary sets the FileTime from its 8 byte little-endian encoding.
+func (ft *FileTime) UnmarshalBinary(b []byte) error {
+	if len(b) != SizeUint64 {
+		return fmt.Errorf("FILETIME encoding is %d bytes, not %d", len(b), SizeUint64)
 	}
+	ft.LowDateTime = binary.LittleEndian.Uint32(b)
+	ft.HighDateTime = binary.LittleEndian.Uint32(b[SizeUint32:])
+	return nil
+}
+
+// MarshalText returns the FileTime as an RFC 3339 time with nano seconds in UTC. A zero FileTime, one that IsNever and
+// an interval are returned as their decimal integer form, as LDAP does, since they are not points in time. It makes
+// the FileTime encode as a JSON string.
+func (ft FileTime) MarshalText() ([]byte, error) {
+	if ft.IsZero() || ft.IsNever() || ft.IsInterval() {
+		return strconv.AppendInt(nil, ft.Interval(), 10), nil
+	}
+	return ft.Time().AppendFormat(nil, time.RFC3339Nano), nil
+}
+
+// UnmarshalText sets the FileTime from an RFC 3339 time or the decimal integer form returned by MarshalText.
+func (ft *FileTime) UnmarshalText(b []byte) error {
+	t, err := time.Parse(time.RFC3339Nano, string(b))
+	if err == nil {
+		ft.FromTime(t)
+		return nil
+	}
+	v, err := ParseFileTime(string(b))
+	if err != nil {
+		return err
+	}
+	*ft = v
+	return nil
+}
+
+// FromReader reads the FileTime from r.
+func (ft *FileTime) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	*ft, err = r.FileTime()
+	return
+}
+
+// ToWriter writes the FileTime to w.
+func (ft *FileTime) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	return nw.FileTime(*ft)
+}
+
+// Size returns the number of bytes of the NDR representation of FileTime.
+func (ft *FileTime) Size() int {
+	return SizeUint64
+}
+
+// PackedFileTime is a FILETIME in a flat structure that is not NDR encoded, such as the supplementalCredentials
+// attribute or the PAC_CLIENT_INFO buffer, where it is not aligned.
+type PackedFileTime struct {
+	FileTime
+}
+
+// FromReader reads the PackedFileTime from r without aligning the stream.
+func (ft *PackedFileTime) FromReader(r *Reader) (err error) {
+	ft.FileTime, err = r.FileTime()
+	return
+}
+
+// ToWriter writes the PackedFileTime to w without aligning the stream.
+func (ft *PackedFileTime) ToWriter(w io.Writer) error {
+	return AsWriter(w).FileTime(ft.FileTime)
+}
+
+// Size returns the number of bytes of the PackedFileTime.
+func (ft *PackedFileTime) Size() int {
+	return SizeUint64
 }
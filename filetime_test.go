@@ -3,8 +3,10 @@ package mstypes
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"github.com/jfjallid/ndr"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 	"time"
 )
@@ -42,7 +44,7 @@ func TestDecodeFileTime(t *testing.T) {
 		a := new(FileTime)
 		hexStr := TestNDRHeader + test.Hex
 		b, _ := hex.DecodeString(hexStr)
-		dec := ndr.NewDecoder(bytes.NewReader(b))
+		dec := ndr.NewDecoder(bytes.NewReader(b), true)
 		err := dec.Decode(a)
 		if err != nil {
 			t.Fatalf("test %d: %v", i+1, err)
@@ -50,3 +52,189 @@ func TestDecodeFileTime(t *testing.T) {
 		assert.Equal(t, test.UnixNano, a.Time().UnixNano(), "Time value not as expected for test: %d", i+1)
 	}
 }
+
+func TestFileTimeRange(t *testing.T) {
+	var tests = []time.Time{
+		time.Date(1601, 1, 1, 0, 0, 0, 100, time.UTC),
+		time.Date(1650, 6, 1, 12, 0, 0, 100, time.UTC),
+		time.Date(2500, 12, 31, 23, 59, 59, 999999900, time.UTC),
+		time.Date(30828, 9, 14, 2, 48, 5, 477580600, time.UTC),
+	}
+	for _, tt := range tests {
+		ft := GetFileTime(tt)
+		assert.Equal(t, tt, ft.Time(), "time %v not converted as expected", tt)
+	}
+	assert.Equal(t, FileTime{LowDateTime: 1}, GetFileTime(tests[0]))
+	assert.Equal(t, FileTime{LowDateTime: 0xfffffffe, HighDateTime: 0x7fffffff}, GetFileTime(tests[3]))
+
+	for _, tt := range []time.Time{
+		time.Date(1600, 12, 31, 23, 59, 59, 999999900, time.UTC),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		assert.Equal(t, FileTime{}, GetFileTime(tt), "time %v before 1601 not clamped", tt)
+	}
+	for _, tt := range []time.Time{
+		time.Date(30828, 9, 14, 2, 48, 5, 477580800, time.UTC),
+		time.Date(30829, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(292277026596, 12, 4, 15, 30, 7, 0, time.UTC),
+	} {
+		assert.Equal(t, FileTimeNever, GetFileTime(tt), "time %v after the largest FILETIME not clamped", tt)
+	}
+}
+
+func TestFileTimeSentinels(t *testing.T) {
+	var tests = []struct {
+		FileTime FileTime
+		Zero     bool
+		Never    bool
+	}{
+		{FileTime{}, true, false},
+		{FileTimeNever, false, true},
+		{FileTimeNeverInt32, false, true},
+		{FileTime{LowDateTime: 1}, false, false},
+		{FileTime{LowDateTime: 0xffffffff, HighDateTime: 0xffffffff}, false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.Zero, test.FileTime.IsZero(), "IsZero of %+v not as expected", test.FileTime)
+		assert.Equal(t, test.Never, test.FileTime.IsNever(), "IsNever of %+v not as expected", test.FileTime)
+		if test.Zero || test.Never {
+			assert.True(t, test.FileTime.Time().IsZero(), "Time of %+v not the zero time", test.FileTime)
+		}
+	}
+	assert.Equal(t, 60056, FileTime{LowDateTime: 0xffffffff, HighDateTime: 0xffffffff}.Time().Year())
+}
+
+func TestFileTimeBinary(t *testing.T) {
+	var ft FileTime
+	ft.FromTime(time.Date(2007, 2, 22, 17, 0, 1, 638215500, time.UTC))
+	b, err := ft.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "cbe02fe4a256c701", hex.EncodeToString(b))
+	var got FileTime
+	assert.NoError(t, got.UnmarshalBinary(b))
+	assert.Equal(t, ft, got)
+	assert.Error(t, got.UnmarshalBinary(b[:7]), "short encoding not detected")
+}
+
+func TestFileTimeInterval(t *testing.T) {
+	var tests = []struct {
+		LDAP     string
+		Duration time.Duration
+	}{
+		{"-36288000000000", 42 * 24 * time.Hour}, // default maxPwdAge
+		{"-18000000000", 30 * time.Minute},       // default lockoutDuration
+		{"-864000000000", 24 * time.Hour},
+		{"0", 0},
+	}
+	for _, test := range tests {
+		ft, err := ParseFileTime(test.LDAP)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Duration, ft.Duration(), "duration of %s not as expected", test.LDAP)
+		assert.Equal(t, test.Duration != 0, ft.IsInterval())
+		assert.Equal(t, ft, FileTimeFromInterval(test.Duration))
+	}
+
+	ft, err := ParseFileTime("-9223372036854775808")
+	assert.NoError(t, err)
+	assert.True(t, ft.IsNeverInterval())
+	assert.Equal(t, time.Duration(math.MaxInt64), ft.Duration())
+
+	ft, err = ParseFileTime("9223372036854775807")
+	assert.NoError(t, err)
+	assert.True(t, ft.IsNever())
+	ft, err = ParseFileTime("18446744073709551615")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), ft.Interval())
+	_, err = ParseFileTime("never")
+	assert.Error(t, err)
+}
+
+func TestFileTimeJSON(t *testing.T) {
+	var tests = []struct {
+		FileTime FileTime
+		JSON     string
+	}{
+		{GetFileTime(time.Date(2007, 2, 22, 17, 0, 1, 638215500, time.UTC)), `"2007-02-22T17:00:01.6382155Z"`},
+		{GetFileTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), `"2024-01-01T00:00:00Z"`},
+		{FileTime{}, `"0"`},
+		{FileTimeNever, `"9223372036854775807"`},
+		{FileTimeNeverInt32, `"9223372034707292159"`},
+		{FileTimeFromInterval(30 * time.Minute), `"-18000000000"`},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.FileTime)
+		assert.NoError(t, err)
+		assert.Equal(t, test.JSON, string(b))
+		var got FileTime
+		assert.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, test.FileTime, got, "%s not decoded as expected", test.JSON)
+	}
+	var got FileTime
+	assert.NoError(t, json.Unmarshal([]byte(`"2024-01-01T01:00:00+01:00"`), &got))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), got.Time())
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &got))
+}
+
+func TestFileTimeTicks(t *testing.T) {
+	assert.Equal(t, int64(10000000), TicksFromDuration(time.Second))
+	assert.Equal(t, int64(0), TicksFromDuration(99))
+	assert.Equal(t, int64(-1), TicksFromDuration(-100))
+	assert.Equal(t, time.Second, DurationFromTicks(10000000))
+	assert.Equal(t, time.Duration(math.MaxInt64), DurationFromTicks(math.MaxInt64))
+	assert.Equal(t, time.Duration(math.MinInt64), DurationFromTicks(math.MinInt64))
+
+	start := GetFileTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	end := start.Add(10 * time.Hour)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), end.Time())
+	assert.Equal(t, 10*time.Hour, end.Sub(start))
+	assert.Equal(t, -10*time.Hour, start.Sub(end))
+	assert.Equal(t, start, end.AddTicks(-TicksFromDuration(10*time.Hour)))
+
+	assert.Equal(t, FileTimeNever, FileTimeNever.Add(time.Hour), "never moved")
+	assert.Equal(t, FileTime{}, FileTime{}.Add(time.Hour), "unset time moved")
+	assert.Equal(t, FileTimeNever, FileTime{LowDateTime: 0xfffffff0, HighDateTime: 0x7fffffff}.AddTicks(math.MaxInt64))
+	assert.Equal(t, FileTime{}, start.AddTicks(math.MinInt64))
+	assert.Equal(t, time.Duration(math.MaxInt64), FileTimeNever.Sub(FileTime{LowDateTime: 1}))
+}
+
+func TestFileTimeSkew(t *testing.T) {
+	now := GetFileTime(time.Date(2024, 1, 1, 12, 0, 0, 123456700, time.UTC))
+	assert.True(t, WithinSkew(now, now.Add(DefaultClockSkew), DefaultClockSkew))
+	assert.True(t, WithinSkew(now.Add(DefaultClockSkew), now, DefaultClockSkew))
+	assert.False(t, WithinSkew(now, now.Add(DefaultClockSkew+100), DefaultClockSkew))
+	assert.False(t, WithinSkew(now, FileTimeNever, DefaultClockSkew))
+	assert.True(t, WithinSkew(FileTimeNever, FileTimeNever, 0))
+	assert.False(t, WithinSkew(FileTime{}, FileTime{LowDateTime: 1}, time.Hour))
+
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), now.KerberosTime().Time())
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 123000000, time.UTC), now.Truncate(time.Millisecond).Time())
+	assert.Equal(t, now, now.Truncate(0))
+	assert.Equal(t, FileTimeNever, FileTimeNever.KerberosTime())
+}
+
+func TestFileTimeAlignment(t *testing.T) {
+	ft := FileTime{LowDateTime: 0x04030201, HighDateTime: 0x08070605}
+	w := NewAppendWriter(nil)
+	assert.NoError(t, w.Uint8(0xff))
+	assert.NoError(t, ft.ToWriter(w))
+	assert.Equal(t, "ff000000"+"0102030405060708", hex.EncodeToString(w.Bytes()), "FileTime not aligned to 4 bytes")
+	r := NewReader(bytes.NewReader(w.Bytes()))
+	_, err := r.Uint8()
+	assert.NoError(t, err)
+	var got FileTime
+	assert.NoError(t, got.FromReader(r))
+	assert.Equal(t, ft, got)
+
+	p := PackedFileTime{ft}
+	w = NewAppendWriter(nil)
+	assert.NoError(t, w.Uint8(0xff))
+	assert.NoError(t, p.ToWriter(w))
+	assert.Equal(t, "ff"+"0102030405060708", hex.EncodeToString(w.Bytes()), "PackedFileTime aligned")
+	r = NewReader(bytes.NewReader(w.Bytes()))
+	_, err = r.Uint8()
+	assert.NoError(t, err)
+	var gotPacked PackedFileTime
+	assert.NoError(t, gotPacked.FromReader(r))
+	assert.Equal(t, p, gotPacked)
+	assert.Equal(t, ft.Time(), gotPacked.Time())
+}
@@ -0,0 +1,46 @@
+package mstypes
+
+import (
+	"unicode"
+	"unicode/utf16"
+)
+
+// EqualFoldWindows reports whether a and b are equal under the case insensitive comparison Windows uses for account
+// names and SPNs. Each UTF-16 character is mapped to its simple uppercase form before comparing. Unlike
+// strings.EqualFold characters are not matched through their case folding orbits, so the Kelvin sign does not match
+// "k", and characters outside the Basic Multilingual Plane only match themselves.
+func EqualFoldWindows(a, b string) bool {
+	ua := wideChars(a, false)
+	ub := wideChars(b, false)
+	if len(ua) != len(ub) {
+		return false
+	}
+	for i := range ua {
+		if upcaseWindows(ua[i]) != upcaseWindows(ub[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToUpperWindows returns s with each UTF-16 character mapped to its uppercase form as Windows does.
+func ToUpperWindows(s string) string {
+	u := wideChars(s, false)
+	for i := range u {
+		u[i] = upcaseWindows(u[i])
+	}
+	return string(utf16.Decode(u))
+}
+
+// upcaseWindows returns the uppercase form of the UTF-16 character c. Surrogates are left as they are since Windows
+// upcases code units rather than code points.
+func upcaseWindows(c uint16) uint16 {
+	if utf16.IsSurrogate(rune(c)) {
+		return c
+	}
+	r := unicode.ToUpper(rune(c))
+	if r > 0xffff {
+		return c
+	}
+	return uint16(r)
+}
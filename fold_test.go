@@ -0,0 +1,31 @@
+package mstypes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EqualFoldWindows(t *testing.T) {
+	var tests = []struct {
+		A, B  string
+		Equal bool
+	}{
+		{"Administrator", "ADMINISTRATOR", true},
+		{"http/web.example.com", "HTTP/Web.Example.COM", true},
+		{"åsa", "ÅSA", true},
+		{"k", "K", false},                   // Kelvin sign
+		{"straße", "STRASSE", false},        // no full case mapping
+		{"\U00010428", "\U00010400", false}, // Deseret outside the BMP
+		{"user", "users", false},
+	}
+	for i, test := range tests {
+		assert.Equal(t, test.Equal, EqualFoldWindows(test.A, test.B), "comparison not as expected for test %d", i+1)
+	}
+	// strings.EqualFold differs on these
+	assert.True(t, strings.EqualFold("k", "K"))
+	assert.True(t, strings.EqualFold("\U00010428", "\U00010400"))
+
+	assert.Equal(t, "ADMINISTRATOR\U00010428", ToUpperWindows("administrator\U00010428"))
+}
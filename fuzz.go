@@ -0,0 +1,213 @@
+package mstypes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrInvariant is wrapped by the errors the fuzzing entry points return when decoded data breaks an invariant of the
+// decoder or encoder.
+var ErrInvariant = errors.New("NDR invariant violated")
+
+/*
+The fuzzing entry points decode arbitrary data and check the invariants that must hold for any input the decoder
+accepts. They are designed to be called from go test fuzz targets, including by projects embedding these types:
+
+	func FuzzClaims(f *testing.F) {
+		f.Fuzz(func(t *testing.T, data []byte) {
+			if err := mstypes.FuzzClaimsSetMetadata(data); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+Data the decoder rejects is not an error. An error wrapping ErrInvariant is returned when a decoded value does not
+encode to the number of bytes its Size method reports or when its encoding does not decode to a value encoding to
+the same bytes. Panics are left to the fuzzing engine to report.
+*/
+
+// FuzzDecode decodes data as the top-level NDR type T with the options opts and checks the decoding invariants.
+func FuzzDecode[T any, P element[T]](data []byte, opts ...Option) error {
+	var v T
+	if Unmarshal(data, P(&v), opts...) != nil {
+		return nil
+	}
+	return checkRoundTrip[T, P](&v, func(v P) ([]byte, error) {
+		b, err := Marshal(v, opts...)
+		if err == nil && len(b) != v.Size() {
+			err = fmt.Errorf("%d bytes encoded but Size reports %d: %w", len(b), v.Size(), ErrInvariant)
+		}
+		return b, err
+	}, func(b []byte, v P) error {
+		return Unmarshal(b, v, opts...)
+	})
+}
+
+// FuzzTypeSerialized decodes data as a type serialized top-level pointer to the NDR type T, as found in the PAC
+// buffers, and checks the decoding invariants.
+func FuzzTypeSerialized[T any, P element[T]](data []byte) error {
+	var v T
+	if UnmarshalTypeSerialized(data, P(&v)) != nil {
+		return nil
+	}
+	return checkRoundTrip[T, P](&v, func(v P) ([]byte, error) {
+		return MarshalTypeSerialized(v)
+	}, func(b []byte, v P) error {
+		return UnmarshalTypeSerialized(b, v)
+	})
+}
+
+// FuzzClaimsSetMetadata decodes data as a type serialized CLAIMS_SET_METADATA, the client and device claims PAC
+// buffers, and checks the decoding invariants. The claims set it holds is decompressed and decoded, and checked the
+// same way.
+func FuzzClaimsSetMetadata(data []byte) error {
+	err := FuzzTypeSerialized[ClaimsSetMetadata](data)
+	if err != nil {
+		return err
+	}
+	var m ClaimsSetMetadata
+	if m.UnmarshalBinary(data) != nil {
+		return nil
+	}
+	c, err := m.ClaimsSet()
+	if err != nil {
+		return nil
+	}
+	return checkBinaryRoundTrip(&c, new(ClaimsSet))
+}
+
+// FuzzClaimsSet decodes data as a type serialized CLAIMS_SET and checks the decoding invariants.
+func FuzzClaimsSet(data []byte) error {
+	return FuzzTypeSerialized[ClaimsSet](data)
+}
+
+// FuzzKerbValidationInfo decodes data as a type serialized KERB_VALIDATION_INFO, the logon information PAC buffer,
+// and checks the decoding invariants.
+func FuzzKerbValidationInfo(data []byte) error {
+	return FuzzTypeSerialized[KerbValidationInfo](data)
+}
+
+// FuzzDecompress decompresses data compressed with format, one of the CompressionFormat constants, to size bytes.
+// Output that does not have the size requested or does not survive compression and decompression breaks an
+// invariant.
+func FuzzDecompress(format uint16, data []byte, size int) error {
+	out, err := Decompress(format, data, size)
+	if err != nil {
+		return nil
+	}
+	if len(out) != size {
+		return fmt.Errorf("%d bytes decompressed but %d requested: %w", len(out), size, ErrInvariant)
+	}
+	b, err := Compress(format, out)
+	if err != nil {
+		return fmt.Errorf("decompressed data does not compress: %v: %w", err, ErrInvariant)
+	}
+	out2, err := Decompress(format, b, size)
+	if err != nil {
+		return fmt.Errorf("compressed data does not decompress: %v: %w", err, ErrInvariant)
+	}
+	if !bytes.Equal(out, out2) {
+		return fmt.Errorf("data not stable across compression: %w", ErrInvariant)
+	}
+	return nil
+}
+
+// FuzzPAC parses data as a PACTYPE and checks its directory encodes to bytes that parse to a PAC encoding
+// identically. Every buffer of a known type is decoded and, if it decodes, checked to round trip the same way.
+func FuzzPAC(data []byte) error {
+	var p PACType
+	if p.UnmarshalBinary(data) != nil {
+		return nil
+	}
+	for _, buf := range p.Buffers {
+		v := newPACBuffer(buf.ULType)
+		if v == nil || v.UnmarshalBinary(buf.Data) != nil {
+			continue
+		}
+		err := checkBinaryRoundTrip(v, newPACBuffer(buf.ULType))
+		if err != nil {
+			return fmt.Errorf("PAC buffer of type %d: %w", buf.ULType, err)
+		}
+	}
+	return checkBinaryRoundTrip(&p, new(PACType))
+}
+
+// newPACBuffer returns a new value of the typed PAC buffer of type ulType, nil for an unknown type.
+func newPACBuffer(ulType uint32) PACBuffer {
+	switch ulType {
+	case PACBufferTypeLogonInfo:
+		return new(KerbValidationInfo)
+	case PACBufferTypeCredentials:
+		return new(PACCredentialInfo)
+	case PACBufferTypeServerChecksum, PACBufferTypeKDCChecksum, PACBufferTypeTicketChecksum,
+		PACBufferTypeFullChecksum:
+		return &PACSignatureData{ULType: ulType}
+	case PACBufferTypeClientInfo:
+		return new(PACClientInfo)
+	case PACBufferTypeS4UDelegationInfo:
+		return new(S4UDelegationInfo)
+	case PACBufferTypeUPNDNSInfo:
+		return new(UPNDNSInfo)
+	case PACBufferTypeClientClaims:
+		return new(PACClientClaimsInfo)
+	case PACBufferTypeDeviceInfo:
+		return new(PACDeviceInfo)
+	case PACBufferTypeDeviceClaims:
+		return new(PACDeviceClaimsInfo)
+	case PACBufferTypeAttributes:
+		return new(PACAttributesInfo)
+	case PACBufferTypeRequestor:
+		return new(PACRequestor)
+	}
+	return nil
+}
+
+// binaryValue is implemented by the values with a binary encoding outside of NDR, such as the PAC and its buffers.
+type binaryValue interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(b []byte) error
+}
+
+// checkBinaryRoundTrip checks the value v, decoded from the fuzzed data, encodes to bytes that decode into the
+// empty value v2 encoding identically.
+func checkBinaryRoundTrip(v, v2 binaryValue) error {
+	b, err := v.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("decoded value does not encode: %v: %w", err, ErrInvariant)
+	}
+	err = v2.UnmarshalBinary(b)
+	if err != nil {
+		return fmt.Errorf("encoded value does not decode: %v: %w", err, ErrInvariant)
+	}
+	b2, err := v2.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("decoded encoding does not encode: %v: %w", err, ErrInvariant)
+	}
+	if !bytes.Equal(b, b2) {
+		return fmt.Errorf("encoding not stable across a round trip: %w", ErrInvariant)
+	}
+	return nil
+}
+
+// checkRoundTrip checks the value v, decoded from the fuzzed data, encodes to bytes that decode to a value encoding
+// identically.
+func checkRoundTrip[T any, P element[T]](v *T, encode func(P) ([]byte, error), decode func([]byte, P) error) error {
+	b, err := encode(P(v))
+	if err != nil {
+		return fmt.Errorf("decoded value does not encode: %v: %w", err, ErrInvariant)
+	}
+	var v2 T
+	err = decode(b, P(&v2))
+	if err != nil {
+		return fmt.Errorf("encoded value does not decode: %v: %w", err, ErrInvariant)
+	}
+	b2, err := encode(P(&v2))
+	if err != nil {
+		return fmt.Errorf("decoded encoding does not encode: %v: %w", err, ErrInvariant)
+	}
+	if !bytes.Equal(b, b2) {
+		return fmt.Errorf("encoding not stable across a round trip: %w", ErrInvariant)
+	}
+	return nil
+}
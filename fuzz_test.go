@@ -0,0 +1,69 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func Fuzz_ClaimsSetMetadata(f *testing.F) {
+	for _, s := range []string{ClientClaimsInfoStr, ClientClaimsInfoInt, ClientClaimsInfoMulti, ClientClaimsInfoMultiUint, ClientClaimsInfoMultiStr} {
+		b, _ := hex.DecodeString(s)
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := FuzzClaimsSetMetadata(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func Fuzz_DomainGroupMembership(f *testing.F) {
+	b, _ := hex.DecodeString("00000200" + "02000000" + "04000200" + "04000000" + "0104000000000005150000004c86cebca07160e63fdce887" + "02000000" + "0102000007000000" + "0002000007000000")
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := FuzzDecode[DomainGroupMembership](data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func Fuzz_KerbValidationInfo(f *testing.F) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := FuzzKerbValidationInfo(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func Fuzz_PAC(f *testing.F) {
+	b, _ := hex.DecodeString(TestPAC)
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := FuzzPAC(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func Fuzz_Decompress(f *testing.F) {
+	for _, format := range []uint16{CompressionFormatLZNT1, CompressionFormatXPress, CompressionFormatXPressHuff} {
+		in := []byte("abcabcabcabcabcabcabcabc claims claims claims")
+		b, err := Compress(format, in)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(format, b, len(in))
+	}
+	f.Fuzz(func(t *testing.T, format uint16, data []byte, size int) {
+		err := FuzzDecompress(format, data, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
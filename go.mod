@@ -5,7 +5,7 @@ go 1.24
 require (
 	github.com/jfjallid/ndr v0.0.0-20250515143046-14ad19ef61a6
 	github.com/stretchr/testify v1.10.0
-	golang.org/x/net v0.39.0
+	golang.org/x/text v0.24.0
 )
 
 require (
@@ -1,5 +1,11 @@
 package mstypes
 
+//go:generate go run ./cmd/ndrgen -type GroupMembership group_membership.go
+
+import (
+	"io"
+)
+
 // GroupMembership implements https://msdn.microsoft.com/en-us/library/cc237945.aspx
 // RelativeID : A 32-bit unsigned integer that contains the RID of a particular group.
 // The possible values for the Attributes flags are identical to those specified in KERB_SID_AND_ATTRIBUTES
@@ -17,3 +23,163 @@ type DomainGroupMembership struct {
 	GroupCount uint32
 	GroupIDs   []GroupMembership `ndr:"pointer,conformant"` // Size is value of GroupCount
 }
+
+// FromReader reads the DomainGroupMembership from r. The referents of its pointers are read when r.Deferred is called.
+func (d *DomainGroupMembership) FromReader(r *Reader) (err error) {
+	_, err = r.fieldPointer("DomainID", func() error {
+		return d.DomainID.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	d.GroupCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("GroupIDs", func() (err error) {
+		d.GroupIDs, err = readGroupMemberships(r)
+		return
+	})
+	return
+}
+
+// ToWriter writes the DomainGroupMembership to w.
+func (d *DomainGroupMembership) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Pointer(func() error {
+		return d.DomainID.ToWriter(nw)
+	})
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(d.GroupCount)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if d.GroupIDs != nil {
+		fn = func() error {
+			return writeGroupMemberships(nw, d.GroupIDs)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of DomainGroupMembership.
+func (d *DomainGroupMembership) Size() int {
+	return ndrSize(d)
+}
+
+// GroupMemberships is a conformant array of GROUP_MEMBERSHIP, the group RIDs of a domain with their attributes, as
+// held by KERB_VALIDATION_INFO, PAC_DEVICE_INFO and the SAMR group lists.
+type GroupMemberships []GroupMembership
+
+// NewGroupMemberships returns the GroupMemberships of the relative IDs rids, each with the attributes.
+func NewGroupMemberships(attributes uint32, rids ...uint32) GroupMemberships {
+	g := make(GroupMemberships, len(rids))
+	for i, rid := range rids {
+		g[i] = GroupMembership{RelativeID: rid, Attributes: attributes}
+	}
+	return g
+}
+
+// RelativeIDs returns the relative IDs of the groups, in order.
+func (g GroupMemberships) RelativeIDs() []uint32 {
+	v := make([]uint32, len(g))
+	for i := range g {
+		v[i] = g[i].RelativeID
+	}
+	return v
+}
+
+// Contains reports whether the group of the relative ID rid is a member.
+func (g GroupMemberships) Contains(rid uint32) bool {
+	for i := range g {
+		if g[i].RelativeID == rid {
+			return true
+		}
+	}
+	return false
+}
+
+// SIDs returns the SIDs of the groups, the domain SID with each relative ID appended, with their attributes.
+func (g GroupMemberships) SIDs(domain *RPCSID) ([]KerbSidAndAttributes, error) {
+	v := make([]KerbSidAndAttributes, len(g))
+	for i := range g {
+		sid, err := domain.WithRID(g[i].RelativeID)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = KerbSidAndAttributes{SID: *sid, Attributes: SIDAttributes(g[i].Attributes)}
+	}
+	return v, nil
+}
+
+// FromReader reads the GroupMemberships from r.
+func (g *GroupMemberships) FromReader(r *Reader) (err error) {
+	*g, err = readGroupMemberships(r)
+	return
+}
+
+// ToWriter writes the GroupMemberships to w.
+func (g *GroupMemberships) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = writeGroupMemberships(nw, *g)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of GroupMemberships.
+func (g *GroupMemberships) Size() int {
+	return ndrSize(g)
+}
+
+// readGroupMemberships reads a conformant array of GROUP_MEMBERSHIP.
+func readGroupMemberships(r *Reader) (GroupMemberships, error) {
+	return ReadConformantArray(r, 8, func(g *GroupMembership) error {
+		return g.FromReader(r)
+	})
+}
+
+// groupMembershipsReader reads the pointer of the field name to the GROUP_MEMBERSHIP array into v, checking
+// countName, the field holding count, matches its number of elements.
+type groupMembershipsReader func(r *Reader, name, countName string, count uint32, v *GroupMemberships) error
+
+// readGroupMembershipsPointer is the groupMembershipsReader decoding the array when r.Deferred is called.
+func readGroupMembershipsPointer(r *Reader, name, countName string, count uint32, v *GroupMemberships) error {
+	return readCountedPointer(r, name, countName, count, v, func() (GroupMemberships, error) {
+		return readGroupMemberships(r)
+	})
+}
+
+// writeGroupMemberships writes a conformant array of GROUP_MEMBERSHIP.
+func writeGroupMemberships(w *Writer, g []GroupMembership) error {
+	return WriteConformantArray(w, g, func(g *GroupMembership) error {
+		return g.ToWriter(w)
+	})
+}
+
+// writeGroupMembershipsPointer writes a pointer to a conformant array of GROUP_MEMBERSHIP, a null pointer if g is nil.
+func writeGroupMembershipsPointer(w *Writer, g []GroupMembership) error {
+	var fn func() error
+	if g != nil {
+		fn = func() error {
+			return writeGroupMemberships(w, g)
+		}
+	}
+	return w.Pointer(fn)
+}
@@ -0,0 +1,65 @@
+// Code generated by ndrgen; DO NOT EDIT.
+
+package mstypes
+
+import (
+	"io"
+)
+
+// FromReader reads the NDR representation of GroupMembership from r.
+func (s *GroupMembership) FromReader(r *Reader) (err error) {
+	err = r.Align(4)
+	if err != nil {
+		return
+	}
+	err = r.Field("RelativeID", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.RelativeID = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("Attributes", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.Attributes = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of GroupMembership to w.
+func (s *GroupMembership) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(4)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.RelativeID)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.Attributes)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of GroupMembership.
+func (s *GroupMembership) Size() int {
+	return 8
+}
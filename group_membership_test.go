@@ -0,0 +1,58 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GroupMembershipGenerated(t *testing.T) {
+	g := GroupMembership{RelativeID: 513, Attributes: 7}
+	var buf bytes.Buffer
+	err := g.ToWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0102000007000000", hex.EncodeToString(buf.Bytes()), "encoding not as expected")
+	assert.Equal(t, buf.Len(), g.Size(), "size not as expected")
+
+	var d GroupMembership
+	err = d.FromReader(NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, g, d, "decoded value not as expected")
+}
+
+func Test_GroupMemberships(t *testing.T) {
+	g := NewGroupMemberships(7, 513, 1108)
+	var buf bytes.Buffer
+	err := g.ToWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "02000000"+"0102000007000000"+"5404000007000000", hex.EncodeToString(buf.Bytes()), "encoding not as expected")
+	assert.Equal(t, buf.Len(), g.Size(), "size not as expected")
+
+	var d GroupMemberships
+	err = d.FromReader(NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, g, d, "decoded value not as expected")
+	assert.Equal(t, []uint32{513, 1108}, d.RelativeIDs(), "relative IDs not as expected")
+	assert.True(t, d.Contains(1108), "member not found")
+	assert.False(t, d.Contains(512), "non member found")
+
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	sids, err := d.SIDs(domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, sids, 2, "SIDs not as expected") {
+		assert.Equal(t, "S-1-5-21-1-2-3-1108", sids[1].SID.String(), "SID not as expected")
+		assert.Equal(t, SIDAttributesDefaultGroup, sids[1].Attributes, "attributes not as expected")
+	}
+}
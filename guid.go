@@ -0,0 +1,314 @@
+package mstypes
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GUIDSize is the number of bytes of a GUID.
+const GUIDSize = 16
+
+// ErrInvalidGUID is returned, wrapped, when a string is not a GUID.
+var ErrInvalidGUID = errors.New("invalid GUID")
+
+// GUID implements the Microsoft GUID type https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-dtyp/49e490b8-f972-45d6-a3a4-99f924998d97
+//
+// Windows stores Data1, Data2 and Data3 in little-endian byte order and Data4 as it is, unlike the RFC 4122 byte
+// order of a UUID that stores them all in big-endian byte order. The 16 bytes of an objectGUID or of the ObjectType
+// of an object ACE are in the Windows byte order.
+//
+// GUID is comparable, so it can be compared with == and used as a map key.
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// GUIDFromBytes returns the GUID of the 16 bytes b in the Windows byte order.
+func GUIDFromBytes(b []byte) (GUID, error) {
+	var g GUID
+	err := g.UnmarshalBinary(b)
+	return g, err
+}
+
+// FromObjectGUID returns the GUID of the raw objectGUID attribute value returned by an LDAP search of AD.
+func FromObjectGUID(b []byte) (GUID, error) {
+	return GUIDFromBytes(b)
+}
+
+// GUIDFromRFC4122 returns the GUID of the 16 bytes b in the RFC 4122 byte order.
+func GUIDFromRFC4122(b []byte) (GUID, error) {
+	if len(b) != GUIDSize {
+		return GUID{}, fmt.Errorf("GUID is %d bytes, not %d", len(b), GUIDSize)
+	}
+	g := GUID{
+		Data1: binary.BigEndian.Uint32(b[0:4]),
+		Data2: binary.BigEndian.Uint16(b[4:6]),
+		Data3: binary.BigEndian.Uint16(b[6:8]),
+	}
+	copy(g.Data4[:], b[8:])
+	return g, nil
+}
+
+// NewGUID returns a random version 4 GUID generated with crypto/rand.
+func NewGUID() GUID {
+	b := make([]byte, GUIDSize)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	g, _ := GUIDFromRFC4122(b)
+	return g
+}
+
+// RFC 4122 name space GUIDs for NewGUIDv5
+var (
+	NamespaceDNS  = mustParseGUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceURL  = mustParseGUID("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceOID  = mustParseGUID("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceX500 = mustParseGUID("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// NewGUIDv5 returns the version 5 GUID derived from the SHA-1 hash of the name space namespace and the name, so the
+// same name always gives the same GUID.
+func NewGUIDv5(namespace GUID, name []byte) GUID {
+	h := sha1.New()
+	h.Write(namespace.RFC4122Bytes())
+	h.Write(name)
+	b := h.Sum(nil)[:GUIDSize]
+	b[6] = b[6]&0x0f | 0x50 // version 5
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	g, _ := GUIDFromRFC4122(b)
+	return g
+}
+
+// Version returns the version of a GUID of the RFC 4122 variant, the top 4 bits of Data3.
+func (g GUID) Version() int {
+	return int(g.Data3 >> 12)
+}
+
+// IsZero reports whether the GUID is the nil GUID 00000000-0000-0000-0000-000000000000, which object ACEs and AD
+// attributes use for a GUID that is not set.
+func (g GUID) IsZero() bool {
+	return g == GUID{}
+}
+
+// Equal reports whether g and o are the same GUID.
+func (g GUID) Equal(o GUID) bool {
+	return g == o
+}
+
+// Compare returns -1, 0 or +1 as g sorts before, equal to or after o in the order of their string forms.
+func (g GUID) Compare(o GUID) int {
+	return bytes.Compare(g.RFC4122Bytes(), o.RFC4122Bytes())
+}
+
+// ParseGUID parses the string form of a GUID, such as 1131f6aa-9c07-11d1-f79f-00c04fc2dcd2, in either case. The GUID
+// may be enclosed in braces, as in SDDL and the registry, prefixed with urn:uuid:, or written without dashes as 32
+// hexadecimal digits.
+func ParseGUID(s string) (GUID, error) {
+	v := s
+	switch {
+	case len(v) >= 2 && v[0] == '{' && v[len(v)-1] == '}':
+		v = v[1 : len(v)-1]
+	case len(v) >= len("urn:uuid:") && strings.EqualFold(v[:len("urn:uuid:")], "urn:uuid:"):
+		v = v[len("urn:uuid:"):]
+	}
+	switch len(v) {
+	case 36:
+		for _, i := range []int{8, 13, 18, 23} {
+			if v[i] != '-' {
+				return GUID{}, fmt.Errorf("%w %q: expected a dash at position %d", ErrInvalidGUID, s, i)
+			}
+		}
+		v = v[0:8] + v[9:13] + v[14:18] + v[19:23] + v[24:]
+	case 32:
+	default:
+		return GUID{}, fmt.Errorf("%w %q: expected 32 hexadecimal digits with or without dashes", ErrInvalidGUID, s)
+	}
+	b, err := hex.DecodeString(v)
+	if err != nil {
+		return GUID{}, fmt.Errorf("%w %q: %v", ErrInvalidGUID, s, err)
+	}
+	return GUIDFromRFC4122(b)
+}
+
+// String returns the GUID in its lowercase dashed form, such as 1131f6aa-9c07-11d1-f79f-00c04fc2dcd2.
+func (g GUID) String() string {
+	b := g.RFC4122Bytes()
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Braced returns the GUID in its lowercase dashed form enclosed in braces, as used by SDDL and the registry.
+func (g GUID) Braced() string {
+	return "{" + g.String() + "}"
+}
+
+// Hex returns the GUID as 32 lowercase hexadecimal digits without dashes, in the order of the string form.
+func (g GUID) Hex() string {
+	return hex.EncodeToString(g.RFC4122Bytes())
+}
+
+// Format implements fmt.Formatter. The verbs %s and %v format the GUID as String does, or as Braced does with the #
+// flag. The verb %x formats it as Hex does and %X does the same in uppercase. The verb %q formats the String form
+// quoted. Width and the - flag pad the result as for strings.
+func (g GUID) Format(f fmt.State, verb rune) {
+	var v string
+	switch verb {
+	case 's', 'v', 'q':
+		v = g.String()
+		if f.Flag('#') && verb != 'q' {
+			v = g.Braced()
+		}
+	case 'x':
+		v = g.Hex()
+	case 'X':
+		v = strings.ToUpper(g.Hex())
+	default:
+		fmt.Fprintf(f, "%%!%c(GUID=%s)", verb, g.String())
+		return
+	}
+	if verb == 'q' {
+		v = `"` + v + `"`
+	}
+	width, ok := f.Width()
+	if ok && width > len(v) {
+		pad := strings.Repeat(" ", width-len(v))
+		if f.Flag('-') {
+			v += pad
+		} else {
+			v = pad + v
+		}
+	}
+	io.WriteString(f, v)
+}
+
+// LDAPFilterString returns the GUID as the escaped bytes of an LDAP filter value, so AD objects can be searched for
+// with a filter such as (objectGUID=\aa\f6\31\11\07\9c\d1\11\f7\9f\00\c0\4f\c2\dc\d2).
+func (g GUID) LDAPFilterString() string {
+	var sb strings.Builder
+	sb.Grow(3 * GUIDSize)
+	for _, c := range g.Bytes() {
+		fmt.Fprintf(&sb, `\%02x`, c)
+	}
+	return sb.String()
+}
+
+// MarshalText returns the GUID in its String form. It makes the GUID encode as a JSON string.
+func (g GUID) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText sets the GUID from any of the string forms ParseGUID accepts.
+func (g *GUID) UnmarshalText(b []byte) (err error) {
+	*g, err = ParseGUID(string(b))
+	return
+}
+
+// Bytes returns the 16 bytes of the GUID in the Windows byte order.
+func (g GUID) Bytes() []byte {
+	b := make([]byte, GUIDSize)
+	binary.LittleEndian.PutUint32(b[0:4], g.Data1)
+	binary.LittleEndian.PutUint16(b[4:6], g.Data2)
+	binary.LittleEndian.PutUint16(b[6:8], g.Data3)
+	copy(b[8:], g.Data4[:])
+	return b
+}
+
+// RFC4122Bytes returns the 16 bytes of the GUID in the RFC 4122 byte order.
+func (g GUID) RFC4122Bytes() []byte {
+	b := make([]byte, GUIDSize)
+	binary.BigEndian.PutUint32(b[0:4], g.Data1)
+	binary.BigEndian.PutUint16(b[4:6], g.Data2)
+	binary.BigEndian.PutUint16(b[6:8], g.Data3)
+	copy(b[8:], g.Data4[:])
+	return b
+}
+
+// MarshalBinary returns the 16 bytes of the GUID in the Windows byte order.
+func (g GUID) MarshalBinary() ([]byte, error) {
+	return g.Bytes(), nil
+}
+
+// UnmarshalBinary sets the GUID from its 16 bytes in the Windows byte order.
+func (g *GUID) UnmarshalBinary(b []byte) error {
+	if len(b) != GUIDSize {
+		return fmt.Errorf("GUID is %d bytes, not %d", len(b), GUIDSize)
+	}
+	g.Data1 = binary.LittleEndian.Uint32(b[0:4])
+	g.Data2 = binary.LittleEndian.Uint16(b[4:6])
+	g.Data3 = binary.LittleEndian.Uint16(b[6:8])
+	copy(g.Data4[:], b[8:])
+	return nil
+}
+
+// GUID reads a GUID without aligning the stream, as in the flat layout of an object ACE.
+func (r *Reader) GUID() (g GUID, err error) {
+	g.Data1, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	g.Data2, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	g.Data3, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	b, err := r.ReadBytes(len(g.Data4))
+	if err != nil {
+		return
+	}
+	copy(g.Data4[:], b)
+	return
+}
+
+// GUID writes a GUID without aligning the stream, as in the flat layout of an object ACE.
+func (w *Writer) GUID(g GUID) error {
+	err := w.Uint32(g.Data1)
+	if err != nil {
+		return err
+	}
+	err = w.Uint16(g.Data2)
+	if err != nil {
+		return err
+	}
+	err = w.Uint16(g.Data3)
+	if err != nil {
+		return err
+	}
+	return w.WriteBytes(g.Data4[:])
+}
+
+// FromReader reads the GUID from r.
+func (g *GUID) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	*g, err = r.GUID()
+	return
+}
+
+// ToWriter writes the GUID to w.
+func (g *GUID) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	return nw.GUID(*g)
+}
+
+// Size returns the number of bytes of the NDR representation of GUID.
+func (g *GUID) Size() int {
+	return GUIDSize
+}
@@ -0,0 +1,183 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGUID is the DS-Replication-Get-Changes control access right 1131f6aa-9c07-11d1-f79f-00c04fc2dcd2.
+var TestGUID = GUID{Data1: 0x1131f6aa, Data2: 0x9c07, Data3: 0x11d1, Data4: [8]byte{0xf7, 0x9f, 0x00, 0xc0, 0x4f, 0xc2, 0xdc, 0xd2}}
+
+const (
+	TestGUIDWindows = "aaf63111079cd111f79f00c04fc2dcd2"
+	TestGUIDRFC4122 = "1131f6aa9c0711d1f79f00c04fc2dcd2"
+)
+
+func Test_GUIDBytes(t *testing.T) {
+	assert.Equal(t, TestGUIDWindows, hex.EncodeToString(TestGUID.Bytes()))
+	assert.Equal(t, TestGUIDRFC4122, hex.EncodeToString(TestGUID.RFC4122Bytes()))
+
+	b, _ := hex.DecodeString(TestGUIDWindows)
+	g, err := GUIDFromBytes(b)
+	assert.NoError(t, err)
+	assert.Equal(t, TestGUID, g)
+	b, _ = hex.DecodeString(TestGUIDRFC4122)
+	g, err = GUIDFromRFC4122(b)
+	assert.NoError(t, err)
+	assert.Equal(t, TestGUID, g)
+
+	_, err = GUIDFromBytes(b[:15])
+	assert.Error(t, err, "short GUID not detected")
+	_, err = GUIDFromRFC4122(b[:15])
+	assert.Error(t, err, "short GUID not detected")
+}
+
+func Test_GUIDNDR(t *testing.T) {
+	b, err := Marshal(&TestGUID)
+	assert.NoError(t, err)
+	assert.Equal(t, TestGUIDWindows, hex.EncodeToString(b), "NDR encoding not the Windows byte order")
+	var g GUID
+	assert.NoError(t, Unmarshal(b, &g))
+	assert.Equal(t, TestGUID, g)
+
+	w := NewAppendWriter(nil)
+	assert.NoError(t, w.Uint8(1))
+	assert.NoError(t, TestGUID.ToWriter(w))
+	assert.Equal(t, "01000000"+TestGUIDWindows, hex.EncodeToString(w.Bytes()), "GUID not aligned to 4 bytes")
+}
+
+func Test_ParseGUID(t *testing.T) {
+	var tests = []string{
+		"1131f6aa-9c07-11d1-f79f-00c04fc2dcd2",
+		"1131F6AA-9C07-11D1-F79F-00C04FC2DCD2",
+		"{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2}",
+		"{1131F6AA-9C07-11D1-F79F-00C04FC2DCD2}",
+		"1131f6aa9c0711d1f79f00c04fc2dcd2",
+		"{1131f6aa9c0711d1f79f00c04fc2dcd2}",
+		"urn:uuid:1131f6aa-9c07-11d1-f79f-00c04fc2dcd2",
+		"URN:UUID:1131f6aa9c0711d1f79f00c04fc2dcd2",
+	}
+	for _, test := range tests {
+		g, err := ParseGUID(test)
+		assert.NoError(t, err, test)
+		assert.Equal(t, TestGUID, g, "%s not parsed as expected", test)
+	}
+
+	var invalid = []string{
+		"",
+		"{}",
+		"1131f6aa-9c07-11d1-f79f-00c04fc2dcd",
+		"1131f6aa-9c07-11d1-f79f-00c04fc2dcd2a",
+		"1131f6aa:9c07-11d1-f79f-00c04fc2dcd2",
+		"1131f6aa-9c07-11d1-f79f-00c04fc2dcdg",
+		"{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2",
+		"urn:uuid:{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2}",
+		"1131f6aa-9c0711d1-f79f-00c04fc2dcd2",
+	}
+	for _, test := range invalid {
+		_, err := ParseGUID(test)
+		assert.ErrorIs(t, err, ErrInvalidGUID, "%q not detected as invalid", test)
+	}
+}
+
+func Test_GUIDFormat(t *testing.T) {
+	assert.Equal(t, "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2", TestGUID.String())
+	assert.Equal(t, "{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2}", TestGUID.Braced())
+	assert.Equal(t, "1131f6aa9c0711d1f79f00c04fc2dcd2", TestGUID.Hex())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", GUID{}.String())
+
+	var tests = []struct {
+		Format, Out string
+	}{
+		{"%s", "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2"},
+		{"%v", "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2"},
+		{"%#v", "{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2}"},
+		{"%#s", "{1131f6aa-9c07-11d1-f79f-00c04fc2dcd2}"},
+		{"%x", "1131f6aa9c0711d1f79f00c04fc2dcd2"},
+		{"%X", "1131F6AA9C0711D1F79F00C04FC2DCD2"},
+		{"%q", `"1131f6aa-9c07-11d1-f79f-00c04fc2dcd2"`},
+		{"%40s|", "    1131f6aa-9c07-11d1-f79f-00c04fc2dcd2|"},
+		{"%-40s|", "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2    |"},
+		{"%d", "%!d(GUID=1131f6aa-9c07-11d1-f79f-00c04fc2dcd2)"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.Out, fmt.Sprintf(test.Format, TestGUID), "%s not formatted as expected", test.Format)
+	}
+	g := TestGUID
+	assert.Equal(t, "1131f6aa-9c07-11d1-f79f-00c04fc2dcd2", fmt.Sprint(&g), "GUID pointer not formatted")
+
+	for _, s := range []string{TestGUID.String(), TestGUID.Braced(), TestGUID.Hex()} {
+		g, err := ParseGUID(s)
+		assert.NoError(t, err)
+		assert.Equal(t, TestGUID, g)
+	}
+}
+
+func Test_NewGUID(t *testing.T) {
+	seen := make(map[GUID]bool)
+	for range 100 {
+		g := NewGUID()
+		assert.Equal(t, 4, g.Version())
+		assert.Equal(t, byte(0x80), g.Data4[0]&0xc0, "variant not RFC 4122")
+		assert.False(t, seen[g], "GUID %s repeated", g)
+		seen[g] = true
+	}
+	assert.Equal(t, 1, TestGUID.Version())
+}
+
+func Test_GUIDJSON(t *testing.T) {
+	v := struct {
+		ObjectType GUID
+		Inherited  *GUID `json:",omitempty"`
+	}{ObjectType: TestGUID}
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ObjectType":"1131f6aa-9c07-11d1-f79f-00c04fc2dcd2"}`, string(b))
+
+	v.ObjectType = GUID{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"ObjectType":"{1131F6AA-9C07-11D1-F79F-00C04FC2DCD2}"}`), &v))
+	assert.Equal(t, TestGUID, v.ObjectType)
+	err = json.Unmarshal([]byte(`{"ObjectType":"not a guid"}`), &v)
+	assert.ErrorIs(t, err, ErrInvalidGUID)
+	assert.Error(t, json.Unmarshal([]byte(`{"ObjectType":16}`), &v))
+}
+
+func Test_GUIDCompare(t *testing.T) {
+	assert.True(t, GUID{}.IsZero())
+	assert.False(t, TestGUID.IsZero())
+	g, _ := ParseGUID(TestGUID.Braced())
+	assert.True(t, TestGUID.Equal(g))
+	assert.False(t, TestGUID.Equal(GUID{}))
+
+	// Data1 is compared as a number, not in its little-endian byte order
+	a, _ := ParseGUID("000000ff-0000-0000-0000-000000000000")
+	b, _ := ParseGUID("00000100-0000-0000-0000-000000000000")
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(a))
+
+	rights := map[GUID]string{TestGUID: "DS-Replication-Get-Changes"}
+	assert.Equal(t, "DS-Replication-Get-Changes", rights[g])
+}
+
+func Test_GUIDObjectGUID(t *testing.T) {
+	b, _ := hex.DecodeString(TestGUIDWindows)
+	g, err := FromObjectGUID(b)
+	assert.NoError(t, err)
+	assert.Equal(t, TestGUID, g)
+	_, err = FromObjectGUID(b[1:])
+	assert.Error(t, err, "short objectGUID not detected")
+	assert.Equal(t, `\aa\f6\31\11\07\9c\d1\11\f7\9f\00\c0\4f\c2\dc\d2`, g.LDAPFilterString())
+}
+
+func Test_NewGUIDv5(t *testing.T) {
+	g := NewGUIDv5(NamespaceDNS, []byte("python.org"))
+	assert.Equal(t, "886313e1-3b8a-5372-9b90-0c9aee199e5d", g.String())
+	assert.Equal(t, 5, g.Version())
+	assert.Equal(t, g, NewGUIDv5(NamespaceDNS, []byte("python.org")), "derivation not deterministic")
+	assert.NotEqual(t, g, NewGUIDv5(NamespaceURL, []byte("python.org")))
+}
@@ -1,7 +1,17 @@
 package mstypes
 
+import (
+	"fmt"
+	"io"
+)
+
 // Attributes of a security group membership and can be combined by using the bitwise OR operation.
 // They are used by an access check mechanism to specify whether the membership is to be used in an access check decision.
+// The values are bit indexes counted from the most significant bit, to be set with SetFlag.
+//
+// Deprecated: use the SIDAttribute constants, which are masks of type SIDAttributes. SEGroupMandatory is
+// SIDAttributeMandatory, SEGroupEnabledByDefault is SIDAttributeEnabledByDefault, SEGroupEnabled is
+// SIDAttributeEnabled, SEGroupOwner is SIDAttributeOwner and SEGroupResource is SIDAttributeResource.
 const (
 	SEGroupMandatory        = 31
 	SEGroupEnabledByDefault = 30
@@ -11,13 +21,109 @@ const (
 	//All other bits MUST be set to zero and MUST be  ignored on receipt.
 )
 
+// SIDAttributes are the attributes of a group SID held by KERB_SID_AND_ATTRIBUTES and GROUP_MEMBERSHIP, the
+// SE_GROUP flags of the token group.
+type SIDAttributes uint32
+
+// SID attribute flags
+const (
+	SIDAttributeMandatory        SIDAttributes = 0x00000001 // SE_GROUP_MANDATORY
+	SIDAttributeEnabledByDefault SIDAttributes = 0x00000002 // SE_GROUP_ENABLED_BY_DEFAULT
+	SIDAttributeEnabled          SIDAttributes = 0x00000004 // SE_GROUP_ENABLED
+	SIDAttributeOwner            SIDAttributes = 0x00000008 // SE_GROUP_OWNER
+	SIDAttributeUseForDenyOnly   SIDAttributes = 0x00000010 // SE_GROUP_USE_FOR_DENY_ONLY
+	SIDAttributeIntegrity        SIDAttributes = 0x00000020 // SE_GROUP_INTEGRITY
+	SIDAttributeIntegrityEnabled SIDAttributes = 0x00000040 // SE_GROUP_INTEGRITY_ENABLED
+	SIDAttributeResource         SIDAttributes = 0x20000000 // SE_GROUP_RESOURCE, a domain local group of the resource domain.
+	SIDAttributeLogonID          SIDAttributes = 0xc0000000 // SE_GROUP_LOGON_ID
+	SIDAttributesDefaultGroup                  = SIDAttributeMandatory | SIDAttributeEnabledByDefault | SIDAttributeEnabled
+	SIDAttributesDefaultResource               = SIDAttributesDefaultGroup | SIDAttributeResource
+)
+
+var sidAttributeNames = []flagName[SIDAttributes]{
+	{SIDAttributeMandatory, "Mandatory"},
+	{SIDAttributeEnabledByDefault, "EnabledByDefault"},
+	{SIDAttributeEnabled, "Enabled"},
+	{SIDAttributeOwner, "Owner"},
+	{SIDAttributeUseForDenyOnly, "UseForDenyOnly"},
+	{SIDAttributeIntegrity, "Integrity"},
+	{SIDAttributeIntegrityEnabled, "IntegrityEnabled"},
+	{SIDAttributeLogonID, "LogonID"},
+	{SIDAttributeResource, "Resource"},
+}
+
+// Has reports whether all the flags f are set.
+func (a SIDAttributes) Has(f SIDAttributes) bool {
+	return a&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (a SIDAttributes) String() string {
+	return formatFlags(a, sidAttributeNames)
+}
+
 // KerbSidAndAttributes implements https://msdn.microsoft.com/en-us/library/cc237947.aspx
 type KerbSidAndAttributes struct {
 	SID        RPCSID `ndr:"pointer"` // A pointer to an RPC_SID structure.
-	Attributes uint32
+	Attributes SIDAttributes
 }
 
-// SetFlag sets a flag in a uint32 attribute value.
+// String returns the SID followed by its attributes.
+func (k *KerbSidAndAttributes) String() string {
+	return fmt.Sprintf("%s (%s)", &k.SID, k.Attributes)
+}
+
+// SetFlag sets a flag in a uint32 attribute value, i being a bit index such as SEGroupMandatory.
+//
+// Deprecated: combine SIDAttributes with the bitwise OR operation, a.Attributes |= SIDAttributeMandatory.
 func SetFlag(a *uint32, i uint) {
 	*a = *a | (1 << (31 - i))
 }
+
+// FromReader reads the KerbSidAndAttributes from r. The SID is read when r.Deferred is called.
+func (k *KerbSidAndAttributes) FromReader(r *Reader) (err error) {
+	_, err = r.fieldPointer("SID", func() error {
+		return k.SID.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	a, err := r.Uint32()
+	k.Attributes = SIDAttributes(a)
+	return
+}
+
+// ToWriter writes the KerbSidAndAttributes to w.
+func (k *KerbSidAndAttributes) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Pointer(func() error {
+		return k.SID.ToWriter(nw)
+	})
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(uint32(k.Attributes))
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of KerbSidAndAttributes.
+func (k *KerbSidAndAttributes) Size() int {
+	return ndrSize(k)
+}
+
+// readKerbSidAndAttributes reads a conformant array of KERB_SID_AND_ATTRIBUTES.
+func readKerbSidAndAttributes(r *Reader) ([]KerbSidAndAttributes, error) {
+	return ReadConformantArray(r, 8, func(k *KerbSidAndAttributes) error {
+		return k.FromReader(r)
+	})
+}
+
+// writeKerbSidAndAttributes writes a conformant array of KERB_SID_AND_ATTRIBUTES.
+func writeKerbSidAndAttributes(w *Writer, k []KerbSidAndAttributes) error {
+	return WriteConformantArray(w, k, func(k *KerbSidAndAttributes) error {
+		return k.ToWriter(w)
+	})
+}
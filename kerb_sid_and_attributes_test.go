@@ -0,0 +1,79 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SIDAttributesString(t *testing.T) {
+	var tests = []struct {
+		a    SIDAttributes
+		want string
+	}{
+		{0, "0"},
+		{SIDAttributesDefaultGroup, "Mandatory|EnabledByDefault|Enabled"},
+		{SIDAttributesDefaultResource, "Mandatory|EnabledByDefault|Enabled|Resource"},
+		{SIDAttributeLogonID | SIDAttributeOwner, "Owner|LogonID"},
+		{SIDAttributeEnabled | 0x100, "Enabled|0x100"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.a.String(), "string of 0x%x not as expected", uint32(test.a))
+	}
+	assert.True(t, SIDAttributesDefaultResource.Has(SIDAttributeResource|SIDAttributeEnabled), "flags not found")
+	assert.False(t, SIDAttributesDefaultGroup.Has(SIDAttributeResource), "flag found")
+}
+
+func Test_SetFlag(t *testing.T) {
+	var tests = []struct {
+		i    uint
+		want SIDAttributes
+	}{
+		{SEGroupMandatory, SIDAttributeMandatory},
+		{SEGroupEnabledByDefault, SIDAttributeEnabledByDefault},
+		{SEGroupEnabled, SIDAttributeEnabled},
+		{SEGroupOwner, SIDAttributeOwner},
+		{SEGroupResource, SIDAttributeResource},
+	}
+	for _, test := range tests {
+		var a uint32
+		SetFlag(&a, test.i)
+		assert.Equal(t, test.want, SIDAttributes(a), "flag of bit index %d not as expected", test.i)
+	}
+}
+
+func Test_KerbSidAndAttributes(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-18-1")
+	k := KerbSidAndAttributes{SID: *sid, Attributes: SIDAttributesDefaultGroup}
+	assert.Equal(t, "S-1-18-1 (Mandatory|EnabledByDefault|Enabled)", k.String(), "string not as expected")
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	err := w.Pointer(func() error {
+		return k.ToWriter(w)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "00000200"+"04000200"+"07000000"+"01000000"+"010100000000001201000000", hex.EncodeToString(buf.Bytes()), "encoding not as expected")
+
+	var d KerbSidAndAttributes
+	r := NewReader(&buf)
+	_, err = r.Pointer(func() error {
+		return d.FromReader(r)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, k, d, "decoded value not as expected")
+}
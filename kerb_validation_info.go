@@ -0,0 +1,527 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+// ValidationInfo holds the fields from LogonTime to LogonDomainId which KERB_VALIDATION_INFO shares with the
+// NETLOGON_VALIDATION_SAM_INFO structures of MS-NRPC. KerbValidationInfo, NetlogonValidationSAMInfo2 and
+// NetlogonValidationSAMInfo4 embed it.
+type ValidationInfo struct {
+	LogOnTime          FileTime
+	LogOffTime         FileTime
+	KickOffTime        FileTime
+	PasswordLastSet    FileTime
+	PasswordCanChange  FileTime
+	PasswordMustChange FileTime
+	EffectiveName      RPCUnicodeString
+	FullName           RPCUnicodeString
+	LogonScript        RPCUnicodeString
+	ProfilePath        RPCUnicodeString
+	HomeDirectory      RPCUnicodeString
+	HomeDirectoryDrive RPCUnicodeString
+	LogonCount         uint16
+	BadPasswordCount   uint16
+	UserID             uint32
+	PrimaryGroupID     uint32
+	GroupCount         uint32
+	GroupIDs           GroupMemberships // A pointer to GroupCount GROUP_MEMBERSHIP, nil if the pointer is null.
+	UserFlags          UserFlags
+	UserSessionKey     UserSessionKey
+	LogonServer        RPCUnicodeString
+	LogonDomainName    RPCUnicodeString
+	LogonDomainID      *RPCSID // nil if the pointer is null.
+}
+
+// UserSID returns the SID of the user, the LogonDomainID with the UserID appended.
+func (v *ValidationInfo) UserSID() (*RPCSID, error) {
+	if v.LogonDomainID == nil {
+		return nil, fmt.Errorf("validation information has no logon domain ID")
+	}
+	return v.LogonDomainID.WithRID(v.UserID)
+}
+
+// fromReader reads the ValidationInfo from r, the GroupIDs with groups. The referents of its pointers are read when
+// r.Deferred is called. GroupCount must match the number of elements of GroupIDs, 0 for a null pointer.
+func (v *ValidationInfo) fromReader(r *Reader, groups groupMembershipsReader) (err error) {
+	for _, f := range []struct {
+		name string
+		ft   *FileTime
+	}{
+		{"LogOnTime", &v.LogOnTime},
+		{"LogOffTime", &v.LogOffTime},
+		{"KickOffTime", &v.KickOffTime},
+		{"PasswordLastSet", &v.PasswordLastSet},
+		{"PasswordCanChange", &v.PasswordCanChange},
+		{"PasswordMustChange", &v.PasswordMustChange},
+	} {
+		err = r.Field(f.name, func() error {
+			return f.ft.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	for _, f := range []struct {
+		name string
+		s    *RPCUnicodeString
+	}{
+		{"EffectiveName", &v.EffectiveName},
+		{"FullName", &v.FullName},
+		{"LogonScript", &v.LogonScript},
+		{"ProfilePath", &v.ProfilePath},
+		{"HomeDirectory", &v.HomeDirectory},
+		{"HomeDirectoryDrive", &v.HomeDirectoryDrive},
+	} {
+		err = r.Field(f.name, func() error {
+			return f.s.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	v.LogonCount, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	v.BadPasswordCount, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	for _, u := range []*uint32{&v.UserID, &v.PrimaryGroupID, &v.GroupCount} {
+		*u, err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	err = groups(r, "GroupIDs", "GroupCount", v.GroupCount, &v.GroupIDs)
+	if err != nil {
+		return
+	}
+	f, err := r.Uint32()
+	if err != nil {
+		return
+	}
+	v.UserFlags = UserFlags(f)
+	err = r.Field("UserSessionKey", func() error {
+		return v.UserSessionKey.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("LogonServer", func() error {
+		return v.LogonServer.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("LogonDomainName", func() error {
+		return v.LogonDomainName.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	v.LogonDomainID, err = readSIDPointer(r, "LogonDomainID")
+	return
+}
+
+// toWriter writes the ValidationInfo to w. An error is returned if GroupCount does not match the number of elements
+// of GroupIDs.
+func (v *ValidationInfo) toWriter(w *Writer) (err error) {
+	err = checkArrayCount("GroupCount", v.GroupCount, len(v.GroupIDs))
+	if err != nil {
+		return
+	}
+	for _, ft := range []*FileTime{
+		&v.LogOnTime, &v.LogOffTime, &v.KickOffTime,
+		&v.PasswordLastSet, &v.PasswordCanChange, &v.PasswordMustChange,
+	} {
+		err = ft.ToWriter(w)
+		if err != nil {
+			return
+		}
+	}
+	for _, s := range []*RPCUnicodeString{
+		&v.EffectiveName, &v.FullName, &v.LogonScript,
+		&v.ProfilePath, &v.HomeDirectory, &v.HomeDirectoryDrive,
+	} {
+		err = s.ToWriter(w)
+		if err != nil {
+			return
+		}
+	}
+	err = w.Uint16(v.LogonCount)
+	if err != nil {
+		return
+	}
+	err = w.Uint16(v.BadPasswordCount)
+	if err != nil {
+		return
+	}
+	for _, u := range []uint32{v.UserID, v.PrimaryGroupID, v.GroupCount} {
+		err = w.Uint32(u)
+		if err != nil {
+			return
+		}
+	}
+	err = writeGroupMembershipsPointer(w, v.GroupIDs)
+	if err != nil {
+		return
+	}
+	err = w.Uint32(uint32(v.UserFlags))
+	if err != nil {
+		return
+	}
+	err = v.UserSessionKey.ToWriter(w)
+	if err != nil {
+		return
+	}
+	err = v.LogonServer.ToWriter(w)
+	if err != nil {
+		return
+	}
+	err = v.LogonDomainName.ToWriter(w)
+	if err != nil {
+		return
+	}
+	return writeSIDPointer(w, v.LogonDomainID)
+}
+
+// KerbValidationInfo implements KERB_VALIDATION_INFO, the logon information PAC buffer
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/69e86ccc-85e3-41b9-b514-7d969cd0ed73
+type KerbValidationInfo struct {
+	ValidationInfo
+	Reserved1              [2]uint32
+	UserAccountControl     UserAccountFlags // The USER_ACCOUNT codes, not the UF_ flags of Active Directory.
+	SubAuthStatus          uint32
+	LastSuccessfulILogon   FileTime
+	LastFailedILogon       FileTime
+	FailedILogonCount      uint32
+	Reserved3              uint32
+	SIDCount               uint32
+	ExtraSIDs              []KerbSidAndAttributes // A pointer to SIDCount KERB_SID_AND_ATTRIBUTES, nil if the pointer is null.
+	ResourceGroupDomainSID *RPCSID                // nil if the pointer is null.
+	ResourceGroupCount     uint32
+	ResourceGroupIDs       GroupMemberships // A pointer to ResourceGroupCount GROUP_MEMBERSHIP, nil if the pointer is null.
+}
+
+// PACBufferType returns PACBufferTypeLogonInfo.
+func (k *KerbValidationInfo) PACBufferType() uint32 {
+	return PACBufferTypeLogonInfo
+}
+
+// SIDs returns the SIDs of the token Windows builds from the logon information, in order: the user SID, the groups
+// of GroupIDs in the logon domain, the primary group if it is not one of them, the ExtraSIDs if the UserFlags has
+// LogonExtraSIDs and the groups of ResourceGroupIDs in the resource group domain if it has LogonResourceGroups. The
+// user SID has no attributes; a SID listed more than once is only returned the first time.
+func (k *KerbValidationInfo) SIDs() ([]KerbSidAndAttributes, error) {
+	user, err := k.UserSID()
+	if err != nil {
+		return nil, err
+	}
+	sids := []KerbSidAndAttributes{{SID: *user}}
+	groups, err := k.GroupIDs.SIDs(k.LogonDomainID)
+	if err != nil {
+		return nil, err
+	}
+	sids = append(sids, groups...)
+	if !k.GroupIDs.Contains(k.PrimaryGroupID) {
+		primary, err := k.LogonDomainID.WithRID(k.PrimaryGroupID)
+		if err != nil {
+			return nil, err
+		}
+		sids = append(sids, KerbSidAndAttributes{SID: *primary, Attributes: SIDAttributesDefaultGroup})
+	}
+	if k.UserFlags&LogonExtraSIDs != 0 {
+		sids = append(sids, k.ExtraSIDs...)
+	}
+	if k.UserFlags&LogonResourceGroups != 0 && k.ResourceGroupDomainSID != nil {
+		groups, err = k.ResourceGroupIDs.SIDs(k.ResourceGroupDomainSID)
+		if err != nil {
+			return nil, err
+		}
+		sids = append(sids, groups...)
+	}
+	seen := make(map[string]bool, len(sids))
+	v := sids[:0]
+	for _, s := range sids {
+		key := s.SID.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		v = append(v, s)
+	}
+	return v, nil
+}
+
+// UnmarshalBinary reads the KerbValidationInfo from the type serialized PAC buffer b.
+func (k *KerbValidationInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, k)
+}
+
+// MarshalBinary returns the KerbValidationInfo as a type serialized PAC buffer.
+func (k *KerbValidationInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(k)
+}
+
+// FromReader reads the KerbValidationInfo from r. The referents of its pointers are read when r.Deferred is called.
+// GroupCount, SIDCount and ResourceGroupCount must match the number of elements of their arrays, 0 for a null pointer.
+func (k *KerbValidationInfo) FromReader(r *Reader) error {
+	return k.fromReader(r, readGroupMembershipsPointer)
+}
+
+// fromReader reads the KerbValidationInfo from r, the GroupIDs and ResourceGroupIDs with groups.
+func (k *KerbValidationInfo) fromReader(r *Reader, groups groupMembershipsReader) (err error) {
+	err = k.ValidationInfo.fromReader(r, groups)
+	if err != nil {
+		return
+	}
+	for _, v := range []*uint32{&k.Reserved1[0], &k.Reserved1[1], (*uint32)(&k.UserAccountControl), &k.SubAuthStatus} {
+		*v, err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	err = r.Field("LastSuccessfulILogon", func() error {
+		return k.LastSuccessfulILogon.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("LastFailedILogon", func() error {
+		return k.LastFailedILogon.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	for _, v := range []*uint32{&k.FailedILogonCount, &k.Reserved3} {
+		*v, err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	err = readExtraSIDs(r, &k.SIDCount, &k.ExtraSIDs)
+	if err != nil {
+		return
+	}
+	k.ResourceGroupDomainSID, err = readSIDPointer(r, "ResourceGroupDomainSID")
+	if err != nil {
+		return
+	}
+	k.ResourceGroupCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = groups(r, "ResourceGroupIDs", "ResourceGroupCount", k.ResourceGroupCount, &k.ResourceGroupIDs)
+	return
+}
+
+// ToWriter writes the KerbValidationInfo to w. Nil slices and SIDs are written as null pointers. An error is returned
+// if GroupCount, SIDCount or ResourceGroupCount does not match the number of elements of its array.
+func (k *KerbValidationInfo) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("ResourceGroupCount", k.ResourceGroupCount, len(k.ResourceGroupIDs))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = k.ValidationInfo.toWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, v := range []uint32{k.Reserved1[0], k.Reserved1[1], uint32(k.UserAccountControl), k.SubAuthStatus} {
+		err = nw.Uint32(v)
+		if err != nil {
+			return
+		}
+	}
+	err = k.LastSuccessfulILogon.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = k.LastFailedILogon.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, v := range []uint32{k.FailedILogonCount, k.Reserved3} {
+		err = nw.Uint32(v)
+		if err != nil {
+			return
+		}
+	}
+	err = writeExtraSIDs(nw, k.SIDCount, k.ExtraSIDs)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, k.ResourceGroupDomainSID)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(k.ResourceGroupCount)
+	if err != nil {
+		return
+	}
+	err = writeGroupMembershipsPointer(nw, k.ResourceGroupIDs)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of KerbValidationInfo.
+func (k *KerbValidationInfo) Size() int {
+	return ndrSize(k)
+}
+
+// readExtraSIDs reads the SidCount and the pointer to the ExtraSids of the validation information. The count must
+// match the number of ExtraSids, 0 for a null pointer.
+func readExtraSIDs(r *Reader, count *uint32, sids *[]KerbSidAndAttributes) (err error) {
+	*count, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	return readCountedPointer(r, "ExtraSIDs", "SIDCount", *count, sids, func() ([]KerbSidAndAttributes, error) {
+		return readKerbSidAndAttributes(r)
+	})
+}
+
+// writeExtraSIDs writes the SidCount and a pointer to the ExtraSids of the validation information, a null pointer if
+// sids is nil. An error is returned if count does not match the number of sids.
+func writeExtraSIDs(w *Writer, count uint32, sids []KerbSidAndAttributes) error {
+	err := checkArrayCount("SIDCount", count, len(sids))
+	if err != nil {
+		return err
+	}
+	err = w.Uint32(count)
+	if err != nil {
+		return err
+	}
+	var fn func() error
+	if sids != nil {
+		fn = func() error {
+			return writeKerbSidAndAttributes(w, sids)
+		}
+	}
+	return w.Pointer(fn)
+}
+
+// readSIDPointer reads the pointer to an RPC_SID of the field name. The RPC_SID is read when r.Deferred is called.
+// The pointer returned is nil if the pointer read is null.
+func readSIDPointer(r *Reader, name string) (*RPCSID, error) {
+	sid := new(RPCSID)
+	ok, err := r.fieldPointer(name, func() error {
+		return sid.FromReader(r)
+	})
+	if !ok {
+		return nil, err
+	}
+	return sid, err
+}
+
+// writeSIDPointer writes a pointer to the RPC_SID sid, a null pointer if sid is nil.
+func writeSIDPointer(w *Writer, sid *RPCSID) error {
+	var fn func() error
+	if sid != nil {
+		fn = func() error {
+			return sid.ToWriter(w)
+		}
+	}
+	return w.Pointer(fn)
+}
+
+// LazyKerbValidationInfo is a KERB_VALIDATION_INFO whose GroupIDs and ResourceGroupIDs are decoded only when they are
+// accessed, for callers reading a few fields of the logon information of a user with many group memberships. Its
+// UnmarshalBinary always defers them; FromReader defers them if the Reader was created with WithLazyArrays. The
+// ExtraSIDs point to their SIDs, which a LazyArray cannot hold, and are decoded with the rest of Info.
+type LazyKerbValidationInfo struct {
+	Info             KerbValidationInfo                            // The logon information without GroupIDs and ResourceGroupIDs.
+	GroupIDs         *LazyArray[GroupMembership, *GroupMembership] // nil if the pointer is null.
+	ResourceGroupIDs *LazyArray[GroupMembership, *GroupMembership] // nil if the pointer is null.
+}
+
+// PACBufferType returns PACBufferTypeLogonInfo.
+func (l *LazyKerbValidationInfo) PACBufferType() uint32 {
+	return PACBufferTypeLogonInfo
+}
+
+// KerbValidationInfo returns the logon information with its GroupIDs and ResourceGroupIDs decoded.
+func (l *LazyKerbValidationInfo) KerbValidationInfo() (k KerbValidationInfo, err error) {
+	k = l.Info
+	k.GroupIDs, err = lazyGroupMemberships(l.GroupIDs)
+	if err != nil {
+		return
+	}
+	k.ResourceGroupIDs, err = lazyGroupMemberships(l.ResourceGroupIDs)
+	return
+}
+
+// UnmarshalBinary reads the LazyKerbValidationInfo from the type serialized PAC buffer b. The GroupIDs and
+// ResourceGroupIDs keep slices of b until they are decoded.
+func (l *LazyKerbValidationInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, l, WithLazyArrays())
+}
+
+// MarshalBinary returns the LazyKerbValidationInfo as a type serialized PAC buffer.
+func (l *LazyKerbValidationInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(l)
+}
+
+// FromReader reads the LazyKerbValidationInfo from r. The referents of its pointers are read when r.Deferred is
+// called. GroupCount, SIDCount and ResourceGroupCount must match the number of elements of their arrays, 0 for a null
+// pointer.
+func (l *LazyKerbValidationInfo) FromReader(r *Reader) error {
+	l.GroupIDs, l.ResourceGroupIDs = nil, nil
+	return l.Info.fromReader(r, func(r *Reader, name, countName string, count uint32, v *GroupMemberships) error {
+		a := &l.GroupIDs
+		if v == &l.Info.ResourceGroupIDs {
+			a = &l.ResourceGroupIDs
+		}
+		return readLazyGroupMembershipsPointer(r, name, countName, count, a)
+	})
+}
+
+// ToWriter writes the LazyKerbValidationInfo to w, decoding the GroupIDs and ResourceGroupIDs.
+func (l *LazyKerbValidationInfo) ToWriter(w io.Writer) error {
+	k, err := l.KerbValidationInfo()
+	if err != nil {
+		return err
+	}
+	return k.ToWriter(w)
+}
+
+// Size returns the number of bytes of the NDR representation of the LazyKerbValidationInfo.
+func (l *LazyKerbValidationInfo) Size() int {
+	return ndrSize(l)
+}
+
+// readLazyGroupMembershipsPointer reads the pointer of the field name to the GROUP_MEMBERSHIP array into *v, checking
+// countName, the field holding count, matches its number of elements. The array is read when r.Deferred is called.
+func readLazyGroupMembershipsPointer(r *Reader, name, countName string, count uint32, v **LazyArray[GroupMembership, *GroupMembership]) error {
+	a := new(LazyArray[GroupMembership, *GroupMembership])
+	ok, err := r.fieldPointer(name, func() error {
+		err := a.FromReader(r)
+		if err != nil {
+			return err
+		}
+		return checkArrayCount(countName, count, a.Len())
+	})
+	if ok {
+		*v = a
+	} else if err == nil {
+		err = checkArrayCount(countName, count, 0)
+	}
+	return err
+}
+
+// lazyGroupMemberships decodes the elements of a, nil if a is nil.
+func lazyGroupMemberships(a *LazyArray[GroupMembership, *GroupMembership]) (GroupMemberships, error) {
+	if a == nil {
+		return nil, nil
+	}
+	v, err := a.Elements()
+	if v == nil && err == nil {
+		v = GroupMemberships{}
+	}
+	return v, err
+}
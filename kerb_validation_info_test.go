@@ -0,0 +1,237 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestKerbValidationInfo is a type serialized KERB_VALIDATION_INFO with two groups, two extra SIDs and a resource
+// group.
+const TestKerbValidationInfo = "01100800cccccccce80100000000000000000200f0c1a6c5a5e8d001ffffffffffffff7fffffffffffffff7ff0c1a6c5a5e8d00100801eeec4e9d001ffffffff" +
+	"ffffff7f120012000400020016001600080002000000000000000000000000000000000000000000000000000000000000000000d80000005104000001020000" +
+	"020000000c00020020000000000102030405060708090a0b0c0d0e0f060006001000020008000800140002001800020000000000000000001002000000000000" +
+	"000000000000000000000000000000000000000000000000020000001c0002002000020001000000240002000900000000000000090000007400650073007400" +
+	"7500730065007200310000000b000000000000000b00000054006500730074003100200055007300650072003100000002000000010200000700000054040000" +
+	"07000000030000000000000003000000440043003100000004000000000000000400000054004500530054000400000001040000000000051500000001000000" +
+	"02000000030000000200000028000200070000002c00020007000020020000000102000000000005120000000100000005000000010500000000000515000000" +
+	"040000000500000006000000e80300000400000001040000000000051500000004000000050000000600000001000000e803000007000020"
+
+func Test_KerbValidationInfoUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, FileTime{LowDateTime: 0xc5a6c1f0, HighDateTime: 0x01d0e8a5}, k.LogOnTime, "logon time not as expected")
+	assert.True(t, k.LogOffTime.IsNever(), "logoff time not as expected")
+	assert.Equal(t, FileTime{LowDateTime: 0xee1e8000, HighDateTime: 0x01d0e9c4}, k.PasswordCanChange, "password can change not as expected")
+	assert.Equal(t, "testuser1", k.EffectiveName.Value, "effective name not as expected")
+	assert.Equal(t, "Test1 User1", k.FullName.Value, "full name not as expected")
+	assert.Equal(t, "", k.LogonScript.Value, "logon script not as expected")
+	assert.Equal(t, uint16(216), k.LogonCount, "logon count not as expected")
+	assert.Equal(t, uint32(1105), k.UserID, "user ID not as expected")
+	assert.Equal(t, uint32(513), k.PrimaryGroupID, "primary group ID not as expected")
+	assert.Equal(t, uint32(2), k.GroupCount, "group count not as expected")
+	assert.Equal(t, GroupMemberships{{RelativeID: 513, Attributes: 7}, {RelativeID: 1108, Attributes: 7}}, k.GroupIDs, "group IDs not as expected")
+	assert.Equal(t, LogonExtraSIDs, k.UserFlags, "user flags not as expected")
+	assert.Equal(t, [8]byte{8, 9, 10, 11, 12, 13, 14, 15}, k.UserSessionKey.CypherBlock[1].Data, "user session key not as expected")
+	assert.Equal(t, "DC1", k.LogonServer.Value, "logon server not as expected")
+	assert.Equal(t, "TEST", k.LogonDomainName.Value, "logon domain name not as expected")
+	assert.Equal(t, "S-1-5-21-1-2-3", k.LogonDomainID.String(), "logon domain ID not as expected")
+	assert.Equal(t, UserAccountNormal|UserAccountDontExpirePassword, k.UserAccountControl, "user account control not as expected")
+	assert.Equal(t, uint32(2), k.SIDCount, "SID count not as expected")
+	if assert.Len(t, k.ExtraSIDs, 2, "extra SIDs not as expected") {
+		assert.Equal(t, "S-1-5-18-1", k.ExtraSIDs[0].SID.String(), "extra SID not as expected")
+		assert.Equal(t, "S-1-5-21-4-5-6-1000", k.ExtraSIDs[1].SID.String(), "extra SID not as expected")
+		assert.Equal(t, SIDAttributesDefaultResource, k.ExtraSIDs[1].Attributes, "extra SID attributes not as expected")
+	}
+	assert.Equal(t, "S-1-5-21-4-5-6", k.ResourceGroupDomainSID.String(), "resource group domain SID not as expected")
+	assert.Equal(t, GroupMemberships{{RelativeID: 1000, Attributes: 0x20000007}}, k.ResourceGroupIDs, "resource group IDs not as expected")
+	assert.Equal(t, PACBufferTypeLogonInfo, k.PACBufferType(), "PAC buffer type not as expected")
+}
+
+func Test_KerbValidationInfoUnmarshalTruncated(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	// Shorten the object buffer so the resource group IDs are cut off
+	b[8] -= 8
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b[:len(b)-8])
+	var de *DecodeError
+	if assert.True(t, errors.As(err, &de), "decode error not returned: %v", err) {
+		assert.Equal(t, "KerbValidationInfo.ResourceGroupIDs[0].RelativeID", de.Path, "decode error path not as expected")
+	}
+}
+
+func Test_KerbValidationInfoUnmarshalCountMismatch(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	// GroupCount at offset 128 claims 3 groups for an array of 2
+	b[128] = 3
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	assert.ErrorContains(t, err, "GroupCount 3 does not match the 2 array elements", "count mismatch not rejected")
+
+	// A null pointer with a count
+	b, _ = hex.DecodeString(TestKerbValidationInfo)
+	k = KerbValidationInfo{}
+	err = k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.ExtraSIDs = nil
+	k.SIDCount = 0
+	b, err = k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SIDCount at offset 216
+	b[216] = 2
+	err = new(KerbValidationInfo).UnmarshalBinary(b)
+	assert.ErrorContains(t, err, "SIDCount 2 does not match the 0 array elements", "count of a null pointer not rejected")
+}
+
+func Test_KerbValidationInfoMarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestKerbValidationInfo, hex.EncodeToString(enc), "encoding not as expected")
+
+	// Null pointers are kept on a round trip
+	k.GroupCount, k.GroupIDs = 0, nil
+	k.SIDCount, k.ExtraSIDs = 0, nil
+	k.ResourceGroupDomainSID = nil
+	k.ResourceGroupCount, k.ResourceGroupIDs = 0, nil
+	enc, err = k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d KerbValidationInfo
+	err = d.UnmarshalBinary(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, k, d, "decoded value not as expected")
+
+	k.GroupCount = 5
+	k.GroupIDs = GroupMemberships{{RelativeID: 513, Attributes: 7}}
+	_, err = k.MarshalBinary()
+	assert.ErrorContains(t, err, "GroupCount 5 does not match the 1 array elements", "count mismatch not rejected")
+}
+
+func Test_LazyKerbValidationInfo(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var l LazyKerbValidationInfo
+	err = l.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testuser1", l.Info.EffectiveName.Value, "effective name not as expected")
+	assert.Nil(t, l.Info.GroupIDs, "group IDs decoded eagerly")
+	if assert.NotNil(t, l.GroupIDs, "group IDs not as expected") {
+		assert.Nil(t, l.GroupIDs.elems, "group IDs decoded eagerly")
+		assert.Equal(t, 2, l.GroupIDs.Len(), "group IDs length not as expected")
+		g, err := l.GroupIDs.At(1)
+		assert.NoError(t, err)
+		assert.Equal(t, GroupMembership{RelativeID: 1108, Attributes: 7}, g, "group ID not as expected")
+	}
+	if assert.NotNil(t, l.ResourceGroupIDs, "resource group IDs not as expected") {
+		assert.Nil(t, l.ResourceGroupIDs.elems, "resource group IDs decoded eagerly")
+	}
+	d, err := l.KerbValidationInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, k, d, "decoded value not as expected")
+	enc, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestKerbValidationInfo, hex.EncodeToString(enc), "encoding not as expected")
+
+	// Null and empty arrays are kept apart
+	k.GroupCount, k.GroupIDs = 0, GroupMemberships{}
+	k.ResourceGroupCount, k.ResourceGroupIDs = 0, nil
+	enc, err = k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.UnmarshalBinary(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, l.ResourceGroupIDs, "null resource group IDs not as expected")
+	d, err = l.KerbValidationInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, k, d, "decoded value not as expected")
+
+	// GroupCount at offset 128 claims 3 groups for an array of 2
+	b[128] = 3
+	err = l.UnmarshalBinary(b)
+	assert.ErrorContains(t, err, "GroupCount 3 does not match the 2 array elements", "count mismatch not rejected")
+}
+
+func Test_KerbValidationInfoSIDs(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sidStrings := func() []string {
+		sids, err := k.SIDs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := make([]string, len(sids))
+		for i := range sids {
+			v[i] = sids[i].String()
+		}
+		return v
+	}
+	assert.Equal(t, []string{
+		"S-1-5-21-1-2-3-1105 (0)",
+		"S-1-5-21-1-2-3-513 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-1-2-3-1108 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-18-1 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-4-5-6-1000 (Mandatory|EnabledByDefault|Enabled|Resource)",
+	}, sidStrings(), "SIDs not as expected")
+
+	// The resource group duplicating an extra SID is listed once
+	k.UserFlags |= LogonResourceGroups
+	k.ResourceGroupIDs = append(k.ResourceGroupIDs, GroupMembership{RelativeID: 1001, Attributes: 0x20000007})
+	assert.Equal(t, []string{
+		"S-1-5-21-1-2-3-1105 (0)",
+		"S-1-5-21-1-2-3-513 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-1-2-3-1108 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-18-1 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-4-5-6-1000 (Mandatory|EnabledByDefault|Enabled|Resource)",
+		"S-1-5-21-4-5-6-1001 (Mandatory|EnabledByDefault|Enabled|Resource)",
+	}, sidStrings(), "SIDs not as expected")
+
+	// The primary group is added when it is not one of the groups
+	k.UserFlags = 0
+	k.PrimaryGroupID = 515
+	assert.Equal(t, []string{
+		"S-1-5-21-1-2-3-1105 (0)",
+		"S-1-5-21-1-2-3-513 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-1-2-3-1108 (Mandatory|EnabledByDefault|Enabled)",
+		"S-1-5-21-1-2-3-515 (Mandatory|EnabledByDefault|Enabled)",
+	}, sidStrings(), "SIDs not as expected")
+}
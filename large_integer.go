@@ -0,0 +1,95 @@
+package mstypes
+
+import "io"
+
+// LargeInteger implements the LARGE_INTEGER type of MS-DTYP. It is a signed 64 bit integer, encoded by NDR as a hyper.
+type LargeInteger int64
+
+// ULargeInteger implements the ULARGE_INTEGER type of MS-DTYP. It is an unsigned 64 bit integer, encoded by NDR as
+// an unsigned hyper.
+type ULargeInteger uint64
+
+// NewLargeInteger returns the LargeInteger with the LowPart low and the HighPart high.
+func NewLargeInteger(low uint32, high int32) LargeInteger {
+	return LargeInteger(int64(high)<<32 | int64(low))
+}
+
+// QuadPart returns the LargeInteger as an int64.
+func (l LargeInteger) QuadPart() int64 {
+	return int64(l)
+}
+
+// LowPart returns the low 32 bits of the LargeInteger.
+func (l LargeInteger) LowPart() uint32 {
+	return uint32(l)
+}
+
+// HighPart returns the high 32 bits of the LargeInteger.
+func (l LargeInteger) HighPart() int32 {
+	return int32(l >> 32)
+}
+
+// FromReader reads the LargeInteger from r.
+func (l *LargeInteger) FromReader(r *Reader) (err error) {
+	var u ULargeInteger
+	err = u.FromReader(r)
+	*l = LargeInteger(u)
+	return
+}
+
+// ToWriter writes the LargeInteger to w.
+func (l *LargeInteger) ToWriter(w io.Writer) error {
+	u := ULargeInteger(*l)
+	return u.ToWriter(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LargeInteger.
+func (l *LargeInteger) Size() int {
+	return SizeUint64
+}
+
+// NewULargeInteger returns the ULargeInteger with the LowPart low and the HighPart high.
+func NewULargeInteger(low, high uint32) ULargeInteger {
+	return ULargeInteger(uint64(high)<<32 | uint64(low))
+}
+
+// QuadPart returns the ULargeInteger as a uint64.
+func (u ULargeInteger) QuadPart() uint64 {
+	return uint64(u)
+}
+
+// LowPart returns the low 32 bits of the ULargeInteger.
+func (u ULargeInteger) LowPart() uint32 {
+	return uint32(u)
+}
+
+// HighPart returns the high 32 bits of the ULargeInteger.
+func (u ULargeInteger) HighPart() uint32 {
+	return uint32(u >> 32)
+}
+
+// FromReader reads the ULargeInteger from r.
+func (u *ULargeInteger) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint64)
+	if err != nil {
+		return
+	}
+	v, err := r.Uint64()
+	*u = ULargeInteger(v)
+	return
+}
+
+// ToWriter writes the ULargeInteger to w.
+func (u *ULargeInteger) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint64)
+	if err != nil {
+		return
+	}
+	return nw.Uint64(uint64(*u))
+}
+
+// Size returns the number of bytes of the NDR representation of ULargeInteger.
+func (u *ULargeInteger) Size() int {
+	return SizeUint64
+}
@@ -0,0 +1,42 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LargeInteger(t *testing.T) {
+	l := NewLargeInteger(0xfffffffe, -1)
+	assert.Equal(t, int64(-2), l.QuadPart())
+	assert.Equal(t, uint32(0xfffffffe), l.LowPart())
+	assert.Equal(t, int32(-1), l.HighPart())
+
+	b, err := Marshal(&l)
+	assert.NoError(t, err)
+	assert.Equal(t, "feffffffffffffff", hex.EncodeToString(b))
+	var got LargeInteger
+	assert.NoError(t, Unmarshal(b, &got))
+	assert.Equal(t, l, got)
+}
+
+func Test_ULargeInteger(t *testing.T) {
+	u := NewULargeInteger(0x04030201, 0x08070605)
+	assert.Equal(t, uint64(0x0807060504030201), u.QuadPart())
+	assert.Equal(t, uint32(0x04030201), u.LowPart())
+	assert.Equal(t, uint32(0x08070605), u.HighPart())
+
+	var buf []byte
+	w := NewAppendWriter(buf)
+	assert.NoError(t, w.Uint8(1))
+	assert.NoError(t, u.ToWriter(w))
+	assert.Equal(t, "01000000000000000102030405060708", hex.EncodeToString(w.Bytes()), "ULARGE_INTEGER not aligned to 8 bytes")
+	var got ULargeInteger
+	r := NewReader(bytes.NewReader(w.Bytes()))
+	_, err := r.Uint8()
+	assert.NoError(t, err)
+	assert.NoError(t, got.FromReader(r))
+	assert.Equal(t, u, got)
+}
@@ -0,0 +1,161 @@
+package mstypes
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// element is the pointer type of an array element implementing NDRType.
+type element[T any] interface {
+	*T
+	NDRType
+}
+
+// LazyArray is a conformant array of fixed size elements without embedded pointers. When it is read by a Reader
+// created with WithLazyArrays only the encoded elements are kept and each element is decoded when it is accessed, so
+// callers needing a few fields of a structure holding a large array do not pay for materializing it. Otherwise the
+// elements are decoded when the array is read. LazyKerbValidationInfo holds the group memberships of the logon
+// information as LazyArray.
+type LazyArray[T any, P element[T]] struct {
+	elems  []T     // decoded elements, nil while raw holds the encoded elements
+	raw    []byte  // encoded elements
+	count  int     // number of elements
+	offset int     // stream offset of the first encoded element, used for its alignment
+	stride int     // distance between the encoded elements
+	opts   options // options of the Reader the elements were read by
+}
+
+// NewLazyArray returns a LazyArray holding the elements v.
+func NewLazyArray[T any, P element[T]](v []T) *LazyArray[T, P] {
+	return &LazyArray[T, P]{elems: v, count: len(v)}
+}
+
+// Len returns the number of elements of the array.
+func (a *LazyArray[T, P]) Len() int {
+	return a.count
+}
+
+// At decodes and returns element i of the array.
+func (a *LazyArray[T, P]) At(i int) (v T, err error) {
+	if i < 0 || i >= a.count {
+		err = fmt.Errorf("array index %d out of range with length %d", i, a.count)
+		return
+	}
+	if a.elems != nil {
+		return a.elems[i], nil
+	}
+	err = P(&v).FromReader(a.reader(i))
+	return
+}
+
+// Elements decodes all elements of the array and returns them. The decoded elements are kept so later accesses do
+// not decode them again.
+func (a *LazyArray[T, P]) Elements() ([]T, error) {
+	if a.elems != nil || a.count == 0 {
+		return a.elems, nil
+	}
+	r := a.reader(0)
+	v := make([]T, a.count)
+	for i := range v {
+		err := r.Element(i, func() error {
+			return P(&v[i]).FromReader(r)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	a.elems, a.raw = v, nil
+	return v, nil
+}
+
+// reader returns a Reader positioned on encoded element i with the alignment of the original stream.
+func (a *LazyArray[T, P]) reader(i int) *Reader {
+	r := NewReader(bytes.NewReader(a.raw[i*a.stride:]))
+	r.opts = a.opts
+	r.n = a.offset + i*a.stride
+	return r
+}
+
+// FromReader reads the LazyArray from r, deferring the decoding of its elements if r was created with
+// WithLazyArrays.
+func (a *LazyArray[T, P]) FromReader(r *Reader) error {
+	max, err := r.Conformance()
+	if err != nil {
+		return err
+	}
+	*a = LazyArray[T, P]{}
+	if !r.opts.lazyArrays {
+		a.elems, err = ReadArray(r, max, elementLayout[T, P](r.opts).size, func(v *T) error {
+			return P(v).FromReader(r)
+		})
+		a.count = len(a.elems)
+		return err
+	}
+	l := elementLayout[T, P](r.opts)
+	a.count, err = r.Allocate(max, l.stride)
+	if err != nil || a.count == 0 {
+		return err
+	}
+	err = r.Align(l.align)
+	if err != nil {
+		return err
+	}
+	a.offset, a.stride, a.opts = r.Offset(), l.stride, r.opts
+	a.raw, err = r.ReadBytes((a.count-1)*l.stride + l.size)
+	return err
+}
+
+// ToWriter writes the LazyArray to w, decoding any elements not yet decoded.
+func (a *LazyArray[T, P]) ToWriter(w io.Writer) error {
+	v, err := a.Elements()
+	if err != nil {
+		return err
+	}
+	nw := AsWriter(w)
+	err = WriteConformantArray(nw, v, func(v *T) error {
+		return P(v).ToWriter(nw)
+	})
+	if err != nil {
+		return err
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of the LazyArray.
+func (a *LazyArray[T, P]) Size() int {
+	if a.count == 0 {
+		return SizeUint32
+	}
+	l := elementLayout[T, P](defaultOptions())
+	pad := (l.align - SizeUint32%l.align) % l.align
+	return SizeUint32 + pad + (a.count-1)*l.stride + l.size
+}
+
+// layout is the encoded size, alignment and array stride of an element type.
+type layout struct {
+	size   int
+	align  int
+	stride int
+}
+
+// elementLayout measures the layout of the element type by encoding zero values of it with the options opts.
+func elementLayout[T any, P element[T]](opts options) (l layout) {
+	var v T
+	var c countWriter
+	w := NewWriter(&c)
+	w.opts = opts
+	P(&v).ToWriter(w)
+	l.size = w.Offset()
+
+	// The padding written ahead of an element following a single byte reveals its alignment
+	w = NewWriter(&c)
+	w.opts = opts
+	w.Uint8(0)
+	P(&v).ToWriter(w)
+	n := w.Offset()
+	l.align = n - l.size
+	P(&v).ToWriter(w)
+	l.stride = w.Offset() - n
+	return
+}
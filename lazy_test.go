@@ -0,0 +1,60 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LazyArray(t *testing.T) {
+	groups := []GroupMembership{{RelativeID: 513, Attributes: 7}, {RelativeID: 512, Attributes: 7}, {RelativeID: 1111, Attributes: 3}}
+	a := NewLazyArray(groups)
+	b, err := Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "03000000"+"0102000007000000"+"0002000007000000"+"5704000003000000", hex.EncodeToString(b))
+	assert.Equal(t, len(b), a.Size())
+	// A value following the array shows the lazy read consumed the encoded elements
+	b = append(b, 0xaa, 0xbb, 0xcc, 0xdd)
+
+	for _, opts := range [][]Option{nil, {WithLazyArrays()}} {
+		var got LazyArray[GroupMembership, *GroupMembership]
+		r := NewReader(bytes.NewReader(b), opts...)
+		assert.NoError(t, got.FromReader(r))
+		u, err := r.Uint32()
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0xddccbbaa), u)
+
+		assert.Equal(t, 3, got.Len())
+		g, err := got.At(2)
+		assert.NoError(t, err)
+		assert.Equal(t, groups[2], g)
+		_, err = got.At(3)
+		assert.Error(t, err, "index out of range not detected")
+		v, err := got.Elements()
+		assert.NoError(t, err)
+		assert.Equal(t, groups, v)
+	}
+}
+
+func Test_LazyArrayAlignment(t *testing.T) {
+	keys := []CypherBlock{{Data: [8]byte{1}}, {Data: [8]byte{2}}}
+	a := NewLazyArray(keys)
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, w.Uint8(0xff))
+	assert.NoError(t, a.ToWriter(w))
+
+	var got LazyArray[CypherBlock, *CypherBlock]
+	r := NewReader(bytes.NewReader(buf.Bytes()), WithLazyArrays())
+	_, err := r.Uint8()
+	assert.NoError(t, err)
+	assert.NoError(t, got.FromReader(r))
+	k, err := got.At(1)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1], k)
+	assert.Equal(t, buf.Len(), r.Offset())
+}
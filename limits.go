@@ -0,0 +1,90 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Default decode limits applied by a Reader unless overridden with WithMaxElements or WithMaxAlloc.
+const (
+	DefaultMaxElements = 1 << 20  // maximum element count of a single array
+	DefaultMaxAlloc    = 64 << 20 // maximum total bytes allocated for arrays while decoding
+)
+
+// ErrDecodeLimit is returned, wrapped, when a count read from the stream exceeds the decode limits of a Reader.
+var ErrDecodeLimit = errors.New("decode limit exceeded")
+
+// ErrOutOfRange is returned, wrapped, when a value falls outside the range its IDL definition restricts it to.
+var ErrOutOfRange = errors.New("value out of range")
+
+// MaxCountedStringLength is the maximum length, in bytes, of the counted strings RPC_UNICODE_STRING and RPC_STRING,
+// whose 16 bit lengths must be a multiple of 2 for UTF-16.
+const MaxCountedStringLength = math.MaxUint16 - 1
+
+// ErrStringTooLong is returned, wrapped, when a string is too long for the 16 bit length of a counted string.
+var ErrStringTooLong = errors.New("string too long")
+
+// WithMaxElements sets the maximum element count a Reader accepts for a single array.
+func WithMaxElements(n uint32) Option {
+	return func(o *options) {
+		o.maxElements = n
+	}
+}
+
+// WithMaxAlloc sets the maximum total number of bytes a Reader allocates for arrays while decoding.
+func WithMaxAlloc(n int) Option {
+	return func(o *options) {
+		o.maxAlloc = n
+	}
+}
+
+// checkCount validates an element count read from the stream against the maximum element count.
+func (r *Reader) checkCount(count uint32) error {
+	if count > r.opts.maxElements {
+		return fmt.Errorf("element count %d exceeds the maximum of %d: %w", count, r.opts.maxElements, ErrDecodeLimit)
+	}
+	return nil
+}
+
+// Allocate validates an element count read from the stream before an array of count elements of elemSize bytes is
+// allocated for it. The count is checked against the maximum element count and the array size is added to the total
+// bytes allocated which is checked against the allocation limit. The count is returned as an int.
+func (r *Reader) Allocate(count uint32, elemSize int) (n int, err error) {
+	err = r.checkCount(count)
+	if err != nil {
+		return
+	}
+	size := uint64(count) * uint64(elemSize)
+	if size > uint64(r.opts.maxAlloc-r.alloc) {
+		err = fmt.Errorf("allocating %d bytes exceeds the limit of %d bytes: %w", size, r.opts.maxAlloc, ErrDecodeLimit)
+		return
+	}
+	r.alloc += int(size)
+	n = int(count)
+	return
+}
+
+// release returns size bytes added by Allocate to the total bytes allocated, once the array they were allocated for is
+// no longer held by the decoding, such as a pipe chunk handed to the caller.
+func (r *Reader) release(size int) {
+	r.alloc -= size
+}
+
+// CheckRange validates the value v of the named field against the inclusive range [min, max], mirroring the IDL
+// range attribute. It is used when decoding and before encoding values restricted by a range.
+func CheckRange(name string, v, min, max uint64) error {
+	if v < min || v > max {
+		return fmt.Errorf("%s %d is outside the range [%d, %d]: %w", name, v, min, max, ErrOutOfRange)
+	}
+	return nil
+}
+
+// countedLength returns the length n, in bytes, of a counted string as its 16 bit length or an error wrapping
+// ErrStringTooLong if it does not fit.
+func countedLength(n int) (uint16, error) {
+	if n > MaxCountedStringLength {
+		return 0, fmt.Errorf("string of %d bytes exceeds the %d bytes a counted string can hold: %w", n, MaxCountedStringLength, ErrStringTooLong)
+	}
+	return uint16(n), nil
+}
@@ -0,0 +1,53 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeLimits(t *testing.T) {
+	b, _ := hex.DecodeString("ffffffff")
+	r := NewReader(bytes.NewReader(b))
+	_, err := r.Conformance()
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "hostile max count not rejected by default: %v", err)
+
+	b, _ = hex.DecodeString("00000000ffffffff")
+	r = NewReader(bytes.NewReader(b))
+	_, _, err = r.Variance()
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "hostile actual count not rejected by default: %v", err)
+
+	r = NewReader(bytes.NewReader(nil), WithMaxElements(10), WithMaxAlloc(64))
+	n, err := r.Allocate(10, SizeUint32)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+	_, err = r.Allocate(11, SizeUint8)
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "element limit not enforced")
+	_, err = r.Allocate(10, SizeUint32)
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "total allocation limit not enforced")
+	_, err = r.Allocate(3, SizeUint64)
+	assert.NoError(t, err, "allocation within the remaining limit rejected")
+
+	r = NewReader(bytes.NewReader(nil))
+	_, err = r.UTF16String(DefaultMaxAlloc)
+	assert.True(t, errors.Is(err, ErrDecodeLimit), "hostile string length not rejected by default")
+}
+
+func Test_CheckRange(t *testing.T) {
+	assert.NoError(t, CheckRange("Level", 1, 1, 3))
+	assert.NoError(t, CheckRange("Level", 3, 1, 3))
+	assert.True(t, errors.Is(CheckRange("Level", 0, 1, 3), ErrOutOfRange), "value below the range not rejected")
+	assert.True(t, errors.Is(CheckRange("Level", 4, 1, 3), ErrOutOfRange), "value above the range not rejected")
+
+	// An RPC_SID has at most 15 sub authorities
+	b, _ := hex.DecodeString("0110000000000005")
+	r := NewReader(bytes.NewReader(b))
+	_, err := r.RPCSid()
+	assert.True(t, errors.Is(err, ErrOutOfRange), "sub authority count out of range not rejected: %v", err)
+	w := NewWriter(new(bytes.Buffer))
+	err = w.RPCSid(RPCSID{Revision: 1, SubAuthorityCount: 16, SubAuthority: make([]uint32, 16)})
+	assert.True(t, errors.Is(err, ErrOutOfRange), "sub authority count out of range not validated on encode: %v", err)
+}
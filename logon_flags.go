@@ -0,0 +1,156 @@
+package mstypes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserFlags are the UserFlags of KERB_VALIDATION_INFO and NETLOGON_VALIDATION_SAM_INFO describing the logon
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-nrpc/bccfdba9-0c38-485e-b751-d4de1935781d
+type UserFlags uint32
+
+// KERB_VALIDATION_INFO UserFlags
+const (
+	LogonGuest               UserFlags = 0x00000001 // The logon is a guest logon.
+	LogonNoEncryption        UserFlags = 0x00000002 // The session keys are not encrypted.
+	LogonCachedAccount       UserFlags = 0x00000004 // The logon used cached credentials.
+	LogonUsedLMPassword      UserFlags = 0x00000008 // The LM password was used.
+	LogonExtraSIDs           UserFlags = 0x00000020 // The ExtraSIDs hold SIDs of the user.
+	LogonSubAuthSessionKey   UserFlags = 0x00000040 // The session key comes from a subauthentication package.
+	LogonServerTrustAccount  UserFlags = 0x00000080 // The account is a domain controller.
+	LogonNTLMv2Enabled       UserFlags = 0x00000100 // The server supports NTLMv2.
+	LogonResourceGroups      UserFlags = 0x00000200 // The ResourceGroupIDs hold resource groups of the user.
+	LogonProfilePathReturned UserFlags = 0x00000400 // The ProfilePath is populated.
+	LogonNTv2                UserFlags = 0x00000800 // The NTv2 response was used.
+	LogonLMv2                UserFlags = 0x00001000 // The LMv2 response was used.
+	LogonNTLMv2              UserFlags = 0x00002000 // NTLMv2 was used.
+	LogonOptimized           UserFlags = 0x00004000 // The logon is an optimized logon session.
+	LogonWinlogon            UserFlags = 0x00008000 // The logon is a Winlogon logon.
+	LogonPKINIT              UserFlags = 0x00010000 // Kerberos PKINIT was used.
+	LogonNotOptimized        UserFlags = 0x00020000 // Optimized logon was disabled for the account.
+	LogonNoElevation         UserFlags = 0x00040000 // The account has no elevated token.
+	LogonManagedService      UserFlags = 0x00080000 // The account is a managed service account.
+)
+
+var userFlagNames = []flagName[UserFlags]{
+	{LogonGuest, "Guest"},
+	{LogonNoEncryption, "NoEncryption"},
+	{LogonCachedAccount, "CachedAccount"},
+	{LogonUsedLMPassword, "UsedLMPassword"},
+	{LogonExtraSIDs, "ExtraSIDs"},
+	{LogonSubAuthSessionKey, "SubAuthSessionKey"},
+	{LogonServerTrustAccount, "ServerTrustAccount"},
+	{LogonNTLMv2Enabled, "NTLMv2Enabled"},
+	{LogonResourceGroups, "ResourceGroups"},
+	{LogonProfilePathReturned, "ProfilePathReturned"},
+	{LogonNTv2, "NTv2"},
+	{LogonLMv2, "LMv2"},
+	{LogonNTLMv2, "NTLMv2"},
+	{LogonOptimized, "Optimized"},
+	{LogonWinlogon, "Winlogon"},
+	{LogonPKINIT, "PKINIT"},
+	{LogonNotOptimized, "NotOptimized"},
+	{LogonNoElevation, "NoElevation"},
+	{LogonManagedService, "ManagedService"},
+}
+
+// Has reports whether all the flags f are set.
+func (u UserFlags) Has(f UserFlags) bool {
+	return u&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (u UserFlags) String() string {
+	return formatFlags(u, userFlagNames)
+}
+
+// UserAccountFlags are the UserAccountControl of KERB_VALIDATION_INFO, the USER_ACCOUNT codes of SAMR. They are
+// not the UF_ flags of the userAccountControl attribute of Active Directory: USER_NORMAL_ACCOUNT is 0x10 where
+// UF_NORMAL_ACCOUNT is 0x200.
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-samr/b10cfda1-f24f-441b-8f43-80cb93e786ec
+type UserAccountFlags uint32
+
+// USER_ACCOUNT codes
+const (
+	UserAccountDisabled                     UserAccountFlags = 0x00000001 // USER_ACCOUNT_DISABLED
+	UserAccountHomeDirectoryRequired        UserAccountFlags = 0x00000002 // USER_HOME_DIRECTORY_REQUIRED
+	UserAccountPasswordNotRequired          UserAccountFlags = 0x00000004 // USER_PASSWORD_NOT_REQUIRED
+	UserAccountTempDuplicate                UserAccountFlags = 0x00000008 // USER_TEMP_DUPLICATE_ACCOUNT
+	UserAccountNormal                       UserAccountFlags = 0x00000010 // USER_NORMAL_ACCOUNT
+	UserAccountMNSLogon                     UserAccountFlags = 0x00000020 // USER_MNS_LOGON_ACCOUNT
+	UserAccountInterdomainTrust             UserAccountFlags = 0x00000040 // USER_INTERDOMAIN_TRUST_ACCOUNT
+	UserAccountWorkstationTrust             UserAccountFlags = 0x00000080 // USER_WORKSTATION_TRUST_ACCOUNT
+	UserAccountServerTrust                  UserAccountFlags = 0x00000100 // USER_SERVER_TRUST_ACCOUNT
+	UserAccountDontExpirePassword           UserAccountFlags = 0x00000200 // USER_DONT_EXPIRE_PASSWORD
+	UserAccountAutoLocked                   UserAccountFlags = 0x00000400 // USER_ACCOUNT_AUTO_LOCKED
+	UserAccountEncryptedTextPasswordAllowed UserAccountFlags = 0x00000800 // USER_ENCRYPTED_TEXT_PASSWORD_ALLOWED
+	UserAccountSmartcardRequired            UserAccountFlags = 0x00001000 // USER_SMARTCARD_REQUIRED
+	UserAccountTrustedForDelegation         UserAccountFlags = 0x00002000 // USER_TRUSTED_FOR_DELEGATION
+	UserAccountNotDelegated                 UserAccountFlags = 0x00004000 // USER_NOT_DELEGATED
+	UserAccountUseDESKeyOnly                UserAccountFlags = 0x00008000 // USER_USE_DES_KEY_ONLY
+	UserAccountDontRequirePreauth           UserAccountFlags = 0x00010000 // USER_DONT_REQUIRE_PREAUTH
+	UserAccountPasswordExpired              UserAccountFlags = 0x00020000 // USER_PASSWORD_EXPIRED
+	UserAccountTrustedToAuthForDelegation   UserAccountFlags = 0x00040000 // USER_TRUSTED_TO_AUTHENTICATE_FOR_DELEGATION
+	UserAccountNoAuthDataRequired           UserAccountFlags = 0x00080000 // USER_NO_AUTH_DATA_REQUIRED
+	UserAccountPartialSecrets               UserAccountFlags = 0x00100000 // USER_PARTIAL_SECRETS_ACCOUNT
+	UserAccountUseAESKeys                   UserAccountFlags = 0x00200000 // USER_USE_AES_KEYS
+)
+
+var userAccountFlagNames = []flagName[UserAccountFlags]{
+	{UserAccountDisabled, "Disabled"},
+	{UserAccountHomeDirectoryRequired, "HomeDirectoryRequired"},
+	{UserAccountPasswordNotRequired, "PasswordNotRequired"},
+	{UserAccountTempDuplicate, "TempDuplicate"},
+	{UserAccountNormal, "Normal"},
+	{UserAccountMNSLogon, "MNSLogon"},
+	{UserAccountInterdomainTrust, "InterdomainTrust"},
+	{UserAccountWorkstationTrust, "WorkstationTrust"},
+	{UserAccountServerTrust, "ServerTrust"},
+	{UserAccountDontExpirePassword, "DontExpirePassword"},
+	{UserAccountAutoLocked, "AutoLocked"},
+	{UserAccountEncryptedTextPasswordAllowed, "EncryptedTextPasswordAllowed"},
+	{UserAccountSmartcardRequired, "SmartcardRequired"},
+	{UserAccountTrustedForDelegation, "TrustedForDelegation"},
+	{UserAccountNotDelegated, "NotDelegated"},
+	{UserAccountUseDESKeyOnly, "UseDESKeyOnly"},
+	{UserAccountDontRequirePreauth, "DontRequirePreauth"},
+	{UserAccountPasswordExpired, "PasswordExpired"},
+	{UserAccountTrustedToAuthForDelegation, "TrustedToAuthForDelegation"},
+	{UserAccountNoAuthDataRequired, "NoAuthDataRequired"},
+	{UserAccountPartialSecrets, "PartialSecrets"},
+	{UserAccountUseAESKeys, "UseAESKeys"},
+}
+
+// Has reports whether all the flags f are set.
+func (u UserAccountFlags) Has(f UserAccountFlags) bool {
+	return u&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (u UserAccountFlags) String() string {
+	return formatFlags(u, userAccountFlagNames)
+}
+
+// flagName is the name of a flag of a bitfield type.
+type flagName[T ~uint32] struct {
+	flag T
+	name string
+}
+
+// formatFlags returns the names of the flags of v joined by "|", with any remaining bits in hexadecimal.
+func formatFlags[T ~uint32](v T, names []flagName[T]) string {
+	if v == 0 {
+		return "0"
+	}
+	var s []string
+	for _, n := range names {
+		if v&n.flag == n.flag {
+			s = append(s, n.name)
+			v &^= n.flag
+		}
+	}
+	if v != 0 {
+		s = append(s, fmt.Sprintf("0x%x", uint32(v)))
+	}
+	return strings.Join(s, "|")
+}
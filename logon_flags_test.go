@@ -0,0 +1,45 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserFlagsString(t *testing.T) {
+	var tests = []struct {
+		f    UserFlags
+		want string
+	}{
+		{0, "0"},
+		{LogonExtraSIDs, "ExtraSIDs"},
+		{LogonExtraSIDs | LogonResourceGroups, "ExtraSIDs|ResourceGroups"},
+		{LogonNTLMv2Enabled | LogonPKINIT | 0x10, "NTLMv2Enabled|PKINIT|0x10"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.f.String(), "string of 0x%x not as expected", uint32(test.f))
+	}
+	assert.True(t, (LogonExtraSIDs | LogonResourceGroups).Has(LogonResourceGroups), "flag not found")
+	assert.False(t, LogonExtraSIDs.Has(LogonExtraSIDs|LogonResourceGroups), "flags found")
+	// The token SID expansion relies on the LOGON_EXTRA_SIDS and LOGON_RESOURCE_GROUPS values.
+	assert.Equal(t, uint32(0x20), uint32(LogonExtraSIDs), "LogonExtraSIDs not as expected")
+	assert.Equal(t, uint32(0x200), uint32(LogonResourceGroups), "LogonResourceGroups not as expected")
+}
+
+func Test_UserAccountFlagsString(t *testing.T) {
+	var tests = []struct {
+		f    UserAccountFlags
+		want string
+	}{
+		{0, "0"},
+		{DefaultPACUserAccountControl, "Normal|DontExpirePassword"},
+		{UserAccountWorkstationTrust | UserAccountTrustedToAuthForDelegation, "WorkstationTrust|TrustedToAuthForDelegation"},
+		{UserAccountDisabled | 0x80000000, "Disabled|0x80000000"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.f.String(), "string of 0x%x not as expected", uint32(test.f))
+	}
+	// The USER_ACCOUNT codes are not the UF_ flags: 0x200 is UF_NORMAL_ACCOUNT but USER_DONT_EXPIRE_PASSWORD.
+	assert.False(t, UserAccountFlags(0x200).Has(UserAccountNormal), "normal account found")
+	assert.True(t, UserAccountFlags(0x210).Has(UserAccountNormal|UserAccountDontExpirePassword), "flags not found")
+}
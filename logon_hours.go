@@ -0,0 +1,183 @@
+package mstypes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogonHoursSize is the number of bytes of a LogonHours bitmap, a bit per hour of a week.
+const LogonHoursSize = 21
+
+// UnitsPerWeek values of SAMPR_LOGON_HOURS
+const (
+	SAMDaysPerWeek    = 7
+	SAMHoursPerWeek   = 24 * SAMDaysPerWeek
+	SAMMinutesPerWeek = 60 * SAMHoursPerWeek
+)
+
+// LogonHours is the 168 bit bitmap of the hours of the week an account may log on, the form of the logonHours
+// attribute of MS-ADTS and of a SAMPR_LOGON_HOURS of SAMHoursPerWeek units.
+//
+// Bit i is the bit 1<<(i%8) of byte i/8, and is set if logons are allowed in the hour starting i hours after Sunday
+// 00:00 UTC. The bitmap is always in UTC: use Shift or Format for the hours of a local time zone.
+type LogonHours [LogonHoursSize]byte
+
+// LogonHoursAll allows logons at all hours.
+var LogonHoursAll = LogonHours{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// Allowed reports whether logons are allowed in the UTC hour of day on the day d. It panics if hour is not 0 to 23.
+func (h LogonHours) Allowed(d time.Weekday, hour int) bool {
+	i := logonHourIndex(d, hour)
+	return h[i/8]&(1<<(i%8)) != 0
+}
+
+// Set sets whether logons are allowed in the UTC hour of day on the day d. It panics if hour is not 0 to 23.
+func (h *LogonHours) Set(d time.Weekday, hour int, allowed bool) {
+	i := logonHourIndex(d, hour)
+	if allowed {
+		h[i/8] |= 1 << (i % 8)
+	} else {
+		h[i/8] &^= 1 << (i % 8)
+	}
+}
+
+// AllowedAt reports whether logons are allowed at t, in whatever location t is.
+func (h LogonHours) AllowedAt(t time.Time) bool {
+	t = t.UTC()
+	return h.Allowed(t.Weekday(), t.Hour())
+}
+
+// IsZero reports whether logons are allowed at no hour.
+func (h LogonHours) IsZero() bool {
+	return h == LogonHours{}
+}
+
+// Shift returns the bitmap moved by offset, truncated to whole hours, around the week. Shifting the UTC bitmap by
+// the offset of a time zone from UTC gives the bitmap of the local hours, and shifting that by the negated offset gives
+// the UTC bitmap back.
+func (h LogonHours) Shift(offset time.Duration) LogonHours {
+	n := int(offset/time.Hour) % SAMHoursPerWeek
+	if n < 0 {
+		n += SAMHoursPerWeek
+	}
+	var s LogonHours
+	for i := 0; i < SAMHoursPerWeek; i++ {
+		if h[i/8]&(1<<(i%8)) != 0 {
+			j := (i + n) % SAMHoursPerWeek
+			s[j/8] |= 1 << (j % 8)
+		}
+	}
+	return s
+}
+
+// String returns the allowed UTC hours of each day, as "Always", "Never" or ranges such as
+// "Mon 08:00-17:00, Tue 08:00-12:00 13:00-17:00".
+func (h LogonHours) String() string {
+	switch h {
+	case LogonHoursAll:
+		return "Always"
+	case LogonHours{}:
+		return "Never"
+	}
+	var days []string
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		var b strings.Builder
+		for hour := 0; hour < 24; hour++ {
+			if !h.Allowed(d, hour) {
+				continue
+			}
+			end := hour + 1
+			for end < 24 && h.Allowed(d, end) {
+				end++
+			}
+			fmt.Fprintf(&b, " %02d:00-%02d:00", hour, end)
+			hour = end
+		}
+		if b.Len() > 0 {
+			days = append(days, d.String()[:3]+b.String())
+		}
+	}
+	return strings.Join(days, ", ")
+}
+
+// Format returns the allowed hours as String does, in the local hours of loc. The offset of loc from UTC is the one in
+// effect at the current time, truncated to whole hours.
+func (h LogonHours) Format(loc *time.Location) string {
+	_, offset := time.Now().In(loc).Zone()
+	return h.Shift(time.Duration(offset) * time.Second).String()
+}
+
+func logonHourIndex(d time.Weekday, hour int) int {
+	if d < time.Sunday || d > time.Saturday || hour < 0 || hour >= 24 {
+		panic(fmt.Sprintf("logon hour %d of %s out of range", hour, d))
+	}
+	return int(d)*24 + hour
+}
+
+// NewSAMLogonHours returns the SAMPR_LOGON_HOURS of SAMHoursPerWeek units of h.
+func NewSAMLogonHours(h LogonHours) SAMLogonHours {
+	return SAMLogonHours{UnitsPerWeek: SAMHoursPerWeek, LogonHours: append([]byte(nil), h[:]...)}
+}
+
+// AllowedAt reports whether logons are allowed at t at the granularity of UnitsPerWeek, which must be SAMDaysPerWeek,
+// SAMHoursPerWeek or SAMMinutesPerWeek. A null bitmap or a UnitsPerWeek of 0 places no restriction.
+func (h *SAMLogonHours) AllowedAt(t time.Time) (bool, error) {
+	if h.LogonHours == nil || h.UnitsPerWeek == 0 {
+		return true, nil
+	}
+	t = t.UTC()
+	minute := (int(t.Weekday())*24+t.Hour())*60 + t.Minute()
+	unit, err := h.unit(minute)
+	if err != nil {
+		return false, err
+	}
+	return h.LogonHours[unit/8]&(1<<(unit%8)) != 0, nil
+}
+
+// Hours returns the bitmap as a LogonHours. With SAMDaysPerWeek units an hour is allowed if its day is, with
+// SAMMinutesPerWeek units only if all its minutes are. A null bitmap or a UnitsPerWeek of 0 places no restriction and
+// returns LogonHoursAll.
+func (h *SAMLogonHours) Hours() (LogonHours, error) {
+	if h.LogonHours == nil || h.UnitsPerWeek == 0 {
+		return LogonHoursAll, nil
+	}
+	var l LogonHours
+	for i := 0; i < SAMHoursPerWeek; i++ {
+		allowed := true
+		for m := i * 60; m < (i+1)*60 && allowed; m++ {
+			unit, err := h.unit(m)
+			if err != nil {
+				return LogonHours{}, err
+			}
+			allowed = h.LogonHours[unit/8]&(1<<(unit%8)) != 0
+		}
+		if allowed {
+			l[i/8] |= 1 << (i % 8)
+		}
+	}
+	return l, nil
+}
+
+// unit returns the index of the bit of the minute of the week, checking that the bitmap holds it.
+func (h *SAMLogonHours) unit(minute int) (int, error) {
+	var unit int
+	switch h.UnitsPerWeek {
+	case SAMDaysPerWeek:
+		unit = minute / (24 * 60)
+	case SAMHoursPerWeek:
+		unit = minute / 60
+	case SAMMinutesPerWeek:
+		unit = minute
+	default:
+		return 0, fmt.Errorf("%w: logon hours of %d units per week", ErrOutOfRange, h.UnitsPerWeek)
+	}
+	if need := (int(h.UnitsPerWeek) + 7) / 8; len(h.LogonHours) < need {
+		return 0, fmt.Errorf("logon hours of %d units per week in %d bytes, not %d", h.UnitsPerWeek, len(h.LogonHours),
+			need)
+	}
+	return unit, nil
+}
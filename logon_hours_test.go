@@ -0,0 +1,87 @@
+package mstypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LogonHours(t *testing.T) {
+	var h LogonHours
+	assert.Equal(t, "Never", h.String(), "string not as expected")
+	for d := time.Monday; d <= time.Friday; d++ {
+		for hour := 8; hour < 17; hour++ {
+			h.Set(d, hour, true)
+		}
+	}
+	h.Set(time.Tuesday, 12, false)
+	assert.Equal(t, byte(0x00), h[3], "Monday 00:00-07:59 not as expected")
+	assert.Equal(t, byte(0xff), h[4], "Monday 08:00-15:59 not as expected")
+	assert.Equal(t, byte(0x01), h[5], "Monday 16:00-23:59 not as expected")
+	assert.True(t, h.Allowed(time.Monday, 8), "Monday 08:00 not allowed")
+	assert.False(t, h.Allowed(time.Monday, 17), "Monday 17:00 allowed")
+	assert.False(t, h.Allowed(time.Tuesday, 12), "Tuesday 12:00 allowed")
+	assert.Equal(t, "Mon 08:00-17:00, Tue 08:00-12:00 13:00-17:00, Wed 08:00-17:00, Thu 08:00-17:00, Fri 08:00-17:00",
+		h.String(), "string not as expected")
+
+	// 2024-01-01 is a Monday.
+	assert.True(t, h.AllowedAt(time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)), "time not allowed")
+	cet := time.FixedZone("CET", 3600)
+	assert.False(t, h.AllowedAt(time.Date(2024, 1, 1, 8, 30, 0, 0, cet)), "time allowed")
+
+	assert.Equal(t, "Mon 09:00-18:00, Tue 09:00-13:00 14:00-18:00, Wed 09:00-18:00, Thu 09:00-18:00, Fri 09:00-18:00",
+		h.Format(cet), "local string not as expected")
+	assert.Equal(t, h, h.Shift(-5*time.Hour).Shift(5*time.Hour), "shift not reversible")
+	s := h.Shift(-10 * time.Hour)
+	assert.True(t, s.Allowed(time.Sunday, 22), "shift not wrapped around the week")
+	assert.Equal(t, "Always", LogonHoursAll.String(), "string not as expected")
+	assert.Panics(t, func() { h.Allowed(time.Monday, 24) }, "hour out of range not rejected")
+}
+
+func Test_SAMLogonHoursUnits(t *testing.T) {
+	var l LogonHours
+	l.Set(time.Monday, 9, true)
+	h := NewSAMLogonHours(l)
+	monday := time.Date(2024, 1, 1, 9, 15, 0, 0, time.UTC)
+	ok, err := h.AllowedAt(monday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok, "hour not allowed")
+	got, err := h.Hours()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, l, got, "hours not as expected")
+
+	days := SAMLogonHours{UnitsPerWeek: SAMDaysPerWeek, LogonHours: []byte{0x02}}
+	got, err = days.Hours()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Mon 00:00-24:00", got.String(), "hours of days not as expected")
+
+	minutes := SAMLogonHours{UnitsPerWeek: SAMMinutesPerWeek, LogonHours: make([]byte, SAMLogonHoursMaxLength)}
+	for m := (24 + 9) * 60; m < (24+10)*60+30; m++ {
+		minutes.LogonHours[m/8] |= 1 << (m % 8)
+	}
+	got, err = minutes.Hours()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, l, got, "partly allowed hour not dropped")
+	ok, err = minutes.AllowedAt(monday.Add(70 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok, "minute not allowed")
+
+	ok, err = new(SAMLogonHours).AllowedAt(monday)
+	assert.NoError(t, err)
+	assert.True(t, ok, "null logon hours restrict logons")
+	_, err = (&SAMLogonHours{UnitsPerWeek: 100, LogonHours: []byte{0}}).Hours()
+	assert.ErrorIs(t, err, ErrOutOfRange, "invalid units per week not rejected")
+	_, err = (&SAMLogonHours{UnitsPerWeek: SAMHoursPerWeek, LogonHours: []byte{0}}).Hours()
+	assert.Error(t, err, "short bitmap not rejected")
+}
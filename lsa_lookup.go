@@ -0,0 +1,492 @@
+package mstypes
+
+import (
+	"io"
+)
+
+/*
+The LsaLookupSids methods of MS-LSAT translate the SIDs of an LSAPR_SID_ENUM_BUFFER into an LSAPR_TRANSLATED_NAMES, or
+LSAPR_TRANSLATED_NAMES_EX for the later methods, with an entry for each SID in the same order. The domains of the
+names are returned once in an LSAPR_REFERENCED_DOMAIN_LIST which the DomainIndex of each name indexes.
+Ref: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-lsat/
+*/
+
+// LSASIDInformation implements LSAPR_SID_INFORMATION of MS-LSAT, a SID to translate.
+type LSASIDInformation struct {
+	SID *RPCSID // A pointer to the SID, nil if the pointer is null.
+}
+
+// FromReader reads the LSASIDInformation from r. The SID is read when r.Deferred is called.
+func (s *LSASIDInformation) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	s.SID, err = readSIDPointer(r, "SID")
+	return
+}
+
+// ToWriter writes the LSASIDInformation to w.
+func (s *LSASIDInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, s.SID)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSASIDInformation.
+func (s *LSASIDInformation) Size() int {
+	return ndrSize(s)
+}
+
+// LSASIDEnumBuffer implements LSAPR_SID_ENUM_BUFFER of MS-LSAT, the SIDs passed to LsaLookupSids.
+type LSASIDEnumBuffer struct {
+	Entries uint32
+	SIDInfo []LSASIDInformation // A pointer to Entries LSAPR_SID_INFORMATION, nil if the pointer is null.
+}
+
+// NewLSASIDEnumBuffer returns the LSASIDEnumBuffer holding the SIDs sids.
+func NewLSASIDEnumBuffer(sids ...*RPCSID) LSASIDEnumBuffer {
+	b := LSASIDEnumBuffer{
+		Entries: uint32(len(sids)),
+		SIDInfo: make([]LSASIDInformation, len(sids)),
+	}
+	for i := range sids {
+		b.SIDInfo[i].SID = sids[i]
+	}
+	return b
+}
+
+// FromReader reads the LSASIDEnumBuffer from r. The SIDs are read when r.Deferred is called.
+// Entries must match the number of elements of SIDInfo, 0 for a null pointer.
+func (b *LSASIDEnumBuffer) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	b.Entries, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "SIDInfo", "Entries", b.Entries, &b.SIDInfo, func() ([]LSASIDInformation, error) {
+		return ReadConformantArray(r, 4, func(s *LSASIDInformation) error {
+			return s.FromReader(r)
+		})
+	})
+	return
+}
+
+// ToWriter writes the LSASIDEnumBuffer to w. An error is returned if Entries does not match the number of elements of
+// SIDInfo.
+func (b *LSASIDEnumBuffer) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("Entries", b.Entries, len(b.SIDInfo))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(b.Entries)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if b.SIDInfo != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, b.SIDInfo, func(s *LSASIDInformation) error {
+				return s.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSASIDEnumBuffer.
+func (b *LSASIDEnumBuffer) Size() int {
+	return ndrSize(b)
+}
+
+// LSATranslatedName implements LSAPR_TRANSLATED_NAME of MS-LSAT, the name a SID translated to.
+type LSATranslatedName struct {
+	Use         SIDNameUse
+	Name        LSAUnicodeString
+	DomainIndex int32 // The index of the domain of the name in the referenced domain list, -1 if there is none.
+}
+
+// Domain returns the domain of the name in the referenced domain list l, nil if it has none or the index is out of
+// range.
+func (n *LSATranslatedName) Domain(l *LSAReferencedDomainList) *LSATrustInformation {
+	return l.domain(n.DomainIndex)
+}
+
+// FromReader reads the LSATranslatedName from r. The name is read when r.Deferred is called.
+func (n *LSATranslatedName) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	u, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	n.Use = SIDNameUse(u)
+	err = r.Field("Name", func() error {
+		return n.Name.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	i, err := r.Uint32()
+	n.DomainIndex = int32(i)
+	return
+}
+
+// ToWriter writes the LSATranslatedName to w.
+func (n *LSATranslatedName) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(uint16(n.Use))
+	if err != nil {
+		return
+	}
+	err = n.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(uint32(n.DomainIndex))
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATranslatedName.
+func (n *LSATranslatedName) Size() int {
+	return ndrSize(n)
+}
+
+// LSATranslatedNames implements LSAPR_TRANSLATED_NAMES of MS-LSAT, the names returned by LsaLookupSids.
+type LSATranslatedNames struct {
+	Entries uint32
+	Names   []LSATranslatedName // A pointer to Entries LSAPR_TRANSLATED_NAME, nil if the pointer is null.
+}
+
+// FromReader reads the LSATranslatedNames from r. The names are read when r.Deferred is called.
+// Entries must match the number of elements of Names, 0 for a null pointer.
+func (t *LSATranslatedNames) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	t.Entries, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Names", "Entries", t.Entries, &t.Names, func() ([]LSATranslatedName, error) {
+		return ReadConformantArray(r, 16, func(n *LSATranslatedName) error {
+			return n.FromReader(r)
+		})
+	})
+	return
+}
+
+// ToWriter writes the LSATranslatedNames to w. An error is returned if Entries does not match the number of elements of
+// Names.
+func (t *LSATranslatedNames) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("Entries", t.Entries, len(t.Names))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(t.Entries)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if t.Names != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, t.Names, func(n *LSATranslatedName) error {
+				return n.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATranslatedNames.
+func (t *LSATranslatedNames) Size() int {
+	return ndrSize(t)
+}
+
+// LSATranslatedNameEx implements LSAPR_TRANSLATED_NAME_EX of MS-LSAT, the name a SID translated to as returned by
+// LsarLookupSids2 and later.
+type LSATranslatedNameEx struct {
+	Use         SIDNameUse
+	Name        LSAUnicodeString
+	DomainIndex int32 // The index of the domain of the name in the referenced domain list, -1 if there is none.
+	Flags       uint32
+}
+
+// Domain returns the domain of the name in the referenced domain list l, nil if it has none or the index is out of
+// range.
+func (n *LSATranslatedNameEx) Domain(l *LSAReferencedDomainList) *LSATrustInformation {
+	return l.domain(n.DomainIndex)
+}
+
+// FromReader reads the LSATranslatedNameEx from r. The name is read when r.Deferred is called.
+func (n *LSATranslatedNameEx) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	u, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	n.Use = SIDNameUse(u)
+	err = r.Field("Name", func() error {
+		return n.Name.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	i, err := r.Uint32()
+	if err != nil {
+		return
+	}
+	n.DomainIndex = int32(i)
+	n.Flags, err = r.Uint32()
+	return
+}
+
+// ToWriter writes the LSATranslatedNameEx to w.
+func (n *LSATranslatedNameEx) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(uint16(n.Use))
+	if err != nil {
+		return
+	}
+	err = n.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(uint32(n.DomainIndex))
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(n.Flags)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATranslatedNameEx.
+func (n *LSATranslatedNameEx) Size() int {
+	return ndrSize(n)
+}
+
+// LSATranslatedNamesEx implements LSAPR_TRANSLATED_NAMES_EX of MS-LSAT, the names returned by LsarLookupSids2 and
+// later.
+type LSATranslatedNamesEx struct {
+	Entries uint32
+	Names   []LSATranslatedNameEx // A pointer to Entries LSAPR_TRANSLATED_NAME_EX, nil if the pointer is null.
+}
+
+// FromReader reads the LSATranslatedNamesEx from r. The names are read when r.Deferred is called.
+// Entries must match the number of elements of Names, 0 for a null pointer.
+func (t *LSATranslatedNamesEx) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	t.Entries, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Names", "Entries", t.Entries, &t.Names, func() ([]LSATranslatedNameEx, error) {
+		return ReadConformantArray(r, 20, func(n *LSATranslatedNameEx) error {
+			return n.FromReader(r)
+		})
+	})
+	return
+}
+
+// ToWriter writes the LSATranslatedNamesEx to w. An error is returned if Entries does not match the number of elements of
+// Names.
+func (t *LSATranslatedNamesEx) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("Entries", t.Entries, len(t.Names))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(t.Entries)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if t.Names != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, t.Names, func(n *LSATranslatedNameEx) error {
+				return n.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATranslatedNamesEx.
+func (t *LSATranslatedNamesEx) Size() int {
+	return ndrSize(t)
+}
+
+// LSATrustInformation implements LSAPR_TRUST_INFORMATION of MS-LSAT, the name and SID of a domain.
+type LSATrustInformation struct {
+	Name LSAUnicodeString
+	SID  *RPCSID // A pointer to the domain SID, nil if the pointer is null.
+}
+
+// FromReader reads the LSATrustInformation from r. The name and SID are read when r.Deferred is called.
+func (t *LSATrustInformation) FromReader(r *Reader) (err error) {
+	err = r.Field("Name", func() error {
+		return t.Name.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	t.SID, err = readSIDPointer(r, "SID")
+	return
+}
+
+// ToWriter writes the LSATrustInformation to w.
+func (t *LSATrustInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = t.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, t.SID)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATrustInformation.
+func (t *LSATrustInformation) Size() int {
+	return ndrSize(t)
+}
+
+// LSAReferencedDomainList implements LSAPR_REFERENCED_DOMAIN_LIST of MS-LSAT, the domains of translated names and
+// SIDs.
+type LSAReferencedDomainList struct {
+	Entries    uint32
+	Domains    []LSATrustInformation // A pointer to Entries LSAPR_TRUST_INFORMATION, nil if the pointer is null.
+	MaxEntries uint32                // Ignored on receipt.
+}
+
+// domain returns the domain at index i, nil if i is out of range.
+func (l *LSAReferencedDomainList) domain(i int32) *LSATrustInformation {
+	if l == nil || i < 0 || int(i) >= len(l.Domains) {
+		return nil
+	}
+	return &l.Domains[i]
+}
+
+// FromReader reads the LSAReferencedDomainList from r. The domains are read when r.Deferred is called.
+// Entries must match the number of elements of Domains, 0 for a null pointer.
+func (l *LSAReferencedDomainList) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	l.Entries, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Domains", "Entries", l.Entries, &l.Domains, func() ([]LSATrustInformation, error) {
+		return ReadConformantArray(r, 12, func(t *LSATrustInformation) error {
+			return t.FromReader(r)
+		})
+	})
+	if err != nil {
+		return
+	}
+	l.MaxEntries, err = r.Uint32()
+	return
+}
+
+// ToWriter writes the LSAReferencedDomainList to w. An error is returned if Entries does not match the number of elements of
+// Domains.
+func (l *LSAReferencedDomainList) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("Entries", l.Entries, len(l.Domains))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(l.Entries)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if l.Domains != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, l.Domains, func(t *LSATrustInformation) error {
+				return t.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(l.MaxEntries)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAReferencedDomainList.
+func (l *LSAReferencedDomainList) Size() int {
+	return ndrSize(l)
+}
@@ -0,0 +1,88 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LSASIDEnumBuffer(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-32-544")
+	b := NewLSASIDEnumBuffer(sid, nil)
+	e := encodeTypeSerialized(t, &b)
+	assert.Equal(t, "00000200"+"02000000"+"04000200"+"02000000"+"08000200"+"00000000"+
+		"02000000"+"0102000000000005"+"20000000"+"20020000"+"00000000", hex.EncodeToString(e[16:]), "encoding not as expected")
+
+	var d LSASIDEnumBuffer
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, b, d, "decoded value not as expected")
+}
+
+func Test_LSATranslatedNames(t *testing.T) {
+	domainSID, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	l := LSAReferencedDomainList{
+		Entries:    1,
+		Domains:    []LSATrustInformation{{Name: LSAUnicodeString{Value: "CORP"}, SID: domainSID}},
+		MaxEntries: 32,
+	}
+	n := LSATranslatedNames{
+		Entries: 2,
+		Names: []LSATranslatedName{
+			{Use: SIDTypeUser, Name: LSAUnicodeString{Value: "a"}, DomainIndex: 0},
+			{Use: SIDTypeUnknown, DomainIndex: -1},
+		},
+	}
+	e := encodeTypeSerialized(t, &n)
+	assert.Equal(t, "00000200"+"02000000"+"04000200"+"02000000"+
+		"01000000"+"02000200"+"08000200"+"00000000"+
+		"08000000"+"00000000"+"00000000"+"ffffffff"+
+		"01000000"+"00000000"+"01000000"+"6100"+"0000", hex.EncodeToString(e[16:]), "encoding not as expected")
+	var d LSATranslatedNames
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, n, d, "decoded value not as expected")
+
+	var dl LSAReferencedDomainList
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &l), &dl)
+	assert.Equal(t, l, dl, "decoded domain list not as expected")
+	assert.Equal(t, "CORP", d.Names[0].Domain(&dl).Name.Value, "domain not as expected")
+	assert.Nil(t, d.Names[1].Domain(&dl), "domain of index -1 found")
+
+	ex := LSATranslatedNamesEx{
+		Entries: 1,
+		Names:   []LSATranslatedNameEx{{Use: SIDTypeUser, Name: LSAUnicodeString{Value: "user"}, DomainIndex: 0, Flags: 2}},
+	}
+	var dex LSATranslatedNamesEx
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &ex), &dex)
+	assert.Equal(t, ex, dex, "decoded value not as expected")
+	assert.Equal(t, domainSID, dex.Names[0].Domain(&dl).SID, "domain SID not as expected")
+}
+
+func Test_LSALookupCountMismatch(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-32-544")
+	b := NewLSASIDEnumBuffer(sid)
+	n := LSATranslatedNames{Entries: 1, Names: []LSATranslatedName{{Use: SIDTypeAlias, DomainIndex: 0}}}
+	ex := LSATranslatedNamesEx{Entries: 1, Names: []LSATranslatedNameEx{{Use: SIDTypeAlias, DomainIndex: 0}}}
+	l := LSAReferencedDomainList{Entries: 1, Domains: []LSATrustInformation{{Name: LSAUnicodeString{Value: "BUILTIN"}}}}
+	var tests = []struct {
+		v       NDRType
+		entries *uint32
+		d       NDRType
+	}{
+		{&b, &b.Entries, &LSASIDEnumBuffer{}},
+		{&n, &n.Entries, &LSATranslatedNames{}},
+		{&ex, &ex.Entries, &LSATranslatedNamesEx{}},
+		{&l, &l.Entries, &LSAReferencedDomainList{}},
+	}
+	for _, test := range tests {
+		e := encodeTypeSerialized(t, test.v)
+		// Entries follows the top level pointer
+		e[20]++
+		err := UnmarshalTypeSerialized(e, test.d)
+		assert.ErrorContains(t, err, "Entries", "expected error decoding %T with Entries not matching", test.d)
+
+		*test.entries = 2
+		_, err = MarshalTypeSerialized(test.v)
+		assert.ErrorContains(t, err, "Entries", "expected error encoding %T with Entries not matching", test.v)
+	}
+}
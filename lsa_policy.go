@@ -0,0 +1,143 @@
+package mstypes
+
+import (
+	"io"
+)
+
+// LSAPolicyPrimaryDomInfo implements LSAPR_POLICY_PRIMARY_DOM_INFO of MS-LSAD, the PolicyPrimaryDomainInformation
+// class: the domain the machine is joined to.
+type LSAPolicyPrimaryDomInfo struct {
+	Name LSAUnicodeString // The NetBIOS name of the domain.
+	SID  *RPCSID          // A pointer to the domain SID, nil if the pointer is null as for a machine not joined to a domain.
+}
+
+// FromReader reads the LSAPolicyPrimaryDomInfo from r. The name and SID are read when r.Deferred is called.
+func (p *LSAPolicyPrimaryDomInfo) FromReader(r *Reader) (err error) {
+	err = r.Field("Name", func() error {
+		return p.Name.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	p.SID, err = readSIDPointer(r, "SID")
+	return
+}
+
+// ToWriter writes the LSAPolicyPrimaryDomInfo to w.
+func (p *LSAPolicyPrimaryDomInfo) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = p.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, p.SID)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAPolicyPrimaryDomInfo.
+func (p *LSAPolicyPrimaryDomInfo) Size() int {
+	return ndrSize(p)
+}
+
+// LSAPolicyAccountDomInfo implements LSAPR_POLICY_ACCOUNT_DOM_INFO of MS-LSAD, the PolicyAccountDomainInformation
+// class: the domain holding the accounts of the machine, the machine itself unless it is a domain controller.
+type LSAPolicyAccountDomInfo struct {
+	DomainName LSAUnicodeString
+	DomainSID  *RPCSID // A pointer to the domain SID, nil if the pointer is null.
+}
+
+// FromReader reads the LSAPolicyAccountDomInfo from r. The name and SID are read when r.Deferred is called.
+func (p *LSAPolicyAccountDomInfo) FromReader(r *Reader) (err error) {
+	err = r.Field("DomainName", func() error {
+		return p.DomainName.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	p.DomainSID, err = readSIDPointer(r, "DomainSID")
+	return
+}
+
+// ToWriter writes the LSAPolicyAccountDomInfo to w.
+func (p *LSAPolicyAccountDomInfo) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = p.DomainName.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, p.DomainSID)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAPolicyAccountDomInfo.
+func (p *LSAPolicyAccountDomInfo) Size() int {
+	return ndrSize(p)
+}
+
+// LSAPolicyDNSDomainInfo implements LSAPR_POLICY_DNS_DOMAIN_INFO of MS-LSAD, the PolicyDnsDomainInformation class:
+// the names, GUID and SID of the domain the machine is joined to.
+type LSAPolicyDNSDomainInfo struct {
+	Name          LSAUnicodeString // The NetBIOS name of the domain.
+	DNSDomainName LSAUnicodeString
+	DNSForestName LSAUnicodeString
+	DomainGUID    GUID
+	SID           *RPCSID // A pointer to the domain SID, nil if the pointer is null.
+}
+
+// FromReader reads the LSAPolicyDNSDomainInfo from r. The names and SID are read when r.Deferred is called.
+func (p *LSAPolicyDNSDomainInfo) FromReader(r *Reader) (err error) {
+	for _, f := range []struct {
+		name string
+		s    *LSAUnicodeString
+	}{
+		{"Name", &p.Name},
+		{"DNSDomainName", &p.DNSDomainName},
+		{"DNSForestName", &p.DNSForestName},
+	} {
+		err = r.Field(f.name, func() error {
+			return f.s.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = r.Field("DomainGUID", func() error {
+		return p.DomainGUID.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	p.SID, err = readSIDPointer(r, "SID")
+	return
+}
+
+// ToWriter writes the LSAPolicyDNSDomainInfo to w.
+func (p *LSAPolicyDNSDomainInfo) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	for _, s := range []*LSAUnicodeString{&p.Name, &p.DNSDomainName, &p.DNSForestName} {
+		err = s.ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	err = p.DomainGUID.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, p.SID)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAPolicyDNSDomainInfo.
+func (p *LSAPolicyDNSDomainInfo) Size() int {
+	return ndrSize(p)
+}
@@ -0,0 +1,45 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LSAPolicyDomainInfo(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	primary := LSAPolicyPrimaryDomInfo{Name: LSAUnicodeString{Value: "CORP"}, SID: sid}
+	e := encodeTypeSerialized(t, &primary)
+	assert.Equal(t, "00000200"+"0800"+"0800"+"04000200"+"08000200"+
+		"04000000"+"00000000"+"04000000"+"43004f0052005000"+
+		"04000000"+"010400000000000515000000010000000200000003000000", hex.EncodeToString(e[16:]), "encoding not as expected")
+	var dp LSAPolicyPrimaryDomInfo
+	decodeTypeSerialized(t, e, &dp)
+	assert.Equal(t, primary, dp, "decoded primary domain not as expected")
+
+	workgroup := LSAPolicyPrimaryDomInfo{Name: LSAUnicodeString{Value: "WORKGROUP"}}
+	var dw LSAPolicyPrimaryDomInfo
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &workgroup), &dw)
+	assert.Nil(t, dw.SID, "SID of a workgroup not null")
+
+	account := LSAPolicyAccountDomInfo{DomainName: LSAUnicodeString{Value: "CORP"}, DomainSID: sid}
+	var da LSAPolicyAccountDomInfo
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &account), &da)
+	assert.Equal(t, account, da, "decoded account domain not as expected")
+
+	g, err := ParseGUID("01234567-89ab-cdef-0123-456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dns := LSAPolicyDNSDomainInfo{
+		Name:          LSAUnicodeString{Value: "CORP"},
+		DNSDomainName: LSAUnicodeString{Value: "corp.example"},
+		DNSForestName: LSAUnicodeString{Value: "example"},
+		DomainGUID:    g,
+		SID:           sid,
+	}
+	var dd LSAPolicyDNSDomainInfo
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &dns), &dd)
+	assert.Equal(t, dns, dd, "decoded DNS domain not as expected")
+}
@@ -0,0 +1,313 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+/*
+The counted strings of the LSA protocols, LSA_UNICODE_STRING and the ANSI STRING of MS-LSAD, share the layout of
+RPC_UNICODE_STRING and RPC_STRING but Windows fills the MaximumLength and the transmitted buffer differently depending
+on how the string was built. Strings initialised with RtlInitUnicodeString count a terminating null character in the
+MaximumLength without transmitting it, while some policy information is returned with the terminating null character
+transmitted. Ref: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-lsad/
+*/
+
+// LSAStringConvention selects how the MaximumLength and the buffer of an LSA string are encoded.
+type LSAStringConvention uint8
+
+// LSA string conventions
+const (
+	// LSAStringCounted encodes a MaximumLength equal to the Length without a terminating null character.
+	LSAStringCounted LSAStringConvention = iota
+	// LSAStringRtlInit encodes a MaximumLength counting a terminating null character that is not transmitted, as
+	// strings built by RtlInitUnicodeString and RtlInitString.
+	LSAStringRtlInit
+	// LSAStringNullTerminated encodes a MaximumLength counting a terminating null character that is transmitted.
+	LSAStringNullTerminated
+)
+
+// String returns the name of the convention.
+func (c LSAStringConvention) String() string {
+	switch c {
+	case LSAStringCounted:
+		return "Counted"
+	case LSAStringRtlInit:
+		return "RtlInit"
+	case LSAStringNullTerminated:
+		return "NullTerminated"
+	}
+	return fmt.Sprintf("LSAStringConvention(%d)", uint8(c))
+}
+
+// counts returns the MaximumLength and the maximum and actual counts of the buffer array for a string of length
+// bytes and characters of size bytes.
+func (c LSAStringConvention) counts(length uint16, size int) (maxLength uint16, max, actual uint32, err error) {
+	switch c {
+	case LSAStringCounted:
+		maxLength = length
+	case LSAStringRtlInit, LSAStringNullTerminated:
+		maxLength, err = countedLength(int(length) + size)
+		if err != nil {
+			return
+		}
+	default:
+		err = fmt.Errorf("invalid LSA string convention %d", uint8(c))
+		return
+	}
+	max = uint32(maxLength) / uint32(size)
+	actual = uint32(length) / uint32(size)
+	if c == LSAStringNullTerminated {
+		actual++
+	}
+	return
+}
+
+// lsaStringConvention returns the convention of a decoded string of length bytes with a MaximumLength of maxLength
+// bytes and actual characters of size bytes transmitted.
+func lsaStringConvention(length, maxLength uint16, actual uint32, size int) LSAStringConvention {
+	switch {
+	case actual == uint32(length)/uint32(size)+1:
+		return LSAStringNullTerminated
+	case int(maxLength) == int(length)+size:
+		return LSAStringRtlInit
+	}
+	return LSAStringCounted
+}
+
+// LSAUnicodeString implements LSA_UNICODE_STRING, the RPC_UNICODE_STRING used by the LSA protocols, encoding the
+// MaximumLength and buffer as selected by the Convention. FromReader sets the Convention the string was received with.
+type LSAUnicodeString struct {
+	Convention LSAStringConvention
+	Value      string
+}
+
+// NewLSAUnicodeString returns an LSAUnicodeString holding s encoded with the convention c. An error wrapping
+// ErrStringTooLong is returned if the string exceeds MaxCountedStringLength.
+func NewLSAUnicodeString(s string, c LSAStringConvention) (LSAUnicodeString, error) {
+	length, err := countedLength(len(wideChars(s, false)) * SizeUint16)
+	if err != nil {
+		return LSAUnicodeString{}, err
+	}
+	_, _, _, err = c.counts(length, SizeUint16)
+	if err != nil {
+		return LSAUnicodeString{}, err
+	}
+	return LSAUnicodeString{Convention: c, Value: s}, nil
+}
+
+// String returns the LSAUnicodeString string value.
+func (s *LSAUnicodeString) String() string {
+	return s.Value
+}
+
+// FromReader reads the LSAUnicodeString from r. The string buffer is read when r.Deferred is called.
+func (s *LSAUnicodeString) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	length, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	maxLength, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	s.Convention = lsaStringConvention(length, maxLength, 0, SizeUint16)
+	s.Value = ""
+	_, err = r.fieldPointer("Buffer", func() error {
+		u, _, err := r.wideChars()
+		if err != nil {
+			return err
+		}
+		s.Convention = lsaStringConvention(length, maxLength, uint32(len(u)), SizeUint16)
+		if n := int(length / SizeUint16); n < len(u) {
+			u = u[:n]
+		}
+		s.Value, err = r.wideString(u, false)
+		return err
+	})
+	return
+}
+
+// ToWriter writes the LSAUnicodeString to w. An empty Counted string is written as a null pointer.
+func (s *LSAUnicodeString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	u := wideChars(s.Value, false)
+	length, err := countedLength(len(u) * SizeUint16)
+	if err != nil {
+		return
+	}
+	maxLength, max, actual, err := s.Convention.counts(length, SizeUint16)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(maxLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if maxLength > 0 {
+		if actual > uint32(len(u)) {
+			u = append(u, 0)
+		}
+		fn = func() error {
+			return nw.wideChars(u, max)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAUnicodeString.
+func (s *LSAUnicodeString) Size() int {
+	return ndrSize(s)
+}
+
+// LSAString implements the ANSI STRING of MS-LSAD, encoding the MaximumLength and buffer as selected by the
+// Convention. FromReader sets the Convention the string was received with. The characters are kept as transmitted;
+// use Decode to convert them with the codepage of the peer.
+type LSAString struct {
+	Convention LSAStringConvention
+	Buffer     []byte
+}
+
+// NewLSAString returns an LSAString holding s encoded with the codepage cp and the convention c. An error wrapping
+// ErrStringTooLong is returned if the encoding exceeds MaxCountedStringLength.
+func NewLSAString(s string, cp Codepage, c LSAStringConvention) (LSAString, error) {
+	b, err := cp.Encode(s)
+	if err != nil {
+		return LSAString{}, err
+	}
+	length, err := countedLength(len(b))
+	if err != nil {
+		return LSAString{}, err
+	}
+	_, _, _, err = c.counts(length, SizeChar)
+	if err != nil {
+		return LSAString{}, err
+	}
+	return LSAString{Convention: c, Buffer: b}, nil
+}
+
+// Decode returns the string converted from the codepage cp.
+func (s *LSAString) Decode(cp Codepage) (string, error) {
+	return cp.Decode(s.Buffer)
+}
+
+// String returns the string converted from ISO 8859-1.
+func (s *LSAString) String() string {
+	v, _ := s.Decode(CodepageLatin1)
+	return v
+}
+
+// FromReader reads the LSAString from r. The string buffer is read when r.Deferred is called.
+func (s *LSAString) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	length, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	maxLength, err := r.Uint16()
+	if err != nil {
+		return
+	}
+	s.Convention = lsaStringConvention(length, maxLength, 0, SizeChar)
+	s.Buffer = nil
+	_, err = r.fieldPointer("Buffer", func() error {
+		max, err := r.Conformance()
+		if err != nil {
+			return err
+		}
+		offset, actual, err := r.Variance()
+		if err != nil {
+			return err
+		}
+		if uint64(offset)+uint64(actual) > uint64(max) {
+			return fmt.Errorf("string offset %d and actual count %d exceed the maximum count %d", offset, actual, max)
+		}
+		n, err := r.Allocate(actual, SizeChar)
+		if err != nil {
+			return err
+		}
+		s.Buffer, err = r.ReadBytes(n)
+		if err != nil {
+			return err
+		}
+		s.Convention = lsaStringConvention(length, maxLength, actual, SizeChar)
+		if int(length) < len(s.Buffer) {
+			s.Buffer = s.Buffer[:length]
+		}
+		return nil
+	})
+	return
+}
+
+// ToWriter writes the LSAString to w. An empty Counted string is written as a null pointer.
+func (s *LSAString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	length, err := countedLength(len(s.Buffer))
+	if err != nil {
+		return
+	}
+	maxLength, max, actual, err := s.Convention.counts(length, SizeChar)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(maxLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if maxLength > 0 {
+		b := s.Buffer
+		if actual > uint32(len(b)) {
+			b = append(b[:len(b):len(b)], 0)
+		}
+		fn = func() error {
+			err := nw.Conformance(max)
+			if err != nil {
+				return err
+			}
+			err = nw.Variance(0, actual)
+			if err != nil {
+				return err
+			}
+			return nw.WriteBytes(b)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSAString.
+func (s *LSAString) Size() int {
+	return ndrSize(s)
+}
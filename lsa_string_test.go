@@ -0,0 +1,71 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LSAUnicodeStringConventions(t *testing.T) {
+	var tests = []struct {
+		Convention LSAStringConvention
+		Hex        string
+	}{
+		{LSAStringCounted, "04000400" + "00000200" + "02000000" + "00000000" + "02000000" + "61006200"},
+		{LSAStringRtlInit, "04000600" + "00000200" + "03000000" + "00000000" + "02000000" + "61006200"},
+		{LSAStringNullTerminated, "04000600" + "00000200" + "03000000" + "00000000" + "03000000" + "610062000000"},
+	}
+	for _, test := range tests {
+		s, err := NewLSAUnicodeString("ab", test.Convention)
+		assert.NoError(t, err)
+		b, err := Marshal(&s)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Hex, hex.EncodeToString(b), "%v string not encoded as expected", test.Convention)
+
+		var v LSAUnicodeString
+		assert.NoError(t, Unmarshal(b, &v))
+		assert.Equal(t, s, v, "%v string not decoded as expected", test.Convention)
+	}
+}
+
+func Test_LSAUnicodeStringEmpty(t *testing.T) {
+	s := LSAUnicodeString{}
+	b, err := Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000000000000", hex.EncodeToString(b), "empty counted string not a null pointer")
+
+	s.Convention = LSAStringRtlInit
+	b, err = Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, "00000200"+"00000200"+"01000000"+"00000000"+"00000000", hex.EncodeToString(b))
+	var v LSAUnicodeString
+	assert.NoError(t, Unmarshal(b, &v))
+	assert.Equal(t, s, v)
+
+	_, err = NewLSAUnicodeString("a", LSAStringConvention(3))
+	assert.Error(t, err, "invalid convention not detected")
+}
+
+func Test_LSAStringConventions(t *testing.T) {
+	var tests = []struct {
+		Convention LSAStringConvention
+		Hex        string
+	}{
+		{LSAStringCounted, "02000200" + "00000200" + "02000000" + "00000000" + "02000000" + "6162"},
+		{LSAStringRtlInit, "02000300" + "00000200" + "03000000" + "00000000" + "02000000" + "6162"},
+		{LSAStringNullTerminated, "02000300" + "00000200" + "03000000" + "00000000" + "03000000" + "616200"},
+	}
+	for _, test := range tests {
+		s, err := NewLSAString("ab", CodepageLatin1, test.Convention)
+		assert.NoError(t, err)
+		b, err := Marshal(&s)
+		assert.NoError(t, err)
+		assert.Equal(t, test.Hex, hex.EncodeToString(b), "%v string not encoded as expected", test.Convention)
+
+		var v LSAString
+		assert.NoError(t, Unmarshal(b, &v))
+		assert.Equal(t, s, v, "%v string not decoded as expected", test.Convention)
+		assert.Equal(t, "ab", v.String())
+	}
+}
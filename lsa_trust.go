@@ -0,0 +1,189 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+// TrustDirection is the direction of a trust of MS-LSAD and the trustDirection attribute of MS-ADTS. An inbound trust
+// lets the trusted domain's principals access this domain, an outbound trust lets this domain's principals access the
+// trusted domain.
+type TrustDirection uint32
+
+// Trust directions
+const (
+	TrustDirectionDisabled      TrustDirection = 0x00000000
+	TrustDirectionInbound       TrustDirection = 0x00000001
+	TrustDirectionOutbound      TrustDirection = 0x00000002
+	TrustDirectionBidirectional                = TrustDirectionInbound | TrustDirectionOutbound
+)
+
+// Has reports whether all the directions d are set.
+func (t TrustDirection) Has(d TrustDirection) bool {
+	return t&d == d
+}
+
+// String returns the name of the direction.
+func (t TrustDirection) String() string {
+	switch t {
+	case TrustDirectionDisabled:
+		return "Disabled"
+	case TrustDirectionInbound:
+		return "Inbound"
+	case TrustDirectionOutbound:
+		return "Outbound"
+	case TrustDirectionBidirectional:
+		return "Bidirectional"
+	}
+	return fmt.Sprintf("TrustDirection(0x%x)", uint32(t))
+}
+
+// TrustType is the type of a trust of MS-LSAD and the trustType attribute of MS-ADTS.
+type TrustType uint32
+
+// Trust types
+const (
+	TrustTypeDownlevel TrustType = 0x00000001 // A domain running Windows NT 4.0 or earlier.
+	TrustTypeUplevel   TrustType = 0x00000002 // An Active Directory domain.
+	TrustTypeMIT       TrustType = 0x00000003 // A Kerberos realm that is not a Windows domain.
+	TrustTypeDCE       TrustType = 0x00000004 // Not used.
+	TrustTypeAAD       TrustType = 0x00000005 // An Azure Active Directory tenant.
+)
+
+// String returns the name of the type.
+func (t TrustType) String() string {
+	switch t {
+	case TrustTypeDownlevel:
+		return "Downlevel"
+	case TrustTypeUplevel:
+		return "Uplevel"
+	case TrustTypeMIT:
+		return "MIT"
+	case TrustTypeDCE:
+		return "DCE"
+	case TrustTypeAAD:
+		return "AAD"
+	}
+	return fmt.Sprintf("TrustType(%d)", uint32(t))
+}
+
+// TrustAttributes are the attributes of a trust of MS-LSAD and the trustAttributes attribute of MS-ADTS.
+type TrustAttributes uint32
+
+// Trust attribute flags
+const (
+	TrustAttributeNonTransitive                        TrustAttributes = 0x00000001
+	TrustAttributeUplevelOnly                          TrustAttributes = 0x00000002
+	TrustAttributeQuarantinedDomain                    TrustAttributes = 0x00000004 // SID filtering is applied.
+	TrustAttributeForestTransitive                     TrustAttributes = 0x00000008
+	TrustAttributeCrossOrganization                    TrustAttributes = 0x00000010 // Selective authentication is applied.
+	TrustAttributeWithinForest                         TrustAttributes = 0x00000020
+	TrustAttributeTreatAsExternal                      TrustAttributes = 0x00000040
+	TrustAttributeUsesRC4Encryption                    TrustAttributes = 0x00000080
+	TrustAttributeCrossOrganizationNoTGTDelegation     TrustAttributes = 0x00000200
+	TrustAttributePIMTrust                             TrustAttributes = 0x00000400
+	TrustAttributeCrossOrganizationEnableTGTDelegation TrustAttributes = 0x00000800
+	TrustAttributeDisableAuthTargetValidation          TrustAttributes = 0x00001000
+)
+
+var trustAttributeNames = []flagName[TrustAttributes]{
+	{TrustAttributeNonTransitive, "NonTransitive"},
+	{TrustAttributeUplevelOnly, "UplevelOnly"},
+	{TrustAttributeQuarantinedDomain, "QuarantinedDomain"},
+	{TrustAttributeForestTransitive, "ForestTransitive"},
+	{TrustAttributeCrossOrganization, "CrossOrganization"},
+	{TrustAttributeWithinForest, "WithinForest"},
+	{TrustAttributeTreatAsExternal, "TreatAsExternal"},
+	{TrustAttributeUsesRC4Encryption, "UsesRC4Encryption"},
+	{TrustAttributeCrossOrganizationNoTGTDelegation, "CrossOrganizationNoTGTDelegation"},
+	{TrustAttributePIMTrust, "PIMTrust"},
+	{TrustAttributeCrossOrganizationEnableTGTDelegation, "CrossOrganizationEnableTGTDelegation"},
+	{TrustAttributeDisableAuthTargetValidation, "DisableAuthTargetValidation"},
+}
+
+// Has reports whether all the flags f are set.
+func (a TrustAttributes) Has(f TrustAttributes) bool {
+	return a&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (a TrustAttributes) String() string {
+	return formatFlags(a, trustAttributeNames)
+}
+
+// LSATrustedDomainInformationEx implements LSAPR_TRUSTED_DOMAIN_INFORMATION_EX of MS-LSAD, a trusted domain as
+// enumerated by LsarEnumerateTrustedDomainsEx and queried with the TrustedDomainInformationEx class. The basic form
+// holding only the name and SID is LSATrustInformation.
+type LSATrustedDomainInformationEx struct {
+	Name            LSAUnicodeString // The DNS name of the domain, the NetBIOS name for a downlevel trust.
+	FlatName        LSAUnicodeString // The NetBIOS name of the domain.
+	SID             *RPCSID          // A pointer to the domain SID, nil if the pointer is null.
+	TrustDirection  TrustDirection
+	TrustType       TrustType
+	TrustAttributes TrustAttributes
+}
+
+// String returns the name of the domain followed by the direction, type and attributes of the trust.
+func (t *LSATrustedDomainInformationEx) String() string {
+	return fmt.Sprintf("%s (%s, %s, %s)", t.Name.Value, t.TrustDirection, t.TrustType, t.TrustAttributes)
+}
+
+// FromReader reads the LSATrustedDomainInformationEx from r. The names and SID are read when r.Deferred is called.
+func (t *LSATrustedDomainInformationEx) FromReader(r *Reader) (err error) {
+	err = r.Field("Name", func() error {
+		return t.Name.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("FlatName", func() error {
+		return t.FlatName.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	t.SID, err = readSIDPointer(r, "SID")
+	if err != nil {
+		return
+	}
+	var v [3]uint32
+	for i := range v {
+		v[i], err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	t.TrustDirection = TrustDirection(v[0])
+	t.TrustType = TrustType(v[1])
+	t.TrustAttributes = TrustAttributes(v[2])
+	return
+}
+
+// ToWriter writes the LSATrustedDomainInformationEx to w.
+func (t *LSATrustedDomainInformationEx) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = t.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = t.FlatName.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, t.SID)
+	if err != nil {
+		return
+	}
+	for _, v := range []uint32{uint32(t.TrustDirection), uint32(t.TrustType), uint32(t.TrustAttributes)} {
+		err = nw.Uint32(v)
+		if err != nil {
+			return
+		}
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of LSATrustedDomainInformationEx.
+func (t *LSATrustedDomainInformationEx) Size() int {
+	return ndrSize(t)
+}
@@ -0,0 +1,37 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TrustStrings(t *testing.T) {
+	assert.Equal(t, "Bidirectional", TrustDirectionBidirectional.String(), "direction name not as expected")
+	assert.Equal(t, "Disabled", TrustDirectionDisabled.String(), "direction name not as expected")
+	assert.Equal(t, "TrustDirection(0x4)", TrustDirection(4).String(), "unknown direction not as expected")
+	assert.True(t, TrustDirectionBidirectional.Has(TrustDirectionInbound), "inbound direction not found")
+	assert.False(t, TrustDirectionOutbound.Has(TrustDirectionInbound), "inbound direction found")
+	assert.Equal(t, "Uplevel", TrustTypeUplevel.String(), "type name not as expected")
+	assert.Equal(t, "TrustType(9)", TrustType(9).String(), "unknown type not as expected")
+	assert.Equal(t, "ForestTransitive|UsesRC4Encryption|0x100",
+		(TrustAttributeForestTransitive | TrustAttributeUsesRC4Encryption | 0x100).String(), "attributes not as expected")
+	assert.True(t, TrustAttributes(0x28).Has(TrustAttributeWithinForest), "within forest attribute not found")
+}
+
+func Test_LSATrustedDomainInformationEx(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	info := LSATrustedDomainInformationEx{
+		Name:            LSAUnicodeString{Value: "child.corp.example"},
+		FlatName:        LSAUnicodeString{Value: "CHILD"},
+		SID:             sid,
+		TrustDirection:  TrustDirectionBidirectional,
+		TrustType:       TrustTypeUplevel,
+		TrustAttributes: TrustAttributeWithinForest,
+	}
+	assert.Equal(t, "child.corp.example (Bidirectional, Uplevel, WithinForest)", info.String(), "string not as expected")
+
+	var d LSATrustedDomainInformationEx
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &info), &d)
+	assert.Equal(t, info, d, "decoded value not as expected")
+}
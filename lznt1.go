@@ -0,0 +1,150 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// LZNT1 layout
+const (
+	lznt1ChunkSize      = 4096   // uncompressed size of a chunk
+	lznt1SizeMask       = 0x0fff // chunk header bits holding the chunk size less 3
+	lznt1CompressedFlag = 0x8000 // chunk header bit set when the chunk is compressed
+	lznt1MinMatch       = 3      // length of a match of length 0
+)
+
+// decompressLZNT1 decompresses the LZNT1 stream b of MS-XCA to size bytes. The stream is a sequence of chunks each
+// decompressing to 4096 bytes, the last possibly fewer, ended by the end of b or a zero chunk header.
+func decompressLZNT1(b []byte, size int) ([]byte, error) {
+	out := newDecompressBuffer(size)
+	pos := 0
+	for pos+SizeUint16 <= len(b) {
+		header := binary.LittleEndian.Uint16(b[pos:])
+		if header == 0 {
+			break
+		}
+		if len(out)%lznt1ChunkSize != 0 {
+			// A chunk decompressing to less than 4096 bytes is padded with zeros when another chunk follows.
+			pad := lznt1ChunkSize - len(out)%lznt1ChunkSize
+			if pad > size-len(out) {
+				return out, fmt.Errorf("%w: LZNT1 chunk at input position %d exceeds %d bytes", ErrCompressedData,
+					pos, size)
+			}
+			out = append(out, make([]byte, pad)...)
+		}
+		n := int(header&lznt1SizeMask) + 1
+		pos += SizeUint16
+		if pos+n > len(b) {
+			return out, fmt.Errorf("%w: LZNT1 chunk of %d bytes truncated at input position %d", ErrCompressedData,
+				n, pos)
+		}
+		chunk := b[pos : pos+n]
+		pos += n
+		if header&lznt1CompressedFlag == 0 {
+			if len(chunk) > size-len(out) {
+				return out, fmt.Errorf("%w: LZNT1 chunk at input position %d exceeds %d bytes", ErrCompressedData,
+					pos, size)
+			}
+			out = append(out, chunk...)
+			continue
+		}
+		var err error
+		out, err = decompressLZNT1Chunk(out, chunk, size)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// decompressLZNT1Chunk appends the decompressed compressed chunk b to out. Each flag byte, least significant bit
+// first, selects a literal byte or a 16 bit match whose split between offset and length depends on the position in
+// the chunk.
+func decompressLZNT1Chunk(out, b []byte, size int) ([]byte, error) {
+	start := len(out)
+	pos := 0
+	for pos < len(b) {
+		flags := b[pos]
+		pos++
+		for i := 0; i < 8 && pos < len(b); i++ {
+			if flags&(1<<i) == 0 {
+				if len(out) >= size {
+					return out, fmt.Errorf("%w: LZNT1 literal exceeds %d bytes", ErrCompressedData, size)
+				}
+				out = append(out, b[pos])
+				pos++
+				continue
+			}
+			if pos+SizeUint16 > len(b) {
+				return out, fmt.Errorf("%w: LZNT1 match truncated", ErrCompressedData)
+			}
+			token := int(binary.LittleEndian.Uint16(b[pos:]))
+			pos += SizeUint16
+			lengthBits := 12
+			for p := len(out) - start - 1; p >= 0x10; p >>= 1 {
+				lengthBits--
+			}
+			offset := token>>lengthBits + 1
+			length := token&(1<<lengthBits-1) + lznt1MinMatch
+			if offset > len(out)-start {
+				return out, fmt.Errorf("%w: LZNT1 match offset %d before the chunk", ErrCompressedData, offset)
+			}
+			var err error
+			out, err = copyMatch(out, offset, length, size)
+			if err != nil {
+				return out, err
+			}
+		}
+	}
+	return out, nil
+}
+
+// compressLZNT1 compresses b to an LZNT1 stream of MS-XCA, storing a chunk uncompressed when compressing does not
+// make it smaller.
+func compressLZNT1(b []byte) []byte {
+	out := make([]byte, 0, len(b)+len(b)/lznt1ChunkSize*SizeUint16+SizeUint16)
+	m := newLZ77Matcher(b)
+	for start := 0; start < len(b); start += lznt1ChunkSize {
+		end := min(start+lznt1ChunkSize, len(b))
+		chunk := compressLZNT1Chunk(m, b[:end], start)
+		header := uint16(0x3000)
+		if len(chunk) < end-start {
+			header |= lznt1CompressedFlag
+		} else {
+			chunk = b[start:end]
+		}
+		out = binary.LittleEndian.AppendUint16(out, header|uint16(len(chunk)-1))
+		out = append(out, chunk...)
+	}
+	return out
+}
+
+// compressLZNT1Chunk returns the compressed chunk of the bytes of b from start, whose matches lie within the chunk.
+func compressLZNT1Chunk(m *lz77Matcher, b []byte, start int) []byte {
+	var out []byte
+	flagPos := 0
+	i := 0
+	for pos := start; pos < len(b); i++ {
+		if i%8 == 0 {
+			flagPos = len(out)
+			out = append(out, 0)
+		}
+		lengthBits := 12
+		for p := pos - start - 1; p >= 0x10; p >>= 1 {
+			lengthBits--
+		}
+		offset, length := m.find(pos, start, 1<<(16-lengthBits), min(1<<lengthBits-1+lznt1MinMatch, len(b)-pos))
+		if length == 0 {
+			m.insert(pos)
+			out = append(out, b[pos])
+			pos++
+			continue
+		}
+		out[flagPos] |= 1 << (i % 8)
+		out = binary.LittleEndian.AppendUint16(out, uint16((offset-1)<<lengthBits|(length-lznt1MinMatch)))
+		for end := pos + length; pos < end; pos++ {
+			m.insert(pos)
+		}
+	}
+	return out
+}
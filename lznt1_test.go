@@ -0,0 +1,51 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecompressLZNT1(t *testing.T) {
+	alphabet := "abcdefghijklmnopqrst"
+	var tests = []struct {
+		in   string
+		want string
+	}{
+		// Literals abc and a match of length 9 at offset 3 with 4 offset bits.
+		{"05b00861626306200000", strings.Repeat("abc", 4)},
+		// An uncompressed chunk.
+		{"023078797a", "xyz"},
+		// A match of length 20 at offset 20 after 20 literals has 5 offset bits.
+		{"18b000" + hex.EncodeToString([]byte(alphabet[:8])) + "00" + hex.EncodeToString([]byte(alphabet[8:16])) +
+			"10" + hex.EncodeToString([]byte(alphabet[16:])) + "1198", alphabet + alphabet},
+		// A short chunk followed by another is padded to 4096 bytes.
+		{"05b008616263062002307879" + "7a", strings.Repeat("abc", 4) + strings.Repeat("\x00", 4096-12) + "xyz"},
+	}
+	for _, test := range tests {
+		in, _ := hex.DecodeString(test.in)
+		out, err := decompressLZNT1(in, len(test.want))
+		if err != nil {
+			t.Fatalf("error decompressing %s: %v", test.in, err)
+		}
+		assert.True(t, bytes.Equal([]byte(test.want), out), "decompression of %s not as expected: %q", test.in, out)
+	}
+}
+
+func Test_DecompressLZNT1Invalid(t *testing.T) {
+	for _, s := range []string{
+		"05b0086162",              // truncated chunk
+		"03b00161620000",          // offset before the chunk
+		"04b00861626306",          // truncated match
+		"023078797a023078797a",    // padding exceeds the size
+		"0fb0086162630620" + "00", // chunk size exceeds the input
+	} {
+		in, _ := hex.DecodeString(s)
+		_, err := decompressLZNT1(in, 12)
+		assert.True(t, errors.Is(err, ErrCompressedData), "error of %s not as expected: %v", s, err)
+	}
+}
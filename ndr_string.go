@@ -0,0 +1,161 @@
+package mstypes
+
+import (
+	"fmt"
+	"unicode/utf16"
+)
+
+/*
+NDR strings are transmitted as varying or conformant varying arrays of WCHAR.
+Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_03_04_02
+Strings declared with the [string] attribute, such as LPWSTR, include a terminating null character in the array.
+Counted strings, such as the buffer of an RPC_UNICODE_STRING, are not null terminated.
+*/
+
+// ConformantVaryingString reads a conformant varying array of WCHAR. If nullTerminated is true a terminating null
+// character is removed from the string.
+func (r *Reader) ConformantVaryingString(nullTerminated bool) (string, error) {
+	u, _, err := r.wideChars()
+	if err != nil {
+		return "", err
+	}
+	return r.wideString(u, nullTerminated)
+}
+
+// VaryingString reads a varying array of WCHAR. If nullTerminated is true a terminating null character is removed
+// from the string.
+func (r *Reader) VaryingString(nullTerminated bool) (string, error) {
+	_, actual, err := r.Variance()
+	if err != nil {
+		return "", err
+	}
+	u, err := r.wideCharArray(actual)
+	if err != nil {
+		return "", err
+	}
+	return r.wideString(u, nullTerminated)
+}
+
+// ConformantVaryingString writes s as a conformant varying array of WCHAR. If nullTerminated is true a terminating
+// null character is appended.
+func (w *Writer) ConformantVaryingString(s string, nullTerminated bool) error {
+	u := wideChars(s, nullTerminated)
+	return w.wideChars(u, uint32(len(u)))
+}
+
+// VaryingString writes s as a varying array of WCHAR. If nullTerminated is true a terminating null character is
+// appended.
+func (w *Writer) VaryingString(s string, nullTerminated bool) error {
+	u := wideChars(s, nullTerminated)
+	err := w.Variance(0, uint32(len(u)))
+	if err != nil {
+		return err
+	}
+	return w.wideCharArray(u)
+}
+
+// wideChars returns the UTF-16 encoding of s, with a terminating null character if nullTerminated is true.
+func wideChars(s string, nullTerminated bool) []uint16 {
+	u := utf16.Encode([]rune(s))
+	if nullTerminated {
+		u = append(u, 0)
+	}
+	return u
+}
+
+// wideString returns the string of the UTF-16 characters u, removing a terminating null character if nullTerminated
+// is true.
+func (r *Reader) wideString(u []uint16, nullTerminated bool) (string, error) {
+	if nullTerminated && len(u) > 0 && u[len(u)-1] == 0 {
+		u = u[:len(u)-1]
+	}
+	return decodeUTF16(u, r.opts.strictUTF16)
+}
+
+// wideChars reads a conformant varying array of WCHAR returning the transmitted characters and the maximum count.
+func (r *Reader) wideChars() (u []uint16, max uint32, err error) {
+	max, err = r.Conformance()
+	if err != nil {
+		return
+	}
+	offset, actual, err := r.Variance()
+	if err != nil {
+		return
+	}
+	if uint64(offset)+uint64(actual) > uint64(max) {
+		err = fmt.Errorf("string offset %d and actual count %d exceed the maximum count %d", offset, actual, max)
+		return
+	}
+	u, err = r.wideCharArray(actual)
+	return
+}
+
+// wideCharArray reads count WCHAR.
+func (r *Reader) wideCharArray(count uint32) (u []uint16, err error) {
+	n, err := r.Allocate(count, SizeUint16)
+	if err != nil {
+		return
+	}
+	u = make([]uint16, n)
+	for i := range u {
+		u[i], err = r.Uint16()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// wideChars writes a conformant varying array of WCHAR with the maximum count max.
+func (w *Writer) wideChars(u []uint16, max uint32) error {
+	err := w.Conformance(max)
+	if err != nil {
+		return err
+	}
+	err = w.Variance(0, uint32(len(u)))
+	if err != nil {
+		return err
+	}
+	return w.wideCharArray(u)
+}
+
+// wideCharArray writes the WCHAR u.
+func (w *Writer) wideCharArray(u []uint16) error {
+	for _, c := range u {
+		err := w.Uint16(c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fixedWideString reads a fixed array of count WCHAR holding a string terminated by a null character if it is shorter
+// than the array.
+func (r *Reader) fixedWideString(count uint32) (string, error) {
+	u, err := r.wideCharArray(count)
+	if err != nil {
+		return "", err
+	}
+	for i, c := range u {
+		if c == 0 {
+			u = u[:i]
+			break
+		}
+	}
+	return r.wideString(u, false)
+}
+
+// fixedWideString writes s as a fixed array of count WCHAR padded with null characters. An error is returned if s
+// does not fit with a terminating null character.
+func (w *Writer) fixedWideString(s string, count int) error {
+	u := wideChars(s, false)
+	if len(u) >= count {
+		return fmt.Errorf("string of %d characters does not fit in an array of %d WCHAR with a terminating null character", len(u), count)
+	}
+	err := w.wideCharArray(u)
+	if err != nil {
+		return err
+	}
+	return w.WriteBytes(make([]byte, (count-len(u))*SizeUint16))
+}
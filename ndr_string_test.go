@@ -0,0 +1,52 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NDRStrings(t *testing.T) {
+	var tests = []struct {
+		Value          string
+		NullTerminated bool
+		Conformant     bool
+		Hex            string
+	}{
+		{"str1", true, true, "05000000" + "00000000" + "05000000" + "7300740072003100" + "0000"},
+		{"str1", false, true, "04000000" + "00000000" + "04000000" + "7300740072003100"},
+		{"str1", true, false, "00000000" + "05000000" + "7300740072003100" + "0000"},
+		{"", true, true, "01000000" + "00000000" + "01000000" + "0000"},
+		{"\U0001f600", false, true, "02000000" + "00000000" + "02000000" + "3dd800de"},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		var err error
+		if test.Conformant {
+			err = w.ConformantVaryingString(test.Value, test.NullTerminated)
+		} else {
+			err = w.VaryingString(test.Value, test.NullTerminated)
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.Hex, hex.EncodeToString(buf.Bytes()), "encoding not as expected for test %d", i+1)
+
+		r := NewReader(bytes.NewReader(buf.Bytes()))
+		var s string
+		if test.Conformant {
+			s, err = r.ConformantVaryingString(test.NullTerminated)
+		} else {
+			s, err = r.VaryingString(test.NullTerminated)
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.Value, s, "decoding not as expected for test %d", i+1)
+	}
+}
+
+func Test_NDRStringInvalidCounts(t *testing.T) {
+	b, _ := hex.DecodeString("02000000" + "01000000" + "02000000" + "41004200")
+	_, err := NewReader(bytes.NewReader(b)).ConformantVaryingString(false)
+	assert.Error(t, err, "actual count beyond the maximum count not detected")
+}
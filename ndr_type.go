@@ -0,0 +1,74 @@
+package mstypes
+
+import (
+	"bytes"
+	"io"
+)
+
+// NDRType is implemented by the wire types of the package so container code, such as buffers of typed elements or
+// the PAC info buffers, can marshal them polymorphically.
+//
+// FromReader reads the type from r. The referents of pointers embedded in the type are read when r.Deferred is
+// called, which the owner of the top-level type must do; Unmarshal does this.
+// ToWriter writes the type to w. If w is a *Writer the referents of embedded pointers are left for the owner of the
+// top-level type to write by calling Deferred, otherwise the type is written as a top-level type including them.
+// Size returns the number of bytes ToWriter writes for a top-level type.
+type NDRType interface {
+	FromReader(r *Reader) error
+	ToWriter(w io.Writer) error
+	Size() int
+}
+
+// Marshal returns the NDR representation of the top-level type v including the referents of its embedded pointers.
+func Marshal(v NDRType, opts ...Option) ([]byte, error) {
+	w := AcquireWriter(opts...)
+	defer ReleaseWriter(w)
+	err := v.ToWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Deferred()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), w.Bytes()...), nil
+}
+
+// Unmarshal reads the top-level type v including the referents of its embedded pointers from b. A decoding failure
+// is returned as a DecodeError.
+func Unmarshal(b []byte, v NDRType, opts ...Option) error {
+	r := NewReader(bytes.NewReader(b), opts...)
+	return r.Field(typeName(v), func() error {
+		err := v.FromReader(r)
+		if err != nil {
+			return err
+		}
+		return r.Deferred()
+	})
+}
+
+// countWriter counts the bytes written to it.
+type countWriter struct {
+	n int
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return len(p), nil
+}
+
+// ndrSize returns the number of bytes written by ToWriter for v as a top-level type.
+func ndrSize(v NDRType) int {
+	var c countWriter
+	v.ToWriter(&c)
+	return c.n
+}
+
+// topLevel writes the referents of the pointers written by w if dst, the io.Writer passed to ToWriter, was not a
+// *Writer, making the type written a top-level type.
+func (w *Writer) topLevel(dst io.Writer) error {
+	if _, ok := dst.(*Writer); ok {
+		return nil
+	}
+	return w.Deferred()
+}
@@ -0,0 +1,171 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/jfjallid/ndr"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = []NDRType{
+	new(LPWSTR),
+	new(FileTime),
+	new(PackedFileTime),
+	new(SystemTime),
+	new(TimeZoneInformation),
+	new(DynamicTimeZoneInformation),
+	new(RegTZI),
+	new(LargeInteger),
+	new(ULargeInteger),
+	new(GUID),
+	new(RPCSID),
+	new(GroupMembership),
+	new(GroupMemberships),
+	new(DomainGroupMembership),
+	new(KerbSidAndAttributes),
+	new(KerbValidationInfo),
+	new(LazyKerbValidationInfo),
+	new(PACCredentialData),
+	new(PACDeviceInfo),
+	new(S4UDelegationInfo),
+	new(SecPkgSupplementalCred),
+	new(RPCUnicodeString),
+	new(PRPCUnicodeString),
+	new(RPCUnicodeStringExact),
+	new(RPCUnicodeStringArray),
+	new(RPCString),
+	new(LSAUnicodeString),
+	new(LSAString),
+	new(LSASIDInformation),
+	new(LSASIDEnumBuffer),
+	new(LSATranslatedName),
+	new(LSATranslatedNames),
+	new(LSATranslatedNameEx),
+	new(LSATranslatedNamesEx),
+	new(LSATrustInformation),
+	new(LSAReferencedDomainList),
+	new(LSATrustedDomainInformationEx),
+	new(LSAPolicyPrimaryDomInfo),
+	new(LSAPolicyAccountDomInfo),
+	new(LSAPolicyDNSDomainInfo),
+	new(SAMRIDEnumeration),
+	new(SAMEnumerationBuffer),
+	new(RPCShortBlob),
+	new(SAMSRSecurityDescriptor),
+	new(SAMLogonHours),
+	new(DomainPasswordInformation),
+	new(NetlogonValidationSAMInfo2),
+	new(NetlogonValidationSAMInfo4),
+	new(NLTrustPassword),
+	new(NLPasswordVersion),
+	new(SAMUserAllInformation),
+	new(SAMEncryptedUserPassword),
+	new(SAMEncryptedUserPasswordNew),
+	new(SAMGetGroupsBuffer),
+	new(SAMGetMembersBuffer),
+	new(SAMULongArray),
+	new(SAMPSIDArray),
+	new(CypherBlock),
+	new(UserSessionKey),
+	new(ClaimsSetMetadata),
+	new(ClaimsSet),
+	new(ClaimsArray),
+	new(ClaimEntry),
+	new(ClaimTypeInt64),
+	new(ClaimTypeUInt64),
+	new(ClaimTypeString),
+	new(ClaimTypeBoolean),
+	new(CommonTypeHeader),
+	new(PrivateHeader),
+}
+
+// decodeTypeSerialized reads the type serialized top-level pointer to v from b.
+func decodeTypeSerialized(t *testing.T, b []byte, v NDRType) {
+	ch, obj, err := UnwrapTypeSerialized(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReader(bytes.NewReader(obj), WithByteOrder(ch.ByteOrder()))
+	_, err = r.Pointer(func() error {
+		return v.FromReader(r)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// encodeTypeSerialized writes v as a type serialized top-level pointer.
+func encodeTypeSerialized(t *testing.T, v NDRType) []byte {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	err := w.Pointer(func() error {
+		return v.ToWriter(w)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return WrapTypeSerialized(buf.Bytes())
+}
+
+func Test_NDRTypeClaims(t *testing.T) {
+	for i, test := range []string{ClientClaimsInfoStr, ClientClaimsInfoInt, ClientClaimsInfoMulti, ClientClaimsInfoMultiUint, ClientClaimsInfoMultiStr} {
+		b, _ := hex.DecodeString(test)
+		expected := new(ClaimsSetMetadata)
+		err := ndr.NewDecoder(bytes.NewReader(b), true).Decode(expected)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		m := new(ClaimsSetMetadata)
+		decodeTypeSerialized(t, b, m)
+		assert.Equal(t, expected, m, "claims set metadata not as expected for test %d", i+1)
+		assert.Equal(t, b, encodeTypeSerialized(t, m), "claims set metadata encoding not as expected for test %d", i+1)
+
+		expectedSet, err := expected.ClaimsSet()
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		c := new(ClaimsSet)
+		decodeTypeSerialized(t, m.ClaimsSetBytes, c)
+		assert.Equal(t, expectedSet, *c, "claims set not as expected for test %d", i+1)
+		assert.Equal(t, m.ClaimsSetBytes, encodeTypeSerialized(t, c), "claims set encoding not as expected for test %d", i+1)
+	}
+}
+
+func Test_NDRTypeRPCUnicodeString(t *testing.T) {
+	b, _ := hex.DecodeString(TestRPCUnicodeStringBytes)
+	s := new(RPCUnicodeString)
+	r := NewReader(bytes.NewReader(b))
+	err := s.FromReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := r.Uint32()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestRPCUnicodeStringValue, s.Value)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, s.ToWriter(w))
+	assert.NoError(t, w.Uint32(other))
+	assert.NoError(t, w.Deferred())
+	assert.NoError(t, w.Align(4))
+	// The referent ID of the test bytes is the second one assigned in the structure they were taken from
+	b[4] = 0x00
+	assert.Equal(t, b, buf.Bytes())
+}
@@ -0,0 +1,143 @@
+package mstypes
+
+//go:generate go run ./cmd/ndrgen -type NLTrustPassword,NLPasswordVersion netlogon_password.go
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// NL_TRUST_PASSWORD sizes
+const (
+	// NLTrustPasswordBufferSize is the size of the Buffer of NL_TRUST_PASSWORD, 256 UTF-16 characters.
+	NLTrustPasswordBufferSize = SAMMaxPasswordLength * SizeUint16
+	// NLTrustPasswordSize is the size of NL_TRUST_PASSWORD, the buffer followed by the length.
+	NLTrustPasswordSize = NLTrustPasswordBufferSize + SizeUint32
+	// NLPasswordVersionSize is the size of NL_PASSWORD_VERSION.
+	NLPasswordVersionSize = 3 * SizeUint32
+)
+
+// NLPasswordVersionPresent is the PasswordVersionPresent value marking an NL_PASSWORD_VERSION as present.
+const NLPasswordVersionPresent = 0x02231968
+
+// NLPasswordVersion implements NL_PASSWORD_VERSION of MS-NRPC, the version of an interdomain trust password stored
+// in the NL_TRUST_PASSWORD buffer right before the password.
+type NLPasswordVersion struct {
+	ReservedField          uint32
+	PasswordVersionNumber  uint32
+	PasswordVersionPresent uint32 // NLPasswordVersionPresent
+}
+
+// NewNLPasswordVersion returns the NL_PASSWORD_VERSION of the version number n.
+func NewNLPasswordVersion(n uint32) NLPasswordVersion {
+	return NLPasswordVersion{PasswordVersionNumber: n, PasswordVersionPresent: NLPasswordVersionPresent}
+}
+
+// NLTrustPassword implements NL_TRUST_PASSWORD of MS-NRPC, the new machine or trust account password sent by
+// NetrServerPasswordSet2 once encrypted with the session key. The password is the last Length bytes of the Buffer, in
+// UTF-16LE; for a trust account it is preceded by an NL_PASSWORD_VERSION. The rest of the Buffer is random.
+type NLTrustPassword struct {
+	Buffer [NLTrustPasswordBufferSize]byte
+	Length uint32
+}
+
+// NewNLTrustPassword returns the NL_TRUST_PASSWORD holding password, preceded by version unless it is nil. The bytes
+// before them are random.
+func NewNLTrustPassword(password string, version *NLPasswordVersion) (NLTrustPassword, error) {
+	max := SAMMaxPasswordLength
+	if version != nil {
+		max -= NLPasswordVersionSize / SizeUint16
+	}
+	var p NLTrustPassword
+	n := len(wideChars(password, false))
+	if n > max {
+		return p, fmt.Errorf("password of %d characters exceeds %d characters: %w", n, max, ErrStringTooLong)
+	}
+	// The layout is that of SAMPR_USER_PASSWORD.
+	b, err := NewSAMUserPassword(password)
+	if err != nil {
+		return p, err
+	}
+	copy(p.Buffer[:], b[:])
+	p.Length = uint32(n * SizeUint16)
+	if version != nil {
+		v := p.Buffer[NLTrustPasswordBufferSize-int(p.Length)-NLPasswordVersionSize:]
+		binary.LittleEndian.PutUint32(v, version.ReservedField)
+		binary.LittleEndian.PutUint32(v[SizeUint32:], version.PasswordVersionNumber)
+		binary.LittleEndian.PutUint32(v[2*SizeUint32:], version.PasswordVersionPresent)
+	}
+	return p, nil
+}
+
+// NewNLEncryptedTrustPassword returns the NL_TRUST_PASSWORD built by NewNLTrustPassword encrypted by encrypt,
+// typically with the Netlogon session key using AES-CFB8 or RC4, as NetrServerPasswordSet2 sends it.
+func NewNLEncryptedTrustPassword(password string, version *NLPasswordVersion, encrypt func(b []byte) error) (NLTrustPassword, error) {
+	p, err := NewNLTrustPassword(password, version)
+	if err != nil {
+		return NLTrustPassword{}, err
+	}
+	b, _ := p.MarshalBinary()
+	err = encrypt(b)
+	if err != nil {
+		return NLTrustPassword{}, err
+	}
+	return p, p.UnmarshalBinary(b)
+}
+
+// Password returns the UTF-16LE bytes of the password held by the decrypted NL_TRUST_PASSWORD. Machine passwords are
+// random and not always valid UTF-16, so the NT hash is best computed from these bytes.
+func (p *NLTrustPassword) Password() ([]byte, error) {
+	if p.Length > NLTrustPasswordBufferSize || p.Length%SizeUint16 != 0 {
+		return nil, fmt.Errorf("invalid NL_TRUST_PASSWORD length %d", p.Length)
+	}
+	return append([]byte(nil), p.Buffer[NLTrustPasswordBufferSize-int(p.Length):]...), nil
+}
+
+// String returns the password held by the decrypted NL_TRUST_PASSWORD, with invalid UTF-16 replaced by U+FFFD, or
+// the empty string if the Length is invalid.
+func (p *NLTrustPassword) String() string {
+	b, err := p.Password()
+	if err != nil {
+		return ""
+	}
+	u := make([]uint16, len(b)/SizeUint16)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*SizeUint16:])
+	}
+	s, _ := decodeUTF16(u, false)
+	return s
+}
+
+// Version returns the NL_PASSWORD_VERSION preceding the password in the decrypted NL_TRUST_PASSWORD, and whether
+// there is one.
+func (p *NLTrustPassword) Version() (NLPasswordVersion, bool) {
+	if p.Length > NLTrustPasswordBufferSize-NLPasswordVersionSize || p.Length%SizeUint16 != 0 {
+		return NLPasswordVersion{}, false
+	}
+	b := p.Buffer[NLTrustPasswordBufferSize-int(p.Length)-NLPasswordVersionSize:]
+	v := NLPasswordVersion{
+		ReservedField:          binary.LittleEndian.Uint32(b),
+		PasswordVersionNumber:  binary.LittleEndian.Uint32(b[SizeUint32:]),
+		PasswordVersionPresent: binary.LittleEndian.Uint32(b[2*SizeUint32:]),
+	}
+	return v, v.PasswordVersionPresent == NLPasswordVersionPresent
+}
+
+// MarshalBinary returns the NL_TRUST_PASSWORD as the 516 bytes encrypted by NetrServerPasswordSet2: the buffer
+// followed by the little-endian length.
+func (p *NLTrustPassword) MarshalBinary() ([]byte, error) {
+	b := make([]byte, NLTrustPasswordSize)
+	copy(b, p.Buffer[:])
+	binary.LittleEndian.PutUint32(b[NLTrustPasswordBufferSize:], p.Length)
+	return b, nil
+}
+
+// UnmarshalBinary sets the NL_TRUST_PASSWORD from the 516 bytes returned by MarshalBinary.
+func (p *NLTrustPassword) UnmarshalBinary(b []byte) error {
+	if len(b) != NLTrustPasswordSize {
+		return fmt.Errorf("NL_TRUST_PASSWORD encoding is %d bytes, not %d", len(b), NLTrustPasswordSize)
+	}
+	copy(p.Buffer[:], b)
+	p.Length = binary.LittleEndian.Uint32(b[NLTrustPasswordBufferSize:])
+	return nil
+}
@@ -0,0 +1,152 @@
+// Code generated by ndrgen; DO NOT EDIT.
+
+package mstypes
+
+import (
+	"io"
+)
+
+// FromReader reads the NDR representation of NLTrustPassword from r.
+func (s *NLTrustPassword) FromReader(r *Reader) (err error) {
+	err = r.Align(4)
+	if err != nil {
+		return
+	}
+	err = r.Field("Buffer", func() (err error) {
+		{
+			b, err := r.ReadBytes(len(s.Buffer))
+			if err != nil {
+				return err
+			}
+			copy(s.Buffer[:], b)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("Length", func() (err error) {
+		err = r.Align(4)
+		if err != nil {
+			return
+		}
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.Length = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of NLTrustPassword to w.
+func (s *NLTrustPassword) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(4)
+	if err != nil {
+		return
+	}
+	err = nw.WriteBytes(s.Buffer[:])
+	if err != nil {
+		return
+	}
+	err = nw.Align(4)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.Length)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of NLTrustPassword.
+func (s *NLTrustPassword) Size() int {
+	n := 0
+	n += len(s.Buffer) * 1
+	n += (4 - n%4) % 4
+	n += 4
+	return n
+}
+
+// FromReader reads the NDR representation of NLPasswordVersion from r.
+func (s *NLPasswordVersion) FromReader(r *Reader) (err error) {
+	err = r.Align(4)
+	if err != nil {
+		return
+	}
+	err = r.Field("ReservedField", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.ReservedField = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("PasswordVersionNumber", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.PasswordVersionNumber = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("PasswordVersionPresent", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.PasswordVersionPresent = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of NLPasswordVersion to w.
+func (s *NLPasswordVersion) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(4)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.ReservedField)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.PasswordVersionNumber)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.PasswordVersionPresent)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of NLPasswordVersion.
+func (s *NLPasswordVersion) Size() int {
+	return 12
+}
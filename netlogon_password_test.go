@@ -0,0 +1,95 @@
+package mstypes
+
+import (
+	"crypto/rc4"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewNLTrustPassword(t *testing.T) {
+	p, err := NewNLTrustPassword("Pässw0rd", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(16), p.Length, "length not as expected")
+	assert.Equal(t, "Pässw0rd", p.String(), "password not as expected")
+	b, err := p.Password()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{'P', 0, 0xe4, 0, 's', 0, 's', 0, 'w', 0, '0', 0, 'r', 0, 'd', 0}, b,
+		"password bytes not as expected")
+
+	v := NewNLPasswordVersion(3)
+	p, err = NewNLTrustPassword("Pässw0rd", &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "00000000"+"03000000"+"68192302", hex.EncodeToString(p.Buffer[484:496]),
+		"password version encoding not as expected")
+	got, ok := p.Version()
+	assert.True(t, ok, "password version not found")
+	assert.Equal(t, v, got, "password version not as expected")
+	assert.Equal(t, "Pässw0rd", p.String(), "password not as expected")
+
+	_, err = NewNLTrustPassword(strings.Repeat("a", SAMMaxPasswordLength), nil)
+	assert.NoError(t, err, "password of the maximum length rejected")
+	_, err = NewNLTrustPassword(strings.Repeat("a", SAMMaxPasswordLength-6), &v)
+	assert.NoError(t, err, "password of the maximum length with a version rejected")
+	_, err = NewNLTrustPassword(strings.Repeat("a", SAMMaxPasswordLength-5), &v)
+	assert.True(t, errors.Is(err, ErrStringTooLong), "password too long for a version not rejected: %v", err)
+
+	p.Length = 513
+	_, err = p.Password()
+	assert.Error(t, err, "invalid length not rejected")
+	assert.Equal(t, "", p.String(), "password of an invalid length not empty")
+	_, ok = p.Version()
+	assert.False(t, ok, "password version of an invalid length found")
+}
+
+func Test_NLTrustPasswordEncoding(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	encrypt := func(b []byte) error {
+		c, err := rc4.NewCipher(key)
+		if err != nil {
+			return err
+		}
+		c.XORKeyStream(b, b)
+		return nil
+	}
+	p, err := NewNLEncryptedTrustPassword("secret", nil, encrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := encodeTypeSerialized(t, &p)
+	var d NLTrustPassword
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, p, d, "decoded password not as expected")
+
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, b, NLTrustPasswordSize, "encoding size not as expected")
+	err = encrypt(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(12), binary.LittleEndian.Uint32(b[NLTrustPasswordBufferSize:]), "length not as expected")
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "secret", d.String(), "decrypted password not as expected")
+	_, ok := d.Version()
+	assert.False(t, ok, "password version found")
+
+	assert.Error(t, d.UnmarshalBinary(b[:NLTrustPasswordBufferSize]), "short encoding not rejected")
+	_, err = NewNLEncryptedTrustPassword("secret", nil, func([]byte) error { return errors.New("no session key") })
+	assert.Error(t, err, "encryption error not returned")
+}
@@ -0,0 +1,203 @@
+package mstypes
+
+import (
+	"io"
+)
+
+// NetlogonValidationSAMInfo2 implements NETLOGON_VALIDATION_SAM_INFO2 of MS-NRPC, the validation information
+// NetrLogonSamLogon returns for the NetlogonValidationSamInfo2 level. It starts with the ValidationInfo of
+// KerbValidationInfo; the ExtraSIDs are NETLOGON_SID_AND_ATTRIBUTES, which have the layout of KerbSidAndAttributes.
+type NetlogonValidationSAMInfo2 struct {
+	ValidationInfo
+	ExpansionRoom [10]uint32 // Holds the fields of KerbValidationInfo from Reserved1 to Reserved3.
+	SIDCount      uint32
+	ExtraSIDs     []KerbSidAndAttributes // A pointer to SIDCount NETLOGON_SID_AND_ATTRIBUTES, nil if the pointer is null.
+}
+
+// FromReader reads the NetlogonValidationSAMInfo2 from r. The referents of its pointers are read when r.Deferred is
+// called. GroupCount and SIDCount must match the number of elements of their arrays, 0 for a null pointer.
+func (v *NetlogonValidationSAMInfo2) FromReader(r *Reader) (err error) {
+	err = v.ValidationInfo.fromReader(r, readGroupMembershipsPointer)
+	if err != nil {
+		return
+	}
+	for i := range v.ExpansionRoom {
+		v.ExpansionRoom[i], err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	return readExtraSIDs(r, &v.SIDCount, &v.ExtraSIDs)
+}
+
+// ToWriter writes the NetlogonValidationSAMInfo2 to w. Nil slices and SIDs are written as null pointers. An error is
+// returned if GroupCount or SIDCount does not match the number of elements of its array.
+func (v *NetlogonValidationSAMInfo2) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = v.ValidationInfo.toWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, e := range v.ExpansionRoom {
+		err = nw.Uint32(e)
+		if err != nil {
+			return
+		}
+	}
+	err = writeExtraSIDs(nw, v.SIDCount, v.ExtraSIDs)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of NetlogonValidationSAMInfo2.
+func (v *NetlogonValidationSAMInfo2) Size() int {
+	return ndrSize(v)
+}
+
+// NetlogonValidationSAMInfo4 implements NETLOGON_VALIDATION_SAM_INFO4 of MS-NRPC, the validation information
+// NetrLogonSamLogonEx and NetrLogonSamLogonWithFlags return for the NetlogonValidationSamInfo4 level. Its fields up to
+// ExtraSIDs are those of KerbValidationInfo with the LMKey in place of Reserved1; the resource groups are replaced by
+// the DNS domain name, the UPN and unused strings.
+type NetlogonValidationSAMInfo4 struct {
+	ValidationInfo
+	LMKey                [8]byte          // The first 8 bytes of the LM hash of the password.
+	UserAccountControl   UserAccountFlags // The USER_ACCOUNT codes, not the UF_ flags of Active Directory.
+	SubAuthStatus        uint32
+	LastSuccessfulILogon FileTime
+	LastFailedILogon     FileTime
+	FailedILogonCount    uint32
+	Reserved4            uint32
+	SIDCount             uint32
+	ExtraSIDs            []KerbSidAndAttributes // A pointer to SIDCount NETLOGON_SID_AND_ATTRIBUTES, nil if the pointer is null.
+	DNSLogonDomainName   RPCUnicodeString
+	UPN                  RPCUnicodeString
+	ExpansionStrings     [10]RPCUnicodeString
+}
+
+// FromReader reads the NetlogonValidationSAMInfo4 from r. The referents of its pointers are read when r.Deferred is
+// called. GroupCount and SIDCount must match the number of elements of their arrays, 0 for a null pointer.
+func (v *NetlogonValidationSAMInfo4) FromReader(r *Reader) (err error) {
+	err = v.ValidationInfo.fromReader(r, readGroupMembershipsPointer)
+	if err != nil {
+		return
+	}
+	b, err := r.ReadBytes(len(v.LMKey))
+	if err != nil {
+		return
+	}
+	copy(v.LMKey[:], b)
+	for _, u := range []*uint32{(*uint32)(&v.UserAccountControl), &v.SubAuthStatus} {
+		*u, err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	err = r.Field("LastSuccessfulILogon", func() error {
+		return v.LastSuccessfulILogon.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("LastFailedILogon", func() error {
+		return v.LastFailedILogon.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	for _, u := range []*uint32{&v.FailedILogonCount, &v.Reserved4} {
+		*u, err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	err = readExtraSIDs(r, &v.SIDCount, &v.ExtraSIDs)
+	if err != nil {
+		return
+	}
+	for _, s := range []struct {
+		name string
+		s    *RPCUnicodeString
+	}{
+		{"DNSLogonDomainName", &v.DNSLogonDomainName},
+		{"UPN", &v.UPN},
+	} {
+		err = r.Field(s.name, func() error {
+			return s.s.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = r.Field("ExpansionStrings", func() (err error) {
+		for i := range v.ExpansionStrings {
+			err = r.Element(i, func() error {
+				return v.ExpansionStrings[i].FromReader(r)
+			})
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// ToWriter writes the NetlogonValidationSAMInfo4 to w. Nil slices and SIDs are written as null pointers. An error is
+// returned if GroupCount or SIDCount does not match the number of elements of its array.
+func (v *NetlogonValidationSAMInfo4) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = v.ValidationInfo.toWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.WriteBytes(v.LMKey[:])
+	if err != nil {
+		return
+	}
+	for _, u := range []uint32{uint32(v.UserAccountControl), v.SubAuthStatus} {
+		err = nw.Uint32(u)
+		if err != nil {
+			return
+		}
+	}
+	err = v.LastSuccessfulILogon.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = v.LastFailedILogon.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, u := range []uint32{v.FailedILogonCount, v.Reserved4} {
+		err = nw.Uint32(u)
+		if err != nil {
+			return
+		}
+	}
+	err = writeExtraSIDs(nw, v.SIDCount, v.ExtraSIDs)
+	if err != nil {
+		return
+	}
+	err = v.DNSLogonDomainName.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = v.UPN.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	for i := range v.ExpansionStrings {
+		err = v.ExpansionStrings[i].ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of NetlogonValidationSAMInfo4.
+func (v *NetlogonValidationSAMInfo4) Size() int {
+	return ndrSize(v)
+}
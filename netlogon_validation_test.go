@@ -0,0 +1,108 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testValidationKerbInfo(t *testing.T) KerbValidationInfo {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.ResourceGroupDomainSID, k.ResourceGroupCount, k.ResourceGroupIDs = nil, 0, nil
+	return k
+}
+
+func Test_NetlogonValidationSAMInfo4(t *testing.T) {
+	k := testValidationKerbInfo(t)
+	v := NetlogonValidationSAMInfo4{
+		ValidationInfo:       k.ValidationInfo,
+		UserAccountControl:   k.UserAccountControl,
+		LastSuccessfulILogon: k.LastSuccessfulILogon,
+		SIDCount:             k.SIDCount,
+		ExtraSIDs:            k.ExtraSIDs,
+	}
+	v.DNSLogonDomainName, _ = NewRPCUnicodeString("test.example")
+	v.UPN, _ = NewRPCUnicodeString("testuser1@test.example")
+	e := encodeTypeSerialized(t, &v)
+	var d NetlogonValidationSAMInfo4
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, v, d, "decoded validation information not as expected")
+
+	// Up to the pointer to the ExtraSids the fixed parts match those of KERB_VALIDATION_INFO.
+	ke := encodeTypeSerialized(t, &k)
+	assert.Equal(t, hex.EncodeToString(ke[20:20+204]), hex.EncodeToString(e[20:20+204]),
+		"fixed part not as expected")
+
+	sid, err := d.UserSID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", sid.String(), "user SID not as expected")
+	_, err = new(NetlogonValidationSAMInfo4).UserSID()
+	assert.Error(t, err, "user SID without logon domain ID not rejected")
+}
+
+func Test_NetlogonValidationSAMInfo2(t *testing.T) {
+	k := testValidationKerbInfo(t)
+	v := NetlogonValidationSAMInfo2{
+		ValidationInfo: k.ValidationInfo,
+		ExpansionRoom:  [10]uint32{2: uint32(k.UserAccountControl)},
+		SIDCount:       k.SIDCount,
+		ExtraSIDs:      k.ExtraSIDs,
+	}
+	var d NetlogonValidationSAMInfo2
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &v), &d)
+	assert.Equal(t, v, d, "decoded validation information not as expected")
+	sid, err := d.UserSID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", sid.String(), "user SID not as expected")
+
+	v.SIDCount, v.ExtraSIDs, v.GroupCount, v.GroupIDs, v.LogonDomainID = 0, nil, 0, nil, nil
+	d = NetlogonValidationSAMInfo2{}
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &v), &d)
+	assert.Equal(t, v, d, "decoded validation information with null pointers not as expected")
+}
+
+func Test_NetlogonValidationCountMismatch(t *testing.T) {
+	k := testValidationKerbInfo(t)
+	v2 := NetlogonValidationSAMInfo2{ValidationInfo: k.ValidationInfo, SIDCount: k.SIDCount, ExtraSIDs: k.ExtraSIDs}
+	v4 := NetlogonValidationSAMInfo4{ValidationInfo: k.ValidationInfo, SIDCount: k.SIDCount, ExtraSIDs: k.ExtraSIDs}
+	var tests = []struct {
+		v        NDRType
+		d        NDRType
+		sidCount *uint32
+	}{
+		{&v2, &NetlogonValidationSAMInfo2{}, &v2.SIDCount},
+		{&v4, &NetlogonValidationSAMInfo4{}, &v4.SIDCount},
+	}
+	for _, test := range tests {
+		// GroupCount at offset 128 and SIDCount at offset 216, as in KERB_VALIDATION_INFO
+		for _, c := range []struct {
+			name   string
+			offset int
+		}{
+			{"GroupCount", 128},
+			{"SIDCount", 216},
+		} {
+			e := encodeTypeSerialized(t, test.v)
+			e[c.offset]++
+			err := UnmarshalTypeSerialized(e, test.d)
+			assert.ErrorContains(t, err, c.name, "expected error decoding %T with %s not matching", test.d, c.name)
+		}
+		*test.sidCount++
+		_, err := MarshalTypeSerialized(test.v)
+		assert.ErrorContains(t, err, "SIDCount", "expected error encoding %T with SIDCount not matching", test.v)
+	}
+	v2.SIDCount--
+	v2.GroupCount++
+	_, err := MarshalTypeSerialized(&v2)
+	assert.ErrorContains(t, err, "GroupCount", "expected error encoding GroupCount not matching")
+}
@@ -0,0 +1,71 @@
+package mstypes
+
+import "fmt"
+
+// Flags of an object ACE indicating which of its GUIDs are present
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-dtyp/c79a383c-2b3f-4655-abe7-dcbb7ce0cfbe
+const (
+	ACEObjectTypePresent          uint32 = 0x1
+	ACEInheritedObjectTypePresent uint32 = 0x2
+)
+
+// ObjectACEGUIDs are the GUIDs of an object ACE, such as ACCESS_ALLOWED_OBJECT_ACE, that follow its Flags. A nil GUID
+// is absent from the ACE.
+type ObjectACEGUIDs struct {
+	ObjectType          *GUID // The property set, property, extended right or child object class the ACE applies to.
+	InheritedObjectType *GUID // The class of the child objects that inherit the ACE.
+}
+
+// Flags returns the object ACE Flags indicating the GUIDs present.
+func (o *ObjectACEGUIDs) Flags() (flags uint32) {
+	if o.ObjectType != nil {
+		flags |= ACEObjectTypePresent
+	}
+	if o.InheritedObjectType != nil {
+		flags |= ACEInheritedObjectTypePresent
+	}
+	return
+}
+
+// ObjectACEGUIDs reads the Flags of an object ACE followed by the GUIDs present according to them, as written by
+// Writer.ObjectACEGUIDs. The GUIDs are not aligned.
+func (r *Reader) ObjectACEGUIDs() (o ObjectACEGUIDs, err error) {
+	flags, err := r.Uint32()
+	if err != nil {
+		return
+	}
+	if flags&^(ACEObjectTypePresent|ACEInheritedObjectTypePresent) != 0 {
+		err = fmt.Errorf("object ACE flags 0x%x hold undefined bits", flags)
+		return
+	}
+	if flags&ACEObjectTypePresent != 0 {
+		o.ObjectType = new(GUID)
+		*o.ObjectType, err = r.GUID()
+		if err != nil {
+			return
+		}
+	}
+	if flags&ACEInheritedObjectTypePresent != 0 {
+		o.InheritedObjectType = new(GUID)
+		*o.InheritedObjectType, err = r.GUID()
+	}
+	return
+}
+
+// ObjectACEGUIDs writes the Flags of an object ACE followed by the GUIDs present.
+func (w *Writer) ObjectACEGUIDs(o ObjectACEGUIDs) error {
+	err := w.Uint32(o.Flags())
+	if err != nil {
+		return err
+	}
+	for _, g := range []*GUID{o.ObjectType, o.InheritedObjectType} {
+		if g == nil {
+			continue
+		}
+		err = w.GUID(*g)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
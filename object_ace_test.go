@@ -0,0 +1,43 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectACEGUIDs(t *testing.T) {
+	user := mustParseGUID("bf967aba-0de6-11d0-a285-00aa003049e2")
+	var tests = []struct {
+		GUIDs ObjectACEGUIDs
+		Hex   string
+	}{
+		{ObjectACEGUIDs{}, "00000000"},
+		{ObjectACEGUIDs{ObjectType: &GUIDSelfMembership}, "01000000" + "c07996bfe60dd011a28500aa003049e2"},
+		{ObjectACEGUIDs{InheritedObjectType: &user}, "02000000" + "ba7a96bfe60dd011a28500aa003049e2"},
+		{ObjectACEGUIDs{ObjectType: &GUIDSelfMembership, InheritedObjectType: &user},
+			"03000000" + "c07996bfe60dd011a28500aa003049e2" + "ba7a96bfe60dd011a28500aa003049e2"},
+	}
+	for _, test := range tests {
+		w := NewAppendWriter(nil)
+		assert.NoError(t, w.ObjectACEGUIDs(test.GUIDs))
+		assert.Equal(t, test.Hex, hex.EncodeToString(w.Bytes()))
+
+		r := NewReader(bytes.NewReader(w.Bytes()))
+		got, err := r.ObjectACEGUIDs()
+		assert.NoError(t, err)
+		assert.Equal(t, test.GUIDs, got)
+	}
+
+	b, _ := hex.DecodeString("04000000" + strings.Repeat("00", 32))
+	r := NewReader(bytes.NewReader(b))
+	_, err := r.ObjectACEGUIDs()
+	assert.Error(t, err, "undefined flags not detected")
+	b, _ = hex.DecodeString("03000000" + strings.Repeat("00", 20))
+	r = NewReader(bytes.NewReader(b))
+	_, err = r.ObjectACEGUIDs()
+	assert.Error(t, err, "missing GUID not detected")
+}
@@ -0,0 +1,86 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Option configures the encoding used by a Reader or Writer.
+type Option func(*options)
+
+// defaultBufferSize is the size of the buffer used by a Reader when no buffer size option is given.
+const defaultBufferSize = 4096
+
+type options struct {
+	order            binary.ByteOrder // byte order of integers
+	packed           bool             // no alignment padding is used between values
+	conformanceAlign int              // alignment of conformance and variance counts
+	bufferSize       int              // size of the buffer used when reading from a stream
+	maxElements      uint32           // maximum element count of a single array
+	maxAlloc         int              // maximum total bytes allocated for arrays while decoding
+	lazyArrays       bool             // the decoding of LazyArray elements is deferred until they are accessed
+	strictUTF16      bool             // strings holding unpaired surrogates are rejected rather than repaired
+}
+
+func defaultOptions() options {
+	return options{
+		order:            binary.LittleEndian,
+		conformanceAlign: SizeUint32,
+		bufferSize:       defaultBufferSize,
+		maxElements:      DefaultMaxElements,
+		maxAlloc:         DefaultMaxAlloc,
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPacked disables alignment padding so every value directly follows the previous one. Some Microsoft blobs are
+// laid out this way rather than with the natural alignment NDR requires.
+func WithPacked() Option {
+	return func(o *options) {
+		o.packed = true
+	}
+}
+
+// WithConformanceAlignment sets the alignment of conformance and variance counts. NDR aligns these counts to 4 bytes
+// which is the default; some blobs align them to 8 bytes instead. It panics if n is not 4 or 8.
+func WithConformanceAlignment(n int) Option {
+	if n != SizeUint32 && n != SizeUint64 {
+		panic(fmt.Sprintf("conformance alignment %d is not %d or %d", n, SizeUint32, SizeUint64))
+	}
+	return func(o *options) {
+		o.conformanceAlign = n
+	}
+}
+
+// WithBufferSize sets the size of the internal buffer a Reader uses when consuming its stream.
+func WithBufferSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.bufferSize = n
+		}
+	}
+}
+
+// WithByteOrder sets the byte order of integers. NDR data is little-endian by default but the data representation of
+// a stream may indicate big-endian integers.
+func WithByteOrder(order binary.ByteOrder) Option {
+	return func(o *options) {
+		if order != nil {
+			o.order = order
+		}
+	}
+}
+
+// WithLazyArrays makes a Reader keep the encoded elements of a LazyArray and decode them only when they are accessed.
+func WithLazyArrays() Option {
+	return func(o *options) {
+		o.lazyArrays = true
+	}
+}
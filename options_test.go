@@ -0,0 +1,65 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AlignmentOptions(t *testing.T) {
+	var tests = []struct {
+		Opts []Option
+		Hex  string
+	}{
+		{nil, "01000000" + "03000000" + "0200" + "000000000000" + "0400000000000000"},
+		{[]Option{WithPacked()}, "01" + "03000000" + "0200" + "0400000000000000"},
+		{[]Option{WithConformanceAlignment(8)}, "0100000000000000" + "03000000" + "0200" + "0000" + "0400000000000000"},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, test.Opts...)
+		assert.NoError(t, w.Uint8(1))
+		assert.NoError(t, w.Conformance(3))
+		assert.NoError(t, w.Align(2))
+		assert.NoError(t, w.Uint16(2))
+		assert.NoError(t, w.Align(8))
+		assert.NoError(t, w.Uint64(4))
+		assert.Equal(t, test.Hex, hex.EncodeToString(buf.Bytes()), "encoding not as expected for test %d", i+1)
+
+		r := NewReader(bytes.NewReader(buf.Bytes()), test.Opts...)
+		u8, _ := r.Uint8()
+		max, err := r.Conformance()
+		assert.NoError(t, err)
+		assert.NoError(t, r.Align(2))
+		u16, _ := r.Uint16()
+		assert.NoError(t, r.Align(8))
+		u64, err := r.Uint64()
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 3, 2, 4}, []uint64{uint64(u8), uint64(max), uint64(u16), u64}, "decoding not as expected for test %d", i+1)
+	}
+}
+
+func Test_ConformanceAlignmentInvalid(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 16} {
+		assert.Panics(t, func() { WithConformanceAlignment(n) }, "conformance alignment %d not rejected", n)
+	}
+}
+
+func Test_BigEndian(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithByteOrder(binary.BigEndian))
+	assert.NoError(t, w.Uint16(0x0102))
+	assert.NoError(t, w.Conformance(3))
+	assert.NoError(t, w.Uint64(0x0102030405060708))
+	assert.Equal(t, "0102"+"0000"+"00000003"+"0102030405060708", hex.EncodeToString(buf.Bytes()))
+
+	r := NewReader(bytes.NewReader(buf.Bytes()), WithByteOrder(binary.BigEndian))
+	u16, _ := r.Uint16()
+	max, _ := r.Conformance()
+	u64, err := r.Uint64()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x0102, 3, 0x0102030405060708}, []uint64{uint64(u16), uint64(max), u64})
+}
@@ -0,0 +1,262 @@
+package mstypes
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+/*
+The Privilege Attribute Certificate carries the authorization data of a Kerberos ticket.
+Ref: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/
+The PACTYPE header is followed by a directory of PAC_INFO_BUFFER entries locating each buffer in the PAC. The PAC is
+a flat little-endian structure; some of its buffers hold type serialized NDR data.
+*/
+
+// PAC buffer types, the ulType of a PAC_INFO_BUFFER
+const (
+	PACBufferTypeLogonInfo         uint32 = 1  // KERB_VALIDATION_INFO
+	PACBufferTypeCredentials       uint32 = 2  // PAC_CREDENTIAL_INFO
+	PACBufferTypeServerChecksum    uint32 = 6  // PAC_SIGNATURE_DATA
+	PACBufferTypeKDCChecksum       uint32 = 7  // PAC_SIGNATURE_DATA
+	PACBufferTypeClientInfo        uint32 = 10 // PAC_CLIENT_INFO
+	PACBufferTypeS4UDelegationInfo uint32 = 11 // S4U_DELEGATION_INFO
+	PACBufferTypeUPNDNSInfo        uint32 = 12 // UPN_DNS_INFO
+	PACBufferTypeClientClaims      uint32 = 13 // PAC_CLIENT_CLAIMS_INFO
+	PACBufferTypeDeviceInfo        uint32 = 14 // PAC_DEVICE_INFO
+	PACBufferTypeDeviceClaims      uint32 = 15 // PAC_DEVICE_CLAIMS_INFO
+	PACBufferTypeTicketChecksum    uint32 = 16 // PAC_SIGNATURE_DATA
+	PACBufferTypeAttributes        uint32 = 17 // PAC_ATTRIBUTES_INFO
+	PACBufferTypeRequestor         uint32 = 18 // PAC_REQUESTOR
+	PACBufferTypeFullChecksum      uint32 = 19 // PAC_SIGNATURE_DATA, the extended KDC checksum
+)
+
+// PAC layout
+const (
+	PACVersion        = 0
+	pacHeaderSize     = 2 * SizeUint32
+	pacInfoBufferSize = 2*SizeUint32 + SizeUint64
+	pacBufferAlign    = 8
+)
+
+// PACType implements the PACTYPE structure https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/6655b92f-ab06-490b-845d-037e6987275f
+type PACType struct {
+	CBuffers uint32 // The number of entries in Buffers.
+	Version  uint32 // MUST be 0.
+	Buffers  []PACInfoBuffer
+}
+
+// PACInfoBuffer implements the PAC_INFO_BUFFER structure locating a buffer in the PAC, together with the data of the
+// buffer.
+type PACInfoBuffer struct {
+	ULType       uint32 // The type of the buffer, one of the PACBufferType constants.
+	CBBufferSize uint32 // The size, in bytes, of the buffer.
+	Offset       uint64 // The offset, in bytes, of the buffer from the start of the PACTYPE.
+	Data         []byte // The CBBufferSize bytes of the buffer.
+}
+
+// Errors of the PAC directory, wrapped by a PACBufferError
+var (
+	ErrPACBufferBounds    = errors.New("PAC buffer out of bounds")
+	ErrPACBufferOverlap   = errors.New("PAC buffers overlap")
+	ErrPACDuplicateBuffer = errors.New("duplicate PAC buffer type")
+)
+
+// PACBufferError locates an invalid entry of the PAC directory.
+type PACBufferError struct {
+	Index  int    // index of the entry in the directory
+	ULType uint32 // type of the buffer
+	Offset uint64 // offset of the buffer
+	Size   uint32 // size of the buffer
+	Err    error  // ErrPACBufferBounds, ErrPACBufferOverlap or ErrPACDuplicateBuffer
+	Detail string // description of the problem
+}
+
+func (e *PACBufferError) Error() string {
+	return fmt.Sprintf("PAC buffer %d of type %d at offset %d with size %d: %v: %s", e.Index, e.ULType, e.Offset,
+		e.Size, e.Err, e.Detail)
+}
+
+func (e *PACBufferError) Unwrap() error {
+	return e.Err
+}
+
+// newPACBufferError returns the PACBufferError of the entry i locating buf.
+func newPACBufferError(i int, buf PACInfoBuffer, err error, format string, a ...interface{}) *PACBufferError {
+	return &PACBufferError{
+		Index:  i,
+		ULType: buf.ULType,
+		Offset: buf.Offset,
+		Size:   buf.CBBufferSize,
+		Err:    err,
+		Detail: fmt.Sprintf(format, a...),
+	}
+}
+
+// PACBuffer is implemented by the typed PAC buffers so they can be decoded from and stored in a PACType.
+type PACBuffer interface {
+	PACBufferType() uint32
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(b []byte) error
+}
+
+// UnmarshalBinary parses the PAC b. The buffers are checked to lie within b after the directory, not to overlap and to
+// be of distinct types, and their data is copied. An invalid directory entry is returned as a PACBufferError.
+func (p *PACType) UnmarshalBinary(b []byte) error {
+	if len(b) < pacHeaderSize {
+		return fmt.Errorf("PAC of %d bytes is shorter than its header", len(b))
+	}
+	p.CBuffers = binary.LittleEndian.Uint32(b)
+	p.Version = binary.LittleEndian.Uint32(b[SizeUint32:])
+	if p.Version != PACVersion {
+		return fmt.Errorf("PAC version %d is not %d", p.Version, PACVersion)
+	}
+	if uint64(p.CBuffers) > uint64(len(b)-pacHeaderSize)/pacInfoBufferSize {
+		return fmt.Errorf("PAC buffer count %d exceeds the %d bytes of the PAC", p.CBuffers, len(b))
+	}
+	p.Buffers = make([]PACInfoBuffer, p.CBuffers)
+	for i := range p.Buffers {
+		e := b[pacHeaderSize+i*pacInfoBufferSize:]
+		buf := &p.Buffers[i]
+		buf.ULType = binary.LittleEndian.Uint32(e)
+		buf.CBBufferSize = binary.LittleEndian.Uint32(e[SizeUint32:])
+		buf.Offset = binary.LittleEndian.Uint64(e[2*SizeUint32:])
+	}
+	err := p.validate(uint64(len(b)))
+	if err != nil {
+		return err
+	}
+	for i := range p.Buffers {
+		buf := &p.Buffers[i]
+		buf.Data = append([]byte(nil), b[buf.Offset:buf.Offset+uint64(buf.CBBufferSize)]...)
+	}
+	return nil
+}
+
+// validate checks the buffers of the directory lie within the size bytes of the PAC after the directory, do not
+// overlap and are of distinct types.
+func (p *PACType) validate(size uint64) error {
+	start := uint64(pacHeaderSize + len(p.Buffers)*pacInfoBufferSize)
+	order := make([]int, 0, len(p.Buffers))
+	types := make(map[uint32]int, len(p.Buffers))
+	for i, buf := range p.Buffers {
+		if j, ok := types[buf.ULType]; ok {
+			return newPACBufferError(i, buf, ErrPACDuplicateBuffer, "buffer %d has the same type", j)
+		}
+		types[buf.ULType] = i
+		if buf.Offset > size || uint64(buf.CBBufferSize) > size-buf.Offset {
+			return newPACBufferError(i, buf, ErrPACBufferBounds, "exceeds the %d bytes of the PAC", size)
+		}
+		if buf.CBBufferSize == 0 {
+			continue
+		}
+		if buf.Offset < start {
+			return newPACBufferError(i, buf, ErrPACBufferOverlap, "overlaps the %d bytes of the PAC header and directory", start)
+		}
+		order = append(order, i)
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(p.Buffers[i].Offset, p.Buffers[j].Offset)
+	})
+	for k := 1; k < len(order); k++ {
+		prev, buf := p.Buffers[order[k-1]], p.Buffers[order[k]]
+		if prev.Offset+uint64(prev.CBBufferSize) > buf.Offset {
+			return newPACBufferError(order[k], buf, ErrPACBufferOverlap, "overlaps buffer %d", order[k-1])
+		}
+	}
+	return nil
+}
+
+// Buffer returns the first buffer of the type ulType or nil if the PAC has none.
+func (p *PACType) Buffer(ulType uint32) *PACInfoBuffer {
+	for i := range p.Buffers {
+		if p.Buffers[i].ULType == ulType {
+			return &p.Buffers[i]
+		}
+	}
+	return nil
+}
+
+// Decode decodes the first buffer of the type of v into v. It returns false if the PAC has no such buffer.
+func (p *PACType) Decode(v PACBuffer) (bool, error) {
+	buf := p.Buffer(v.PACBufferType())
+	if buf == nil {
+		return false, nil
+	}
+	err := v.UnmarshalBinary(buf.Data)
+	if err != nil {
+		return true, fmt.Errorf("PAC buffer of type %d: %w", buf.ULType, err)
+	}
+	return true, nil
+}
+
+// SetBuffer encodes v and stores it as the data of the first buffer of its type, adding a buffer if the PAC has none.
+func (p *PACType) SetBuffer(v PACBuffer) error {
+	b, err := v.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("PAC buffer of type %d: %w", v.PACBufferType(), err)
+	}
+	p.SetBufferData(v.PACBufferType(), b)
+	return nil
+}
+
+// SetBufferData stores b as the data of the first buffer of the type ulType, adding a buffer if the PAC has none.
+func (p *PACType) SetBufferData(ulType uint32, b []byte) {
+	buf := p.Buffer(ulType)
+	if buf == nil {
+		p.Buffers = append(p.Buffers, PACInfoBuffer{ULType: ulType})
+		buf = &p.Buffers[len(p.Buffers)-1]
+	}
+	buf.Data = b
+	buf.CBBufferSize = uint32(len(b))
+}
+
+// Layout sets CBuffers and the CBBufferSize and Offset of each buffer to the layout MarshalBinary writes: the buffers
+// follow the directory in order, each starting on a multiple of 8 bytes.
+func (p *PACType) Layout() error {
+	p.CBuffers = uint32(len(p.Buffers))
+	offset := alignUp(uint64(pacHeaderSize+len(p.Buffers)*pacInfoBufferSize), pacBufferAlign)
+	for i := range p.Buffers {
+		buf := &p.Buffers[i]
+		if uint64(len(buf.Data)) > math.MaxUint32 {
+			return fmt.Errorf("PAC buffer %d of type %d is too large: %d bytes", i, buf.ULType, len(buf.Data))
+		}
+		buf.CBBufferSize = uint32(len(buf.Data))
+		buf.Offset = offset
+		offset = alignUp(offset+uint64(buf.CBBufferSize), pacBufferAlign)
+	}
+	return nil
+}
+
+// MarshalBinary returns the PAC with its buffers laid out as Layout does, each zero padded to a multiple of 8 bytes.
+// The CBuffers and the CBBufferSize and Offset of each buffer are updated to the layout written.
+func (p *PACType) MarshalBinary() ([]byte, error) {
+	err := p.Layout()
+	if err != nil {
+		return nil, err
+	}
+	size := alignUp(uint64(pacHeaderSize+len(p.Buffers)*pacInfoBufferSize), pacBufferAlign)
+	if n := len(p.Buffers); n > 0 {
+		last := p.Buffers[n-1]
+		size = alignUp(last.Offset+uint64(last.CBBufferSize), pacBufferAlign)
+	}
+	b := make([]byte, size)
+	binary.LittleEndian.PutUint32(b, p.CBuffers)
+	binary.LittleEndian.PutUint32(b[SizeUint32:], p.Version)
+	for i, buf := range p.Buffers {
+		e := b[pacHeaderSize+i*pacInfoBufferSize:]
+		binary.LittleEndian.PutUint32(e, buf.ULType)
+		binary.LittleEndian.PutUint32(e[SizeUint32:], buf.CBBufferSize)
+		binary.LittleEndian.PutUint64(e[2*SizeUint32:], buf.Offset)
+		copy(b[buf.Offset:], buf.Data)
+	}
+	return b, nil
+}
+
+// alignUp returns n rounded up to a multiple of align.
+func alignUp(n, align uint64) uint64 {
+	return (n + align - 1) / align * align
+}
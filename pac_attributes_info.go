@@ -0,0 +1,76 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PAC_ATTRIBUTES_INFO flags
+const (
+	PACWasRequested          uint32 = 0x1 // The client requested the PAC.
+	PACWasGivenImplicitly    uint32 = 0x2 // The client did not request or decline the PAC.
+	pacAttributesFlagsLength        = 2   // The number of flag bits defined.
+)
+
+// PACAttributesInfo implements the PAC_ATTRIBUTES_INFO buffer of MS-PAC.
+type PACAttributesInfo struct {
+	FlagsLength uint32   // The number of bits of Flags in use.
+	Flags       []uint32 // The flag bits, in (FlagsLength+31)/32 32-bit words.
+}
+
+// NewPACAttributesInfo returns the PACAttributesInfo holding the PAC_ATTRIBUTES_INFO flags.
+func NewPACAttributesInfo(flags uint32) PACAttributesInfo {
+	return PACAttributesInfo{FlagsLength: pacAttributesFlagsLength, Flags: []uint32{flags}}
+}
+
+// PACBufferType returns PACBufferTypeAttributes.
+func (a *PACAttributesInfo) PACBufferType() uint32 {
+	return PACBufferTypeAttributes
+}
+
+// Flag returns the first word of the flags, holding the PACWasRequested and PACWasGivenImplicitly bits.
+func (a *PACAttributesInfo) Flag() uint32 {
+	if len(a.Flags) == 0 {
+		return 0
+	}
+	return a.Flags[0]
+}
+
+// WasRequested reports whether the client requested the PAC.
+func (a *PACAttributesInfo) WasRequested() bool {
+	return a.Flag()&PACWasRequested != 0
+}
+
+// WasGivenImplicitly reports whether the PAC was given without the client requesting or declining it.
+func (a *PACAttributesInfo) WasGivenImplicitly() bool {
+	return a.Flag()&PACWasGivenImplicitly != 0
+}
+
+// UnmarshalBinary parses the PACAttributesInfo buffer b.
+func (a *PACAttributesInfo) UnmarshalBinary(b []byte) error {
+	if len(b) < SizeUint32 {
+		return fmt.Errorf("PAC attributes info of %d bytes is shorter than its header", len(b))
+	}
+	a.FlagsLength = binary.LittleEndian.Uint32(b)
+	n := (uint64(a.FlagsLength) + 31) / 32
+	if n > uint64(len(b)-SizeUint32)/SizeUint32 {
+		return fmt.Errorf("PAC attributes flags length %d exceeds the %d bytes of the buffer", a.FlagsLength, len(b))
+	}
+	a.Flags = make([]uint32, n)
+	for i := range a.Flags {
+		a.Flags[i] = binary.LittleEndian.Uint32(b[SizeUint32*(i+1):])
+	}
+	return nil
+}
+
+// MarshalBinary returns the PACAttributesInfo buffer.
+func (a *PACAttributesInfo) MarshalBinary() ([]byte, error) {
+	if n := (uint64(a.FlagsLength) + 31) / 32; n != uint64(len(a.Flags)) {
+		return nil, fmt.Errorf("PAC attributes flags length %d does not match the %d flag words", a.FlagsLength, len(a.Flags))
+	}
+	b := binary.LittleEndian.AppendUint32(make([]byte, 0, SizeUint32*(len(a.Flags)+1)), a.FlagsLength)
+	for _, f := range a.Flags {
+		b = binary.LittleEndian.AppendUint32(b, f)
+	}
+	return b, nil
+}
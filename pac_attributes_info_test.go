@@ -0,0 +1,47 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PACAttributesInfo(t *testing.T) {
+	b, _ := hex.DecodeString("0200000001000000")
+	var a PACAttributesInfo
+	err := a.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, NewPACAttributesInfo(PACWasRequested), a, "decoded value not as expected")
+	assert.True(t, a.WasRequested(), "was requested not as expected")
+	assert.False(t, a.WasGivenImplicitly(), "was given implicitly not as expected")
+	assert.Equal(t, PACBufferTypeAttributes, a.PACBufferType(), "PAC buffer type not as expected")
+	enc, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, enc, "encoding not as expected")
+
+	// Flags beyond the first word are kept
+	b, _ = hex.DecodeString("21000000" + "02000000" + "01000000")
+	err = a.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, a.WasGivenImplicitly(), "was given implicitly not as expected")
+	enc, err = a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, enc, "encoding not as expected")
+
+	for i, test := range []string{"020000", "21000000" + "02000000"} {
+		b, _ := hex.DecodeString(test)
+		assert.Error(t, a.UnmarshalBinary(b), "test %d: invalid attributes info not detected", i+1)
+	}
+	a.FlagsLength = 65
+	_, err = a.MarshalBinary()
+	assert.Error(t, err, "flags length mismatch not detected")
+}
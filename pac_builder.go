@@ -0,0 +1,180 @@
+package mstypes
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Defaults of NewPAC
+const (
+	// DefaultPACPrimaryGroupID is the RID of the Domain Users group.
+	DefaultPACPrimaryGroupID uint32 = 513
+	// DefaultPACUserAccountControl is a normal account whose password does not expire.
+	DefaultPACUserAccountControl = UserAccountNormal | UserAccountDontExpirePassword
+)
+
+// PACOptions are the inputs NewPAC builds a PAC from. The zero value of an optional field selects its default.
+type PACOptions struct {
+	UserName      string  // The sAMAccountName of the user. Required.
+	FullName      string  // The display name of the user.
+	UserID        uint32  // The RID of the user. Required.
+	DomainSID     *RPCSID // The SID of the domain of the user. Required.
+	DNSDomainName string  // The DNS name of the domain. Required.
+	DomainName    string  // The NetBIOS name of the domain; defaults to the first label of the DNS name in upper case.
+	LogonServer   string  // The NetBIOS name of the domain controller.
+
+	PrimaryGroupID uint32   // Defaults to DefaultPACPrimaryGroupID.
+	GroupIDs       []uint32 // The RIDs of the groups of the domain of the user; defaults to the primary group.
+	GroupAttrs     SIDAttributes
+	ExtraSIDs      []KerbSidAndAttributes // SIDs of other domains and well known SIDs.
+
+	ResourceGroupDomainSID *RPCSID  // The SID of the domain of the ResourceGroupIDs.
+	ResourceGroupIDs       []uint32 // The RIDs of the domain local groups of the resource domain.
+
+	AuthTime           time.Time // The time the user authenticated; defaults to the current time.
+	LogonCount         uint16
+	BadPasswordCount   uint16
+	PasswordLastSet    time.Time        // Defaults to not set.
+	UserAccountControl UserAccountFlags // Defaults to DefaultPACUserAccountControl.
+
+	UPN          string  // The UPN of the user; defaults to the user name at the DNS domain name with the U flag set.
+	RequestorSID *RPCSID // The SID of the requestor buffer; defaults to the user SID.
+	Attributes   uint32  // The PAC_ATTRIBUTES_INFO flags; defaults to PACWasRequested.
+
+	SignatureType     int32 // The type of the signature placeholders; defaults to PACSignatureTypeHMACSHA196AES256.
+	ExtendedChecksums bool  // Add ticket and full PAC signature placeholders.
+}
+
+// NewPAC returns the PAC of a user built from o. It holds the logon information, client information, UPN and DNS
+// information, attributes and requestor buffers followed by zeroed server and KDC signatures, and the ticket and
+// full PAC signatures if ExtendedChecksums is set. The signatures are left for the caller to compute.
+func NewPAC(o PACOptions) (*PACType, error) {
+	if o.UserName == "" || o.UserID == 0 || o.DomainSID == nil || o.DNSDomainName == "" {
+		return nil, errors.New("PAC options require the user name, user ID, domain SID and DNS domain name")
+	}
+	if o.AuthTime.IsZero() {
+		o.AuthTime = time.Now()
+	}
+	if o.PrimaryGroupID == 0 {
+		o.PrimaryGroupID = DefaultPACPrimaryGroupID
+	}
+	if o.GroupIDs == nil {
+		o.GroupIDs = []uint32{o.PrimaryGroupID}
+	}
+	if o.GroupAttrs == 0 {
+		o.GroupAttrs = SIDAttributesDefaultGroup
+	}
+	if o.DomainName == "" {
+		o.DomainName = strings.ToUpper(strings.SplitN(o.DNSDomainName, ".", 2)[0])
+	}
+	if o.UserAccountControl == 0 {
+		o.UserAccountControl = DefaultPACUserAccountControl
+	}
+	if o.Attributes == 0 {
+		o.Attributes = PACWasRequested
+	}
+	if o.SignatureType == 0 {
+		o.SignatureType = PACSignatureTypeHMACSHA196AES256
+	}
+
+	logonInfo, err := newPACLogonInfo(&o)
+	if err != nil {
+		return nil, err
+	}
+	userSID, err := logonInfo.UserSID()
+	if err != nil {
+		return nil, err
+	}
+	clientInfo, err := NewPACClientInfo(o.AuthTime, o.UserName)
+	if err != nil {
+		return nil, err
+	}
+	upnDNSInfo := UPNDNSInfo{
+		Flags:         UPNDNSInfoFlagExtended,
+		UPN:           o.UPN,
+		DNSDomainName: strings.ToUpper(o.DNSDomainName),
+		SamName:       o.UserName,
+		SID:           userSID,
+	}
+	if upnDNSInfo.UPN == "" {
+		upnDNSInfo.Flags |= UPNDNSInfoFlagNoUPN
+		upnDNSInfo.UPN = o.UserName + "@" + strings.ToLower(o.DNSDomainName)
+	}
+	attributes := NewPACAttributesInfo(o.Attributes)
+	requestor := PACRequestor{SID: *userSID}
+	if o.RequestorSID != nil {
+		requestor.SID = *o.RequestorSID
+	}
+	buffers := []PACBuffer{logonInfo, &clientInfo, &upnDNSInfo, &attributes, &requestor}
+	signatures := []uint32{PACBufferTypeServerChecksum, PACBufferTypeKDCChecksum}
+	if o.ExtendedChecksums {
+		signatures = append(signatures, PACBufferTypeTicketChecksum, PACBufferTypeFullChecksum)
+	}
+	for _, t := range signatures {
+		buffers = append(buffers, &PACSignatureData{
+			ULType:        t,
+			SignatureType: o.SignatureType,
+			Signature:     make([]byte, PACSignatureLength(o.SignatureType)),
+		})
+	}
+	p := &PACType{Version: PACVersion}
+	for _, b := range buffers {
+		err = p.SetBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, p.Layout()
+}
+
+// newPACLogonInfo returns the logon information of the PAC options o, completed with their defaults.
+func newPACLogonInfo(o *PACOptions) (*KerbValidationInfo, error) {
+	k := &KerbValidationInfo{
+		ValidationInfo: ValidationInfo{
+			LogOnTime:          GetFileTime(o.AuthTime).KerberosTime(),
+			LogOffTime:         FileTimeNever,
+			KickOffTime:        FileTimeNever,
+			PasswordMustChange: FileTimeNever,
+			LogonCount:         o.LogonCount,
+			BadPasswordCount:   o.BadPasswordCount,
+			UserID:             o.UserID,
+			PrimaryGroupID:     o.PrimaryGroupID,
+			GroupCount:         uint32(len(o.GroupIDs)),
+			GroupIDs:           NewGroupMemberships(uint32(o.GroupAttrs), o.GroupIDs...),
+			LogonDomainID:      o.DomainSID,
+		},
+		UserAccountControl: o.UserAccountControl,
+	}
+	if !o.PasswordLastSet.IsZero() {
+		k.PasswordLastSet = GetFileTime(o.PasswordLastSet)
+		k.PasswordCanChange = k.PasswordLastSet
+	}
+	for _, s := range []struct {
+		v *RPCUnicodeString
+		s string
+	}{
+		{&k.EffectiveName, o.UserName},
+		{&k.FullName, o.FullName},
+		{&k.LogonServer, o.LogonServer},
+		{&k.LogonDomainName, o.DomainName},
+	} {
+		var err error
+		*s.v, err = NewRPCUnicodeString(s.s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(o.ExtraSIDs) > 0 {
+		k.UserFlags |= LogonExtraSIDs
+		k.SIDCount = uint32(len(o.ExtraSIDs))
+		k.ExtraSIDs = o.ExtraSIDs
+	}
+	if o.ResourceGroupDomainSID != nil && len(o.ResourceGroupIDs) > 0 {
+		k.UserFlags |= LogonResourceGroups
+		k.ResourceGroupDomainSID = o.ResourceGroupDomainSID
+		k.ResourceGroupCount = uint32(len(o.ResourceGroupIDs))
+		k.ResourceGroupIDs = NewGroupMemberships(uint32(o.GroupAttrs|SIDAttributeResource), o.ResourceGroupIDs...)
+	}
+	return k, nil
+}
@@ -0,0 +1,150 @@
+package mstypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPAC(t *testing.T) {
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	authTime := time.Date(2017, 5, 6, 15, 53, 11, 0, time.UTC)
+	p, err := NewPAC(PACOptions{
+		UserName:      "testuser1",
+		UserID:        1105,
+		DomainSID:     domain,
+		DNSDomainName: "test.gokrb5",
+		GroupIDs:      []uint32{513, 1108},
+		AuthTime:      authTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d PACType
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []uint32
+	for _, buf := range d.Buffers {
+		types = append(types, buf.ULType)
+	}
+	assert.Equal(t, []uint32{
+		PACBufferTypeLogonInfo, PACBufferTypeClientInfo, PACBufferTypeUPNDNSInfo, PACBufferTypeAttributes,
+		PACBufferTypeRequestor, PACBufferTypeServerChecksum, PACBufferTypeKDCChecksum,
+	}, types, "buffer types not as expected")
+
+	var k KerbValidationInfo
+	_, err = d.Decode(&k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testuser1", k.EffectiveName.Value, "effective name not as expected")
+	assert.Equal(t, "TEST", k.LogonDomainName.Value, "logon domain name not as expected")
+	assert.Equal(t, authTime, k.LogOnTime.Time(), "logon time not as expected")
+	assert.True(t, k.KickOffTime.IsNever(), "kick off time not as expected")
+	assert.Equal(t, DefaultPACPrimaryGroupID, k.PrimaryGroupID, "primary group ID not as expected")
+	assert.Equal(t, NewGroupMemberships(7, 513, 1108), k.GroupIDs, "group IDs not as expected")
+	assert.Equal(t, uint32(2), k.GroupCount, "group count not as expected")
+	assert.Equal(t, DefaultPACUserAccountControl, k.UserAccountControl, "user account control not as expected")
+
+	var c PACClientInfo
+	_, err = d.Decode(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, c.Validate(authTime, "testuser1"))
+
+	var u UPNDNSInfo
+	_, err = d.Decode(&u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testuser1@test.gokrb5", u.UPN, "UPN not as expected")
+	assert.Equal(t, "TEST.GOKRB5", u.DNSDomainName, "DNS domain name not as expected")
+	assert.True(t, u.NoUPN(), "no UPN flag not as expected")
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", u.SID.String(), "SID not as expected")
+
+	var a PACAttributesInfo
+	_, err = d.Decode(&a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, a.WasRequested(), "attributes not as expected")
+
+	var r PACRequestor
+	_, err = d.Decode(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, r.Validate(&k))
+
+	s := PACSignatureData{ULType: PACBufferTypeKDCChecksum}
+	_, err = d.Decode(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PACSignatureTypeHMACSHA196AES256, s.SignatureType, "signature type not as expected")
+	assert.Equal(t, make([]byte, 12), s.Signature, "signature not as expected")
+}
+
+func Test_NewPACOptions(t *testing.T) {
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	resource, _ := ConvertStrToSID("S-1-5-21-4-5-6")
+	extra, _ := ConvertStrToSID("S-1-18-1")
+	p, err := NewPAC(PACOptions{
+		UserName:               "testuser1",
+		UserID:                 1105,
+		DomainSID:              domain,
+		DNSDomainName:          "test.gokrb5",
+		UPN:                    "tu1@gokrb5",
+		ExtraSIDs:              []KerbSidAndAttributes{{SID: *extra, Attributes: SIDAttributesDefaultGroup}},
+		ResourceGroupDomainSID: resource,
+		ResourceGroupIDs:       []uint32{1000},
+		SignatureType:          PACSignatureTypeHMACMD5,
+		ExtendedChecksums:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var k KerbValidationInfo
+	_, err = p.Decode(&k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sids, err := k.SIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v []string
+	for i := range sids {
+		v = append(v, sids[i].SID.String())
+	}
+	assert.Equal(t, []string{"S-1-5-21-1-2-3-1105", "S-1-5-21-1-2-3-513", "S-1-18-1", "S-1-5-21-4-5-6-1000"}, v, "SIDs not as expected")
+
+	var u UPNDNSInfo
+	_, err = p.Decode(&u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "tu1@gokrb5", u.UPN, "UPN not as expected")
+	assert.False(t, u.NoUPN(), "no UPN flag not as expected")
+
+	s := PACSignatureData{ULType: PACBufferTypeFullChecksum}
+	ok, err := p.Decode(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok, "full checksum not found")
+	assert.Len(t, s.Signature, 16, "signature not as expected")
+
+	_, err = NewPAC(PACOptions{UserName: "testuser1"})
+	assert.Error(t, err, "missing options not detected")
+	_, err = NewPAC(PACOptions{UserName: "testuser1", DomainSID: domain, DNSDomainName: "test.gokrb5"})
+	assert.ErrorContains(t, err, "user ID", "missing user ID not detected")
+}
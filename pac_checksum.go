@@ -0,0 +1,136 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPACSignature is returned when a PAC signature does not verify.
+var ErrPACSignature = errors.New("PAC signature verification failed")
+
+// Checksummer computes and verifies the keyed checksums of the PAC signatures. This package holds no Kerberos
+// cryptography, so the caller implements it with the checksum of the key of the service or the KDC, such as
+// HMAC-MD5 for RC4 keys or HMAC-SHA1-96 for AES keys.
+type Checksummer interface {
+	// SignatureType returns the checksum type, one of the PACSignatureType constants for the Windows checksums.
+	SignatureType() int32
+	// Size returns the length, in bytes, of the checksum.
+	Size() int
+	// Sign returns the checksum of data.
+	Sign(data []byte) ([]byte, error)
+	// Verify returns an error if signature is not the checksum of data.
+	Verify(data, signature []byte) error
+}
+
+// MarshalSigned returns the PAC signed with the server and KDC checksums: the server signature is computed by server
+// over the PAC with both signatures zeroed, and the KDC signature by kdc over the server signature. The signature
+// buffers are added if the PAC has none, and the PAC is updated to hold the signatures. The ticket and full PAC
+// signatures are computed over data outside the PAC and are left for the caller to set before signing.
+func (p *PACType) MarshalSigned(server, kdc Checksummer) ([]byte, error) {
+	serverSig, err := p.signaturePlaceholder(PACBufferTypeServerChecksum, server)
+	if err != nil {
+		return nil, err
+	}
+	kdcSig, err := p.signaturePlaceholder(PACBufferTypeKDCChecksum, kdc)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	serverSig.Signature, err = signPAC(server, b)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute the server signature: %w", err)
+	}
+	kdcSig.Signature, err = signPAC(kdc, serverSig.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute the KDC signature: %w", err)
+	}
+	for _, s := range []*PACSignatureData{serverSig, kdcSig} {
+		err = p.SetBuffer(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.MarshalBinary()
+}
+
+// signaturePlaceholder stores a zeroed signature of the type of c in the signature buffer ulType, keeping the RODC
+// identifier of a signature the buffer already holds, and returns it.
+func (p *PACType) signaturePlaceholder(ulType uint32, c Checksummer) (*PACSignatureData, error) {
+	s := &PACSignatureData{ULType: ulType}
+	if buf := p.Buffer(ulType); buf != nil {
+		err := s.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return nil, fmt.Errorf("PAC buffer of type %d: %w", ulType, err)
+		}
+	}
+	s.SignatureType = c.SignatureType()
+	s.Signature = make([]byte, c.Size())
+	return s, p.SetBuffer(s)
+}
+
+// signPAC returns the checksum of data computed by c, checking it has the size c declares.
+func signPAC(c Checksummer, data []byte) ([]byte, error) {
+	sig, err := c.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != c.Size() {
+		return nil, fmt.Errorf("checksum of type %d is %d bytes, not %d", c.SignatureType(), len(sig), c.Size())
+	}
+	return sig, nil
+}
+
+// VerifyPAC verifies the server signature of the PAC b with server and, if kdc is not nil, the KDC signature with
+// kdc. An error wrapping ErrPACSignature is returned if a signature is missing, of another type than its Checksummer
+// or does not verify.
+func VerifyPAC(b []byte, server, kdc Checksummer) error {
+	var p PACType
+	err := p.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+	serverSig, err := p.signature(PACBufferTypeServerChecksum, server)
+	if err != nil {
+		return err
+	}
+	z, err := ZeroPACSignatures(b)
+	if err != nil {
+		return err
+	}
+	err = server.Verify(z, serverSig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: server signature: %v", ErrPACSignature, err)
+	}
+	if kdc == nil {
+		return nil
+	}
+	kdcSig, err := p.signature(PACBufferTypeKDCChecksum, kdc)
+	if err != nil {
+		return err
+	}
+	err = kdc.Verify(serverSig.Signature, kdcSig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: KDC signature: %v", ErrPACSignature, err)
+	}
+	return nil
+}
+
+// signature decodes the signature buffer ulType, checking it is of the type of c.
+func (p *PACType) signature(ulType uint32, c Checksummer) (*PACSignatureData, error) {
+	s := &PACSignatureData{ULType: ulType}
+	ok, err := p.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: PAC has no signature buffer of type %d", ErrPACSignature, ulType)
+	}
+	if s.SignatureType != c.SignatureType() {
+		return nil, fmt.Errorf("%w: signature buffer of type %d has signature type %d, not %d", ErrPACSignature,
+			ulType, s.SignatureType, c.SignatureType())
+	}
+	return s, nil
+}
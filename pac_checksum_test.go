@@ -0,0 +1,77 @@
+package mstypes
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hmacChecksummer is a stand-in for the Kerberos checksums computing a truncated HMAC-SHA1 of the data.
+type hmacChecksummer struct {
+	key []byte
+}
+
+func (c hmacChecksummer) SignatureType() int32 {
+	return PACSignatureTypeHMACSHA196AES256
+}
+
+func (c hmacChecksummer) Size() int {
+	return 12
+}
+
+func (c hmacChecksummer) Sign(data []byte) ([]byte, error) {
+	m := hmac.New(sha1.New, c.key)
+	m.Write(data)
+	return m.Sum(nil)[:c.Size()], nil
+}
+
+func (c hmacChecksummer) Verify(data, signature []byte) error {
+	sig, _ := c.Sign(data)
+	if !hmac.Equal(sig, signature) {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func Test_PACSign(t *testing.T) {
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	p, err := NewPAC(PACOptions{
+		UserName:      "testuser1",
+		UserID:        1105,
+		DomainSID:     domain,
+		DNSDomainName: "test.gokrb5",
+		AuthTime:      time.Date(2017, 5, 6, 15, 53, 11, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := hmacChecksummer{key: []byte("server")}
+	kdc := hmacChecksummer{key: []byte("kdc")}
+	b, err := p.MarshalSigned(server, kdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, VerifyPAC(b, server, kdc))
+	assert.NoError(t, VerifyPAC(b, server, nil))
+
+	s := PACSignatureData{ULType: PACBufferTypeServerChecksum}
+	_, err = p.Decode(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, make([]byte, 12), s.Signature, "server signature not set")
+
+	err = VerifyPAC(b, hmacChecksummer{key: []byte("other")}, kdc)
+	assert.True(t, errors.Is(err, ErrPACSignature), "server signature mismatch not detected: %v", err)
+	err = VerifyPAC(b, server, hmacChecksummer{key: []byte("other")})
+	assert.True(t, errors.Is(err, ErrPACSignature), "KDC signature mismatch not detected: %v", err)
+
+	// Tampering with the logon information invalidates the server signature
+	b[p.Buffers[0].Offset+100] ^= 1
+	err = VerifyPAC(b, server, kdc)
+	assert.True(t, errors.Is(err, ErrPACSignature), "modified PAC not detected: %v", err)
+}
@@ -0,0 +1,53 @@
+package mstypes
+
+// PACClientClaimsInfo implements the PAC_CLIENT_CLAIMS_INFO buffer of MS-PAC, the type serialized claims set metadata
+// of the claims of the client.
+type PACClientClaimsInfo struct {
+	Claims ClaimsSetMetadata
+}
+
+// PACBufferType returns PACBufferTypeClientClaims.
+func (c *PACClientClaimsInfo) PACBufferType() uint32 {
+	return PACBufferTypeClientClaims
+}
+
+// UnmarshalBinary reads the claims set metadata from the type serialized PAC buffer b.
+func (c *PACClientClaimsInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, &c.Claims)
+}
+
+// MarshalBinary returns the claims set metadata as a type serialized PAC buffer.
+func (c *PACClientClaimsInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(&c.Claims)
+}
+
+// ClaimsSet returns the claims set held by the claims set metadata.
+func (c *PACClientClaimsInfo) ClaimsSet() (ClaimsSet, error) {
+	return c.Claims.ClaimsSet()
+}
+
+// PACDeviceClaimsInfo implements the PAC_DEVICE_CLAIMS_INFO buffer of MS-PAC, the type serialized claims set metadata
+// of the claims of the device of a compound identity ticket.
+type PACDeviceClaimsInfo struct {
+	Claims ClaimsSetMetadata
+}
+
+// PACBufferType returns PACBufferTypeDeviceClaims.
+func (c *PACDeviceClaimsInfo) PACBufferType() uint32 {
+	return PACBufferTypeDeviceClaims
+}
+
+// UnmarshalBinary reads the claims set metadata from the type serialized PAC buffer b.
+func (c *PACDeviceClaimsInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, &c.Claims)
+}
+
+// MarshalBinary returns the claims set metadata as a type serialized PAC buffer.
+func (c *PACDeviceClaimsInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(&c.Claims)
+}
+
+// ClaimsSet returns the claims set held by the claims set metadata.
+func (c *PACDeviceClaimsInfo) ClaimsSet() (ClaimsSet, error) {
+	return c.Claims.ClaimsSet()
+}
@@ -0,0 +1,52 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PACClientClaimsInfo(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoStr)
+	var c PACClientClaimsInfo
+	err := c.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PACBufferTypeClientClaims, c.PACBufferType(), "PAC buffer type not as expected")
+	enc, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ClientClaimsInfoStr, hex.EncodeToString(enc), "encoding not as expected")
+
+	s, err := c.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ClaimsEntryIDStr, s.ClaimsArrays[0].ClaimEntries[0].ID, "claims entry ID not as expected")
+	assert.Equal(t, []LPWSTR{{ClaimsEntryValueStr}}, s.ClaimsArrays[0].ClaimEntries[0].TypeString.Value, "claims value not as expected")
+}
+
+func Test_PACDeviceClaimsInfo(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoInt)
+	var c PACDeviceClaimsInfo
+	err := c.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PACBufferTypeDeviceClaims, c.PACBufferType(), "PAC buffer type not as expected")
+	enc, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ClientClaimsInfoInt, hex.EncodeToString(enc), "encoding not as expected")
+
+	s, err := c.ClaimsSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ClaimsEntryIDInt64, s.ClaimsArrays[0].ClaimEntries[0].ID, "claims entry ID not as expected")
+	assert.Equal(t, []int64{ClaimsEntryValueInt64}, s.ClaimsArrays[0].ClaimEntries[0].TypeInt64.Value, "claims value not as expected")
+}
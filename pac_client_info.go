@@ -0,0 +1,103 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrClientInfoMismatch is returned when a PAC_CLIENT_INFO does not match the ticket it was issued in.
+var ErrClientInfoMismatch = errors.New("PAC client info does not match the ticket")
+
+// pacClientInfoHeaderSize is the size of the ClientId and NameLength of a PAC_CLIENT_INFO.
+const pacClientInfoHeaderSize = 2*SizeUint32 + SizeUint16
+
+// PACClientInfo implements the PAC_CLIENT_INFO buffer https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/e465cb27-4bc1-4173-8be0-b5fd64dc9ff7
+type PACClientInfo struct {
+	ClientID   FileTime // The Kerberos initial ticket-granting ticket authentication time.
+	NameLength uint16   // The length, in bytes, of the UTF-16LE Name. Set from the Name by MarshalBinary.
+	Name       string   // The client name from the ticket, without the realm.
+}
+
+// NewPACClientInfo returns the PACClientInfo of the client name issued a ticket at authTime. The authTime is truncated
+// to the second precision of Kerberos times. An error wrapping ErrStringTooLong is returned if the name is too long.
+func NewPACClientInfo(authTime time.Time, name string) (PACClientInfo, error) {
+	length, err := countedLength(len(EncodeUTF16LE(name)))
+	if err != nil {
+		return PACClientInfo{}, err
+	}
+	return PACClientInfo{
+		ClientID:   GetFileTime(authTime).KerberosTime(),
+		NameLength: length,
+		Name:       name,
+	}, nil
+}
+
+// PACBufferType returns PACBufferTypeClientInfo.
+func (c *PACClientInfo) PACBufferType() uint32 {
+	return PACBufferTypeClientInfo
+}
+
+// MarshalBinary returns the PACClientInfo buffer. The NameLength written is taken from the Name.
+func (c *PACClientInfo) MarshalBinary() ([]byte, error) {
+	name := EncodeUTF16LE(c.Name)
+	length, err := countedLength(len(name))
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, pacClientInfoHeaderSize, pacClientInfoHeaderSize+len(name))
+	binary.LittleEndian.PutUint32(b, c.ClientID.LowDateTime)
+	binary.LittleEndian.PutUint32(b[SizeUint32:], c.ClientID.HighDateTime)
+	binary.LittleEndian.PutUint16(b[2*SizeUint32:], length)
+	return append(b, name...), nil
+}
+
+// UnmarshalBinary parses the PACClientInfo buffer b.
+func (c *PACClientInfo) UnmarshalBinary(b []byte) error {
+	if len(b) < pacClientInfoHeaderSize {
+		return fmt.Errorf("PAC client info of %d bytes is shorter than its header", len(b))
+	}
+	c.ClientID.LowDateTime = binary.LittleEndian.Uint32(b)
+	c.ClientID.HighDateTime = binary.LittleEndian.Uint32(b[SizeUint32:])
+	c.NameLength = binary.LittleEndian.Uint16(b[2*SizeUint32:])
+	if c.NameLength%SizeUint16 != 0 {
+		return fmt.Errorf("PAC client info name length %d is odd", c.NameLength)
+	}
+	if int(c.NameLength) > len(b)-pacClientInfoHeaderSize {
+		return fmt.Errorf("PAC client info name length %d exceeds the %d bytes of the buffer", c.NameLength, len(b))
+	}
+	c.Name = DecodeUTF16LE(b[pacClientInfoHeaderSize : pacClientInfoHeaderSize+int(c.NameLength)])
+	return nil
+}
+
+// ValidateAuthTime checks the ClientID is the authTime of the ticket, compared at the second precision of Kerberos
+// times. An error wrapping ErrClientInfoMismatch is returned if it is not.
+func (c *PACClientInfo) ValidateAuthTime(authTime time.Time) error {
+	want := GetFileTime(authTime).KerberosTime()
+	if c.ClientID.KerberosTime() != want {
+		return fmt.Errorf("%w: client ID %s is not the authentication time %s", ErrClientInfoMismatch,
+			c.ClientID.Time().Format(time.RFC3339), want.Time().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ValidateName checks the Name is the client name of the ticket, without the realm. Names are compared case
+// insensitively as principal names are by Active Directory, with EqualFoldWindows. An error wrapping ErrClientInfoMismatch is returned if
+// they differ.
+func (c *PACClientInfo) ValidateName(name string) error {
+	if !EqualFoldWindows(c.Name, name) {
+		return fmt.Errorf("%w: name %q is not the client name %q", ErrClientInfoMismatch, c.Name, name)
+	}
+	return nil
+}
+
+// Validate checks the PACClientInfo against the authTime and client name of the ticket as ValidateAuthTime and
+// ValidateName do.
+func (c *PACClientInfo) Validate(authTime time.Time, name string) error {
+	err := c.ValidateAuthTime(authTime)
+	if err != nil {
+		return err
+	}
+	return c.ValidateName(name)
+}
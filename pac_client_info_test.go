@@ -0,0 +1,70 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPACClientInfo is the PAC_CLIENT_INFO of testuser1 authenticated at 2017-05-06T15:53:11Z.
+const TestPACClientInfo = "808dd1dc80c6d201" + "1200" + "740065007300740075007300650072003100"
+
+func Test_PACClientInfoUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPACClientInfo)
+	var c PACClientInfo
+	err := c.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authTime := time.Date(2017, 5, 6, 15, 53, 11, 0, time.UTC)
+	assert.Equal(t, authTime, c.ClientID.Time(), "client ID not as expected")
+	assert.Equal(t, uint16(18), c.NameLength, "name length not as expected")
+	assert.Equal(t, "testuser1", c.Name, "name not as expected")
+	assert.Equal(t, PACBufferTypeClientInfo, c.PACBufferType(), "PAC buffer type not as expected")
+
+	for i, test := range []string{
+		"808dd1dc80c6d2011200",              // name exceeding the buffer
+		"808dd1dc80c6d2010300" + "74006500", // odd name length
+		"808dd1dc80c6d20112",                // short header
+	} {
+		b, _ := hex.DecodeString(test)
+		assert.Error(t, c.UnmarshalBinary(b), "test %d: invalid client info not detected", i+1)
+	}
+}
+
+func Test_PACClientInfoMarshal(t *testing.T) {
+	c, err := NewPACClientInfo(time.Date(2017, 5, 6, 15, 53, 11, 999, time.UTC), "testuser1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestPACClientInfo, hex.EncodeToString(b), "encoding not as expected")
+}
+
+func Test_PACClientInfoValidate(t *testing.T) {
+	b, _ := hex.DecodeString(TestPACClientInfo)
+	var c PACClientInfo
+	err := c.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authTime := time.Date(2017, 5, 6, 15, 53, 11, 0, time.UTC)
+	assert.NoError(t, c.Validate(authTime, "testuser1"))
+	assert.NoError(t, c.Validate(authTime.Add(500*time.Millisecond), "TestUser1"))
+	err = c.Validate(authTime.Add(time.Second), "testuser1")
+	assert.True(t, errors.Is(err, ErrClientInfoMismatch), "auth time mismatch not detected: %v", err)
+	err = c.Validate(authTime, "testuser2")
+	assert.True(t, errors.Is(err, ErrClientInfoMismatch), "name mismatch not detected: %v", err)
+
+	// Windows does not fold the Kelvin sign to k
+	c.Name = "kdcuser"
+	assert.NoError(t, c.ValidateName("KDCUSER"))
+	err = c.ValidateName("\u212adcuser")
+	assert.True(t, errors.Is(err, ErrClientInfoMismatch), "Kelvin sign matched k: %v", err)
+}
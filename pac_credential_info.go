@@ -0,0 +1,279 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// PAC_CREDENTIAL_INFO layout
+const (
+	PACCredentialInfoVersion    = 0
+	pacCredentialInfoHeaderSize = 2 * SizeUint32
+)
+
+// NTLM_SUPPLEMENTAL_CREDENTIAL flags
+const (
+	NTLMSupplementalCredentialLMPresent uint32 = 0x1 // The LmPassword holds the LM OWF of the password.
+	NTLMSupplementalCredentialNTPresent uint32 = 0x2 // The NtPassword holds the NT OWF of the password.
+)
+
+// NTLMSupplementalCredentialPackage is the PackageName of the SECPKG_SUPPLEMENTAL_CRED holding an
+// NTLM_SUPPLEMENTAL_CREDENTIAL.
+const NTLMSupplementalCredentialPackage = "NTLM"
+
+// ntlmSupplementalCredentialSize is the size of an NTLM_SUPPLEMENTAL_CREDENTIAL.
+const ntlmSupplementalCredentialSize = 2*SizeUint32 + 2*16
+
+// PACCredentialDecrypter decrypts the SerializedData of a PAC_CREDENTIAL_INFO. This package holds no Kerberos
+// cryptography, so the caller implements it with the reply key of the AS exchange the credentials were returned in.
+type PACCredentialDecrypter interface {
+	DecryptPACCredentials(encryptionType uint32, ciphertext []byte) ([]byte, error)
+}
+
+// PACCredentialDecrypterFunc adapts a function to a PACCredentialDecrypter.
+type PACCredentialDecrypterFunc func(encryptionType uint32, ciphertext []byte) ([]byte, error)
+
+// DecryptPACCredentials calls f.
+func (f PACCredentialDecrypterFunc) DecryptPACCredentials(encryptionType uint32, ciphertext []byte) ([]byte, error) {
+	return f(encryptionType, ciphertext)
+}
+
+// PACCredentialInfo implements the PAC_CREDENTIAL_INFO buffer https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/cc919d0c-f2eb-4f21-b487-080c486d85fe
+type PACCredentialInfo struct {
+	Version        uint32 // MUST be 0.
+	EncryptionType uint32 // The Kerberos encryption type of the SerializedData.
+	SerializedData []byte // The encrypted type serialized PAC_CREDENTIAL_DATA.
+}
+
+// PACBufferType returns PACBufferTypeCredentials.
+func (c *PACCredentialInfo) PACBufferType() uint32 {
+	return PACBufferTypeCredentials
+}
+
+// UnmarshalBinary parses the PACCredentialInfo buffer b.
+func (c *PACCredentialInfo) UnmarshalBinary(b []byte) error {
+	if len(b) < pacCredentialInfoHeaderSize {
+		return fmt.Errorf("PAC credential info of %d bytes is shorter than its header", len(b))
+	}
+	c.Version = binary.LittleEndian.Uint32(b)
+	if c.Version != PACCredentialInfoVersion {
+		return fmt.Errorf("PAC credential info version %d is not %d", c.Version, PACCredentialInfoVersion)
+	}
+	c.EncryptionType = binary.LittleEndian.Uint32(b[SizeUint32:])
+	c.SerializedData = append([]byte(nil), b[pacCredentialInfoHeaderSize:]...)
+	return nil
+}
+
+// MarshalBinary returns the PACCredentialInfo buffer.
+func (c *PACCredentialInfo) MarshalBinary() ([]byte, error) {
+	b := make([]byte, pacCredentialInfoHeaderSize, pacCredentialInfoHeaderSize+len(c.SerializedData))
+	binary.LittleEndian.PutUint32(b, c.Version)
+	binary.LittleEndian.PutUint32(b[SizeUint32:], c.EncryptionType)
+	return append(b, c.SerializedData...), nil
+}
+
+// CredentialData decrypts the SerializedData with d and decodes the PAC_CREDENTIAL_DATA it holds.
+func (c *PACCredentialInfo) CredentialData(d PACCredentialDecrypter) (*PACCredentialData, error) {
+	b, err := d.DecryptPACCredentials(c.EncryptionType, c.SerializedData)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt PAC credentials: %w", err)
+	}
+	data := new(PACCredentialData)
+	err = data.UnmarshalBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PACCredentialData implements PAC_CREDENTIAL_DATA https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/4927158e-c9d5-493d-a3f6-1826b88d22ba
+type PACCredentialData struct {
+	CredentialCount uint32
+	Credentials     []SecPkgSupplementalCred `ndr:"conformant"` // Size is value of CredentialCount
+}
+
+// UnmarshalBinary reads the PACCredentialData from its type serialized representation b.
+func (c *PACCredentialData) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, c)
+}
+
+// MarshalBinary returns the type serialized representation of the PACCredentialData, to be encrypted into the
+// SerializedData of a PACCredentialInfo.
+func (c *PACCredentialData) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(c)
+}
+
+// Credential returns the first credential of the security package name or nil if there is none.
+func (c *PACCredentialData) Credential(name string) *SecPkgSupplementalCred {
+	for i := range c.Credentials {
+		if c.Credentials[i].PackageName.Value == name {
+			return &c.Credentials[i]
+		}
+	}
+	return nil
+}
+
+// NTLM returns the NTLM_SUPPLEMENTAL_CREDENTIAL of the NTLM security package or nil if there is none.
+func (c *PACCredentialData) NTLM() (*NTLMSupplementalCredential, error) {
+	cred := c.Credential(NTLMSupplementalCredentialPackage)
+	if cred == nil {
+		return nil, nil
+	}
+	n := new(NTLMSupplementalCredential)
+	err := n.UnmarshalBinary(cred.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// FromReader reads the PACCredentialData from r including the max count of its conformant Credentials array, which
+// NDR hoists to the front of the structure. The referents of its pointers are read when r.Deferred is called.
+func (c *PACCredentialData) FromReader(r *Reader) (err error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return
+	}
+	c.CredentialCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	if max != c.CredentialCount {
+		return fmt.Errorf("PAC_CREDENTIAL_DATA conformance max count %d does not match the credential count %d", max, c.CredentialCount)
+	}
+	err = r.Field("Credentials", func() (err error) {
+		c.Credentials, err = ReadArray(r, max, 16, func(s *SecPkgSupplementalCred) error {
+			return s.FromReader(r)
+		})
+		return
+	})
+	return
+}
+
+// ToWriter writes the PACCredentialData to w including the max count of its conformant Credentials array ahead of
+// the structure. An error is returned if the CredentialCount does not match the number of Credentials.
+func (c *PACCredentialData) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("CredentialCount", c.CredentialCount, len(c.Credentials))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Conformance(uint32(len(c.Credentials)))
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(c.CredentialCount)
+	if err != nil {
+		return
+	}
+	err = WriteArray(nw, c.Credentials, func(s *SecPkgSupplementalCred) error {
+		return s.ToWriter(nw)
+	})
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of PACCredentialData.
+func (c *PACCredentialData) Size() int {
+	return ndrSize(c)
+}
+
+// SecPkgSupplementalCred implements SECPKG_SUPPLEMENTAL_CRED https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/50974dc6-7e71-4a1e-9de1-2e4e4be6ed82
+type SecPkgSupplementalCred struct {
+	PackageName    RPCUnicodeString // The name of the security package the credentials are for.
+	CredentialSize uint32
+	Credentials    []byte `ndr:"pointer,conformant"` // Size is value of CredentialSize
+}
+
+// FromReader reads the SecPkgSupplementalCred from r. The package name and credentials are read when r.Deferred is
+// called. The CredentialSize must match the number of bytes of the Credentials, 0 for a null pointer.
+func (s *SecPkgSupplementalCred) FromReader(r *Reader) (err error) {
+	err = r.Field("PackageName", func() error {
+		return s.PackageName.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	s.CredentialSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	s.Credentials = nil
+	ok, err := r.fieldPointer("Credentials", func() (err error) {
+		s.Credentials, err = r.readConformantBytes()
+		if err != nil {
+			return
+		}
+		return checkArrayCount("CredentialSize", s.CredentialSize, len(s.Credentials))
+	})
+	if err == nil && !ok {
+		err = checkArrayCount("CredentialSize", s.CredentialSize, 0)
+	}
+	return
+}
+
+// ToWriter writes the SecPkgSupplementalCred to w. Nil Credentials are written as a null pointer. An error is
+// returned if the CredentialSize does not match the number of bytes of the Credentials.
+func (s *SecPkgSupplementalCred) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("CredentialSize", s.CredentialSize, len(s.Credentials))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = s.PackageName.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.CredentialSize)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if s.Credentials != nil {
+		fn = func() error {
+			return nw.writeConformantBytes(s.Credentials)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SecPkgSupplementalCred.
+func (s *SecPkgSupplementalCred) Size() int {
+	return ndrSize(s)
+}
+
+// NTLMSupplementalCredential implements the NTLM_SUPPLEMENTAL_CREDENTIAL of MS-PAC.
+type NTLMSupplementalCredential struct {
+	Version    uint32   // MUST be 0.
+	Flags      uint32   // A combination of the NTLMSupplementalCredential flag constants.
+	LMPassword [16]byte // The LM OWF of the password, if NTLMSupplementalCredentialLMPresent is set.
+	NTPassword [16]byte // The NT OWF of the password, if NTLMSupplementalCredentialNTPresent is set.
+}
+
+// UnmarshalBinary parses the NTLMSupplementalCredential b, the Credentials of its SecPkgSupplementalCred.
+func (n *NTLMSupplementalCredential) UnmarshalBinary(b []byte) error {
+	if len(b) != ntlmSupplementalCredentialSize {
+		return fmt.Errorf("NTLM supplemental credential is %d bytes, not %d", len(b), ntlmSupplementalCredentialSize)
+	}
+	n.Version = binary.LittleEndian.Uint32(b)
+	n.Flags = binary.LittleEndian.Uint32(b[SizeUint32:])
+	copy(n.LMPassword[:], b[2*SizeUint32:])
+	copy(n.NTPassword[:], b[2*SizeUint32+16:])
+	return nil
+}
+
+// MarshalBinary returns the NTLMSupplementalCredential to be held as the Credentials of a SecPkgSupplementalCred.
+func (n *NTLMSupplementalCredential) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2*SizeUint32, ntlmSupplementalCredentialSize)
+	binary.LittleEndian.PutUint32(b, n.Version)
+	binary.LittleEndian.PutUint32(b[SizeUint32:], n.Flags)
+	b = append(b, n.LMPassword[:]...)
+	return append(b, n.NTPassword[:]...), nil
+}
@@ -0,0 +1,121 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPACCredentialData is a type serialized PAC_CREDENTIAL_DATA holding the NT OWF of a password.
+const TestPACCredentialData = "01100800cccccccc6000000000000000" +
+	"00000200" + "01000000" + "01000000" + "0800080004000200" + "28000000" + "08000200" +
+	"040000000000000004000000" + "4e0054004c004d00" +
+	"28000000" + "0000000002000000" + "00000000000000000000000000000000" + "101112131415161718191a1b1c1d1e1f" +
+	"00000000"
+
+// xorDecrypter is a stand-in for the Kerberos decryption of the serialized data.
+var xorDecrypter = PACCredentialDecrypterFunc(func(encryptionType uint32, ciphertext []byte) ([]byte, error) {
+	if encryptionType != 18 {
+		return nil, errors.New("unexpected encryption type")
+	}
+	b := make([]byte, len(ciphertext))
+	for i := range ciphertext {
+		b[i] = ciphertext[i] ^ 0x5a
+	}
+	return b, nil
+})
+
+func Test_PACCredentialInfo(t *testing.T) {
+	plain, _ := hex.DecodeString(TestPACCredentialData)
+	enc, _ := xorDecrypter.DecryptPACCredentials(18, plain)
+	c := PACCredentialInfo{EncryptionType: 18, SerializedData: enc}
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0000000012000000", hex.EncodeToString(b[:8]), "header not as expected")
+
+	var d PACCredentialInfo
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c, d, "decoded value not as expected")
+	assert.Equal(t, PACBufferTypeCredentials, d.PACBufferType(), "PAC buffer type not as expected")
+
+	data, err := d.CredentialData(xorDecrypter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(1), data.CredentialCount, "credential count not as expected")
+	n, err := data.NTLM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, NTLMSupplementalCredentialNTPresent, n.Flags, "flags not as expected")
+	assert.Equal(t, plain[len(plain)-20:len(plain)-4], n.NTPassword[:], "NT OWF not as expected")
+
+	d.EncryptionType = 17
+	_, err = d.CredentialData(xorDecrypter)
+	assert.Error(t, err, "decryption error not returned")
+	assert.Error(t, d.UnmarshalBinary([]byte{1, 0, 0, 0, 18, 0, 0, 0}), "version not checked")
+}
+
+func Test_PACCredentialDataMarshal(t *testing.T) {
+	n := NTLMSupplementalCredential{Flags: NTLMSupplementalCredentialNTPresent}
+	copy(n.NTPassword[:], []byte{0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f})
+	cred, err := n.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := NewRPCUnicodeString(NTLMSupplementalCredentialPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := PACCredentialData{
+		CredentialCount: 1,
+		Credentials:     []SecPkgSupplementalCred{{PackageName: name, CredentialSize: uint32(len(cred)), Credentials: cred}},
+	}
+	b, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestPACCredentialData, hex.EncodeToString(b), "encoding not as expected")
+
+	var d PACCredentialData
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, data, d, "decoded value not as expected")
+	assert.Nil(t, d.Credential("Kerberos"), "credential of unknown package not as expected")
+
+	// The hoisted max count differing from the CredentialCount is rejected
+	b = bytes.Replace(b, []byte{0, 0, 2, 0, 1, 0, 0, 0, 1}, []byte{0, 0, 2, 0, 1, 0, 0, 0, 2}, 1)
+	assert.Error(t, d.UnmarshalBinary(b), "credential count mismatch not detected")
+
+	// Counts not matching their arrays are not encoded
+	data.CredentialCount = 2
+	_, err = data.MarshalBinary()
+	assert.ErrorContains(t, err, "CredentialCount 2 does not match the 1 array elements", "credential count mismatch encoded")
+	data.CredentialCount = 1
+	data.Credentials[0].CredentialSize++
+	_, err = data.MarshalBinary()
+	assert.ErrorContains(t, err, "CredentialSize", "credential size mismatch encoded")
+
+	// A CredentialSize differing from the bytes of the credentials is rejected
+	data.Credentials[0].CredentialSize--
+	b, err = data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(cred)))
+	i := bytes.Index(b[24:], size) + 24
+	b[i]++
+	assert.ErrorContains(t, d.UnmarshalBinary(b), "CredentialSize", "credential size mismatch not detected")
+}
@@ -0,0 +1,160 @@
+package mstypes
+
+import (
+	"io"
+)
+
+// PACDeviceInfo implements the PAC_DEVICE_INFO buffer of compound identity tickets
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/cd17a1a5-c6ec-4b35-8f53-b4c1fdd6c34c
+type PACDeviceInfo struct {
+	UserID            uint32
+	PrimaryGroupID    uint32
+	AccountDomainID   *RPCSID // nil if the pointer is null.
+	AccountGroupCount uint32
+	AccountGroupIDs   GroupMemberships // A pointer to AccountGroupCount GROUP_MEMBERSHIP, nil if the pointer is null.
+	SIDCount          uint32
+	ExtraSIDs         []KerbSidAndAttributes // A pointer to SIDCount KERB_SID_AND_ATTRIBUTES, nil if the pointer is null.
+	DomainGroupCount  uint32
+	DomainGroup       []DomainGroupMembership // A pointer to DomainGroupCount DOMAIN_GROUP_MEMBERSHIP, nil if the pointer is null.
+}
+
+// PACBufferType returns PACBufferTypeDeviceInfo.
+func (d *PACDeviceInfo) PACBufferType() uint32 {
+	return PACBufferTypeDeviceInfo
+}
+
+// UnmarshalBinary reads the PACDeviceInfo from the type serialized PAC buffer b.
+func (d *PACDeviceInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, d)
+}
+
+// MarshalBinary returns the PACDeviceInfo as a type serialized PAC buffer.
+func (d *PACDeviceInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(d)
+}
+
+// FromReader reads the PACDeviceInfo from r. The referents of its pointers are read when r.Deferred is called.
+// AccountGroupCount, SIDCount and DomainGroupCount must match the number of elements of their arrays, 0 for a null
+// pointer.
+func (d *PACDeviceInfo) FromReader(r *Reader) (err error) {
+	d.UserID, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	d.PrimaryGroupID, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	d.AccountDomainID, err = readSIDPointer(r, "AccountDomainID")
+	if err != nil {
+		return
+	}
+	d.AccountGroupCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "AccountGroupIDs", "AccountGroupCount", d.AccountGroupCount, &d.AccountGroupIDs,
+		func() (GroupMemberships, error) {
+			return readGroupMemberships(r)
+		})
+	if err != nil {
+		return
+	}
+	d.SIDCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "ExtraSIDs", "SIDCount", d.SIDCount, &d.ExtraSIDs, func() ([]KerbSidAndAttributes, error) {
+		return readKerbSidAndAttributes(r)
+	})
+	if err != nil {
+		return
+	}
+	d.DomainGroupCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "DomainGroup", "DomainGroupCount", d.DomainGroupCount, &d.DomainGroup,
+		func() ([]DomainGroupMembership, error) {
+			return ReadConformantArray(r, 12, func(g *DomainGroupMembership) error {
+				return g.FromReader(r)
+			})
+		})
+	return
+}
+
+// ToWriter writes the PACDeviceInfo to w. Nil slices and SIDs are written as null pointers. An error is returned if
+// AccountGroupCount, SIDCount or DomainGroupCount does not match the number of elements of its array.
+func (d *PACDeviceInfo) ToWriter(w io.Writer) (err error) {
+	for _, c := range []struct {
+		name  string
+		count uint32
+		n     int
+	}{
+		{"AccountGroupCount", d.AccountGroupCount, len(d.AccountGroupIDs)},
+		{"SIDCount", d.SIDCount, len(d.ExtraSIDs)},
+		{"DomainGroupCount", d.DomainGroupCount, len(d.DomainGroup)},
+	} {
+		err = checkArrayCount(c.name, c.count, c.n)
+		if err != nil {
+			return
+		}
+	}
+	nw := AsWriter(w)
+	err = nw.Uint32(d.UserID)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(d.PrimaryGroupID)
+	if err != nil {
+		return
+	}
+	err = writeSIDPointer(nw, d.AccountDomainID)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(d.AccountGroupCount)
+	if err != nil {
+		return
+	}
+	err = writeGroupMembershipsPointer(nw, d.AccountGroupIDs)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(d.SIDCount)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if d.ExtraSIDs != nil {
+		fn = func() error {
+			return writeKerbSidAndAttributes(nw, d.ExtraSIDs)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(d.DomainGroupCount)
+	if err != nil {
+		return
+	}
+	fn = nil
+	if d.DomainGroup != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, d.DomainGroup, func(g *DomainGroupMembership) error {
+				return g.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of PACDeviceInfo.
+func (d *PACDeviceInfo) Size() int {
+	return ndrSize(d)
+}
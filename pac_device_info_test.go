@@ -0,0 +1,94 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPACDeviceInfo is a type serialized PAC_DEVICE_INFO with an account group, the asserted identity SID and a
+// domain of two resource groups.
+const TestPACDeviceInfo = "01100800ccccccccb000000000000000000002005004000003020000040002000100000008000200010000000c00020001000000100002000400000001040000" +
+	"00000005150000000100000002000000030000000100000003020000070000000100000014000200070000000100000001010000000000120100000001000000" +
+	"18000200020000001c0002000400000001040000000000051500000004000000050000000600000002000000e803000007000020e90300000700002000000000"
+
+func Test_PACDeviceInfoUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPACDeviceInfo)
+	var d PACDeviceInfo
+	err := d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(1104), d.UserID, "user ID not as expected")
+	assert.Equal(t, uint32(515), d.PrimaryGroupID, "primary group ID not as expected")
+	assert.Equal(t, "S-1-5-21-1-2-3", d.AccountDomainID.String(), "account domain ID not as expected")
+	assert.Equal(t, GroupMemberships{{RelativeID: 515, Attributes: 7}}, d.AccountGroupIDs, "account group IDs not as expected")
+	if assert.Len(t, d.ExtraSIDs, 1, "extra SIDs not as expected") {
+		assert.Equal(t, "S-1-18-1", d.ExtraSIDs[0].SID.String(), "extra SID not as expected")
+	}
+	if assert.Len(t, d.DomainGroup, 1, "domain groups not as expected") {
+		assert.Equal(t, "S-1-5-21-4-5-6", d.DomainGroup[0].DomainID.String(), "domain ID not as expected")
+		assert.Equal(t, []GroupMembership{{RelativeID: 1000, Attributes: 0x20000007}, {RelativeID: 1001, Attributes: 0x20000007}},
+			d.DomainGroup[0].GroupIDs, "domain group IDs not as expected")
+	}
+	assert.Equal(t, PACBufferTypeDeviceInfo, d.PACBufferType(), "PAC buffer type not as expected")
+}
+
+func Test_PACDeviceInfoMarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPACDeviceInfo)
+	var d PACDeviceInfo
+	err := d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestPACDeviceInfo, hex.EncodeToString(enc), "encoding not as expected")
+
+	// Null pointers are kept on a round trip
+	d.AccountDomainID = nil
+	d.ExtraSIDs = nil
+	d.SIDCount = 0
+	d.DomainGroup = nil
+	d.DomainGroupCount = 0
+	enc, err = d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e PACDeviceInfo
+	err = e.UnmarshalBinary(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, d, e, "decoded value not as expected")
+
+	// The counts must match their arrays
+	d.AccountGroupCount++
+	_, err = d.MarshalBinary()
+	assert.ErrorContains(t, err, "AccountGroupCount", "expected error for a count not matching its array")
+	d.AccountGroupCount--
+	d.DomainGroupCount = 1
+	_, err = d.MarshalBinary()
+	assert.ErrorContains(t, err, "DomainGroupCount", "expected error for a count of a null pointer")
+}
+
+func Test_PACDeviceInfoUnmarshalCountMismatch(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		offset int
+		count  uint32
+	}{
+		{"SIDCount", 40, 2},
+		{"DomainGroupCount", 48, 0},
+	} {
+		b, _ := hex.DecodeString(TestPACDeviceInfo)
+		binary.LittleEndian.PutUint32(b[test.offset:], test.count)
+		var d PACDeviceInfo
+		err := d.UnmarshalBinary(b)
+		assert.ErrorContains(t, err, test.name, "expected error for %s not matching its array", test.name)
+	}
+}
@@ -0,0 +1,404 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PACDumpOptions configures DumpPAC. The zero value dumps every buffer without names for SIDs, with times in UTC and
+// without decrypting the credentials.
+type PACDumpOptions struct {
+	// ResolveSID returns the name of the account or group of a SID, printed after the SID when ok.
+	ResolveSID func(sid *RPCSID) (name string, ok bool)
+	// Decrypter decrypts the PAC_CREDENTIAL_INFO so the credentials it holds are printed.
+	Decrypter PACCredentialDecrypter
+	// Location is the location times are printed in; defaults to UTC.
+	Location *time.Location
+	// RawData prints the data of every buffer in hexadecimal, not only of the buffers that could not be decoded.
+	RawData bool
+}
+
+// DumpPAC writes the PAC in an annotated human-readable form to w, for debugging tickets. Each buffer is decoded and
+// its fields printed with SIDs resolved, times decoded and flags named. A buffer that cannot be decoded is reported
+// together with its data in hexadecimal and does not stop the dump; only an error writing to w is returned.
+func DumpPAC(w io.Writer, pac *PACType, opts PACDumpOptions) error {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	d := &pacDumper{w: w, o: opts}
+	d.printf(0, "PAC version %d, %d buffers", pac.Version, len(pac.Buffers))
+	for i := range pac.Buffers {
+		buf := &pac.Buffers[i]
+		d.printf(0, "Buffer %d: %s (type %d), %d bytes at offset %d", i, pacBufferTypeName(buf.ULType), buf.ULType,
+			buf.CBBufferSize, buf.Offset)
+		err := d.buffer(buf)
+		if err != nil {
+			d.printf(1, "Error: %v", err)
+		}
+		if err != nil || opts.RawData || pacBufferTypeName(buf.ULType) == "unknown" {
+			d.printf(1, "Data: %s", hex.EncodeToString(buf.Data))
+		}
+	}
+	return d.err
+}
+
+// pacBufferTypeName returns the name of the structure held by a PAC buffer of type ulType.
+func pacBufferTypeName(ulType uint32) string {
+	switch ulType {
+	case PACBufferTypeLogonInfo:
+		return "KERB_VALIDATION_INFO"
+	case PACBufferTypeCredentials:
+		return "PAC_CREDENTIAL_INFO"
+	case PACBufferTypeServerChecksum:
+		return "Server checksum"
+	case PACBufferTypeKDCChecksum:
+		return "KDC checksum"
+	case PACBufferTypeClientInfo:
+		return "PAC_CLIENT_INFO"
+	case PACBufferTypeS4UDelegationInfo:
+		return "S4U_DELEGATION_INFO"
+	case PACBufferTypeUPNDNSInfo:
+		return "UPN_DNS_INFO"
+	case PACBufferTypeClientClaims:
+		return "PAC_CLIENT_CLAIMS_INFO"
+	case PACBufferTypeDeviceInfo:
+		return "PAC_DEVICE_INFO"
+	case PACBufferTypeDeviceClaims:
+		return "PAC_DEVICE_CLAIMS_INFO"
+	case PACBufferTypeTicketChecksum:
+		return "Ticket checksum"
+	case PACBufferTypeAttributes:
+		return "PAC_ATTRIBUTES_INFO"
+	case PACBufferTypeRequestor:
+		return "PAC_REQUESTOR"
+	case PACBufferTypeFullChecksum:
+		return "Extended KDC checksum"
+	}
+	return "unknown"
+}
+
+// pacSignatureTypeName returns the name of the checksum type of a PAC_SIGNATURE_DATA.
+func pacSignatureTypeName(t int32) string {
+	switch t {
+	case PACSignatureTypeHMACMD5:
+		return "KERB_CHECKSUM_HMAC_MD5"
+	case PACSignatureTypeHMACSHA196AES128:
+		return "HMAC_SHA1_96_AES128"
+	case PACSignatureTypeHMACSHA196AES256:
+		return "HMAC_SHA1_96_AES256"
+	}
+	return "unknown"
+}
+
+var upnDNSInfoFlagNames = []flagName[uint32]{
+	{UPNDNSInfoFlagNoUPN, "NoUPN"},
+	{UPNDNSInfoFlagExtended, "Extended"},
+}
+
+var pacAttributeNames = []flagName[uint32]{
+	{PACWasRequested, "WasRequested"},
+	{PACWasGivenImplicitly, "WasGivenImplicitly"},
+}
+
+var ntlmSupplementalCredentialFlagNames = []flagName[uint32]{
+	{NTLMSupplementalCredentialLMPresent, "LMPresent"},
+	{NTLMSupplementalCredentialNTPresent, "NTPresent"},
+}
+
+// pacDumper writes the buffers of a PAC, keeping the first error writing to w.
+type pacDumper struct {
+	w   io.Writer
+	o   PACDumpOptions
+	err error
+}
+
+// printf writes a line indented by indent levels.
+func (d *pacDumper) printf(indent int, format string, a ...interface{}) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, "%s%s\n", strings.Repeat("  ", indent), fmt.Sprintf(format, a...))
+}
+
+// time formats a point in time in the location of the options.
+func (d *pacDumper) time(ft FileTime) string {
+	switch {
+	case ft.IsZero():
+		return "not set"
+	case ft.IsNever():
+		return "never"
+	}
+	return ft.Time().In(d.o.Location).Format(time.RFC3339)
+}
+
+// sid formats a SID followed by its name if the resolver knows it.
+func (d *pacDumper) sid(s *RPCSID) string {
+	if s == nil {
+		return "none"
+	}
+	if d.o.ResolveSID != nil {
+		if name, ok := d.o.ResolveSID(s); ok {
+			return fmt.Sprintf("%s (%s)", s, name)
+		}
+	}
+	return s.String()
+}
+
+// rid formats the relative ID of a domain as its SID, falling back to the bare RID without a domain.
+func (d *pacDumper) rid(domain *RPCSID, rid uint32) string {
+	if domain != nil {
+		s, err := domain.WithRID(rid)
+		if err == nil {
+			return d.sid(s)
+		}
+	}
+	return fmt.Sprintf("RID %d", rid)
+}
+
+// groups prints the group memberships of a domain.
+func (d *pacDumper) groups(indent int, name string, domain *RPCSID, g []GroupMembership) {
+	d.printf(indent, "%s: %d", name, len(g))
+	for _, m := range g {
+		d.printf(indent+1, "%s %s", d.rid(domain, m.RelativeID), SIDAttributes(m.Attributes))
+	}
+}
+
+// sidsAndAttributes prints a list of KERB_SID_AND_ATTRIBUTES.
+func (d *pacDumper) sidsAndAttributes(indent int, name string, s []KerbSidAndAttributes) {
+	d.printf(indent, "%s: %d", name, len(s))
+	for i := range s {
+		d.printf(indent+1, "%s %s", d.sid(&s[i].SID), s[i].Attributes)
+	}
+}
+
+// buffer decodes and prints the data of buf.
+func (d *pacDumper) buffer(buf *PACInfoBuffer) error {
+	switch buf.ULType {
+	case PACBufferTypeLogonInfo:
+		var k KerbValidationInfo
+		err := k.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.logonInfo(&k)
+	case PACBufferTypeCredentials:
+		var c PACCredentialInfo
+		err := c.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		return d.credentials(&c)
+	case PACBufferTypeServerChecksum, PACBufferTypeKDCChecksum, PACBufferTypeTicketChecksum,
+		PACBufferTypeFullChecksum:
+		s := PACSignatureData{ULType: buf.ULType}
+		err := s.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "SignatureType: %s (%d)", pacSignatureTypeName(s.SignatureType), s.SignatureType)
+		d.printf(1, "Signature: %s", hex.EncodeToString(s.Signature))
+		if s.HasRODC {
+			d.printf(1, "RODCIdentifier: %d", s.RODCIdentifier)
+		}
+	case PACBufferTypeClientInfo:
+		var c PACClientInfo
+		err := c.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "ClientID: %s", d.time(c.ClientID))
+		d.printf(1, "Name: %s", c.Name)
+	case PACBufferTypeS4UDelegationInfo:
+		var s S4UDelegationInfo
+		err := s.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "S4U2proxyTarget: %s", s.S4U2proxyTarget.Value)
+		d.printf(1, "TransitedServices: %s", strings.Join(s.TransitedServices(), ", "))
+	case PACBufferTypeUPNDNSInfo:
+		var u UPNDNSInfo
+		err := u.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "UPN: %s", u.UPN)
+		d.printf(1, "DNSDomainName: %s", u.DNSDomainName)
+		d.printf(1, "Flags: %s", formatFlags(u.Flags, upnDNSInfoFlagNames))
+		if u.Extended() {
+			d.printf(1, "SamName: %s", u.SamName)
+			d.printf(1, "SID: %s", d.sid(u.SID))
+		}
+	case PACBufferTypeClientClaims:
+		var c PACClientClaimsInfo
+		err := c.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		return d.claims(&c.Claims)
+	case PACBufferTypeDeviceInfo:
+		var v PACDeviceInfo
+		err := v.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.deviceInfo(&v)
+	case PACBufferTypeDeviceClaims:
+		var c PACDeviceClaimsInfo
+		err := c.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		return d.claims(&c.Claims)
+	case PACBufferTypeAttributes:
+		var a PACAttributesInfo
+		err := a.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "Flags: %s", formatFlags(a.Flag(), pacAttributeNames))
+	case PACBufferTypeRequestor:
+		var r PACRequestor
+		err := r.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return err
+		}
+		d.printf(1, "SID: %s", d.sid(&r.SID))
+	}
+	return nil
+}
+
+// logonInfo prints a KERB_VALIDATION_INFO.
+func (d *pacDumper) logonInfo(k *KerbValidationInfo) {
+	for _, t := range []struct {
+		name string
+		ft   FileTime
+	}{
+		{"LogonTime", k.LogOnTime},
+		{"LogoffTime", k.LogOffTime},
+		{"KickOffTime", k.KickOffTime},
+		{"PasswordLastSet", k.PasswordLastSet},
+		{"PasswordCanChange", k.PasswordCanChange},
+		{"PasswordMustChange", k.PasswordMustChange},
+	} {
+		d.printf(1, "%s: %s", t.name, d.time(t.ft))
+	}
+	for _, s := range []struct {
+		name string
+		s    *RPCUnicodeString
+	}{
+		{"EffectiveName", &k.EffectiveName},
+		{"FullName", &k.FullName},
+		{"LogonScript", &k.LogonScript},
+		{"ProfilePath", &k.ProfilePath},
+		{"HomeDirectory", &k.HomeDirectory},
+		{"HomeDirectoryDrive", &k.HomeDirectoryDrive},
+		{"LogonServer", &k.LogonServer},
+		{"LogonDomainName", &k.LogonDomainName},
+	} {
+		d.printf(1, "%s: %s", s.name, s.s.Value)
+	}
+	d.printf(1, "LogonCount: %d", k.LogonCount)
+	d.printf(1, "BadPasswordCount: %d", k.BadPasswordCount)
+	d.printf(1, "LogonDomainID: %s", d.sid(k.LogonDomainID))
+	d.printf(1, "UserID: %s", d.rid(k.LogonDomainID, k.UserID))
+	d.printf(1, "PrimaryGroupID: %s", d.rid(k.LogonDomainID, k.PrimaryGroupID))
+	d.groups(1, "GroupIDs", k.LogonDomainID, k.GroupIDs)
+	d.printf(1, "UserFlags: %s", k.UserFlags)
+	if k.UserSessionKey.IsZero() {
+		d.printf(1, "UserSessionKey: zero")
+	} else {
+		d.printf(1, "UserSessionKey: %s", hex.EncodeToString(k.UserSessionKey.Bytes()))
+	}
+	d.printf(1, "UserAccountControl: %s", k.UserAccountControl)
+	d.printf(1, "SubAuthStatus: 0x%x", k.SubAuthStatus)
+	d.printf(1, "LastSuccessfulILogon: %s", d.time(k.LastSuccessfulILogon))
+	d.printf(1, "LastFailedILogon: %s", d.time(k.LastFailedILogon))
+	d.printf(1, "FailedILogonCount: %d", k.FailedILogonCount)
+	d.sidsAndAttributes(1, "ExtraSIDs", k.ExtraSIDs)
+	d.printf(1, "ResourceGroupDomainSID: %s", d.sid(k.ResourceGroupDomainSID))
+	d.groups(1, "ResourceGroupIDs", k.ResourceGroupDomainSID, k.ResourceGroupIDs)
+}
+
+// deviceInfo prints a PAC_DEVICE_INFO.
+func (d *pacDumper) deviceInfo(v *PACDeviceInfo) {
+	d.printf(1, "AccountDomainID: %s", d.sid(v.AccountDomainID))
+	d.printf(1, "UserID: %s", d.rid(v.AccountDomainID, v.UserID))
+	d.printf(1, "PrimaryGroupID: %s", d.rid(v.AccountDomainID, v.PrimaryGroupID))
+	d.groups(1, "AccountGroupIDs", v.AccountDomainID, v.AccountGroupIDs)
+	d.sidsAndAttributes(1, "ExtraSIDs", v.ExtraSIDs)
+	d.printf(1, "DomainGroup: %d", len(v.DomainGroup))
+	for i := range v.DomainGroup {
+		g := &v.DomainGroup[i]
+		d.groups(2, d.sid(&g.DomainID), &g.DomainID, g.GroupIDs)
+	}
+}
+
+// credentials prints a PAC_CREDENTIAL_INFO, decrypting it if the options have a Decrypter.
+func (d *pacDumper) credentials(c *PACCredentialInfo) error {
+	d.printf(1, "Version: %d", c.Version)
+	d.printf(1, "EncryptionType: %d", c.EncryptionType)
+	if d.o.Decrypter == nil {
+		d.printf(1, "SerializedData: %d bytes encrypted", len(c.SerializedData))
+		return nil
+	}
+	data, err := c.CredentialData(d.o.Decrypter)
+	if err != nil {
+		return err
+	}
+	d.printf(1, "Credentials: %d", len(data.Credentials))
+	for i := range data.Credentials {
+		cred := &data.Credentials[i]
+		d.printf(2, "%s: %d bytes", cred.PackageName.Value, len(cred.Credentials))
+	}
+	n, err := data.NTLM()
+	if err != nil || n == nil {
+		return err
+	}
+	d.printf(2, "NTLM flags: %s", formatFlags(n.Flags, ntlmSupplementalCredentialFlagNames))
+	if n.Flags&NTLMSupplementalCredentialLMPresent != 0 {
+		d.printf(2, "LM: %s", hex.EncodeToString(n.LMPassword[:]))
+	}
+	if n.Flags&NTLMSupplementalCredentialNTPresent != 0 {
+		d.printf(2, "NT: %s", hex.EncodeToString(n.NTPassword[:]))
+	}
+	return nil
+}
+
+// claims prints the claims set of a CLAIMS_SET_METADATA.
+func (d *pacDumper) claims(m *ClaimsSetMetadata) error {
+	d.printf(1, "CompressionFormat: %d", m.CompressionFormat)
+	c, err := m.ClaimsSet()
+	if err != nil {
+		return err
+	}
+	d.printf(1, "ClaimsArrays: %d", len(c.ClaimsArrays))
+	for i := range c.ClaimsArrays {
+		a := &c.ClaimsArrays[i]
+		d.printf(2, "Source type %s: %d claims", a.ClaimsSourceType, len(a.ClaimEntries))
+		for j := range a.ClaimEntries {
+			e := &a.ClaimEntries[j]
+			if desc, ok := ClaimIDDescription(e.ID); ok {
+				d.printf(3, "%s (%s): %s", e.ID, desc, claimEntryValues(e))
+				continue
+			}
+			d.printf(3, "%s: %s", e.ID, claimEntryValues(e))
+		}
+	}
+	return nil
+}
+
+// claimEntryValues formats the values of a claim entry.
+func claimEntryValues(e *ClaimEntry) string {
+	switch v := e.Values().(type) {
+	case nil:
+		return fmt.Sprintf("values of unknown type %d", e.Type)
+	case []string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
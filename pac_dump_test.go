@@ -0,0 +1,62 @@
+package mstypes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DumpPAC(t *testing.T) {
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	p, err := NewPAC(PACOptions{
+		UserName:      "testuser1",
+		UserID:        1105,
+		DomainSID:     domain,
+		DNSDomainName: "test.gokrb5",
+		GroupIDs:      []uint32{513, 512},
+		AuthTime:      time.Date(2017, 5, 6, 15, 53, 11, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SetBufferData(PACBufferTypeS4UDelegationInfo, []byte{1, 2})
+	p.SetBufferData(99, []byte{0xca, 0xfe})
+	names := map[string]string{"S-1-5-21-1-2-3-512": "Domain Admins"}
+	var sb strings.Builder
+	err = DumpPAC(&sb, p, PACDumpOptions{
+		ResolveSID: func(sid *RPCSID) (string, bool) {
+			name, ok := names[sid.String()]
+			return name, ok
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"PAC version 0, 9 buffers\n",
+		"Buffer 0: KERB_VALIDATION_INFO (type 1)",
+		"  LogonTime: 2017-05-06T15:53:11Z\n",
+		"  KickOffTime: never\n",
+		"  PasswordLastSet: not set\n",
+		"  UserID: S-1-5-21-1-2-3-1105\n",
+		"  GroupIDs: 2\n    S-1-5-21-1-2-3-513 Mandatory|EnabledByDefault|Enabled\n" +
+			"    S-1-5-21-1-2-3-512 (Domain Admins) Mandatory|EnabledByDefault|Enabled\n",
+		"  UserAccountControl: Normal|DontExpirePassword\n",
+		"  Name: testuser1\n",
+		"  UPN: testuser1@test.gokrb5\n",
+		"  Flags: NoUPN|Extended\n",
+		"  Flags: WasRequested\n",
+		"  SignatureType: HMAC_SHA1_96_AES256 (16)\n",
+		"Buffer 7: S4U_DELEGATION_INFO (type 11), 2 bytes",
+		"  Data: 0102\n",
+		"Buffer 8: unknown (type 99), 2 bytes",
+		"  Data: cafe\n",
+	} {
+		assert.Contains(t, out, want, "dump not as expected")
+	}
+	assert.Contains(t, out[strings.Index(out, "Buffer 7"):], "  Error: ", "decode error not reported")
+	assert.NotContains(t, out[:strings.Index(out, "Buffer 7")], "Data: ", "raw data of decoded buffers printed")
+}
@@ -0,0 +1,48 @@
+package mstypes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRequestorMismatch is returned when the PAC_REQUESTOR SID is not the SID of the user of the logon information.
+var ErrRequestorMismatch = errors.New("PAC requestor does not match the logon information")
+
+// PACRequestor implements the PAC_REQUESTOR buffer of MS-PAC holding the SID of the client the ticket was requested
+// by.
+type PACRequestor struct {
+	SID RPCSID
+}
+
+// PACBufferType returns PACBufferTypeRequestor.
+func (p *PACRequestor) PACBufferType() uint32 {
+	return PACBufferTypeRequestor
+}
+
+// UnmarshalBinary parses the PACRequestor buffer b.
+func (p *PACRequestor) UnmarshalBinary(b []byte) error {
+	err := p.SID.UnmarshalBinary(b)
+	if err != nil {
+		return fmt.Errorf("PAC requestor: %w", err)
+	}
+	return nil
+}
+
+// MarshalBinary returns the PACRequestor buffer.
+func (p *PACRequestor) MarshalBinary() ([]byte, error) {
+	return p.SID.MarshalBinary()
+}
+
+// Validate checks the requestor SID is the SID of the user of the logon information k, its LogonDomainID with the
+// UserID appended, as the KDC checks a ticket presented to it. An error wrapping ErrRequestorMismatch is returned if
+// it is not.
+func (p *PACRequestor) Validate(k *KerbValidationInfo) error {
+	sid, err := k.UserSID()
+	if err != nil {
+		return err
+	}
+	if !p.SID.Equal(sid) {
+		return fmt.Errorf("%w: requestor %s is not the user %s", ErrRequestorMismatch, &p.SID, sid)
+	}
+	return nil
+}
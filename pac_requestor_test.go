@@ -0,0 +1,44 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PACRequestor(t *testing.T) {
+	b, _ := hex.DecodeString("01050000000000051500000001000000020000000300000051040000")
+	var p PACRequestor
+	err := p.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", p.SID.String(), "SID not as expected")
+	assert.Equal(t, PACBufferTypeRequestor, p.PACBufferType(), "PAC buffer type not as expected")
+	enc, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, enc, "encoding not as expected")
+	assert.Error(t, p.UnmarshalBinary(b[:20]), "truncated SID not detected")
+}
+
+func Test_PACRequestorValidate(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sid, _ := ConvertStrToSID("S-1-5-21-1-2-3-1105")
+	p := PACRequestor{SID: *sid}
+	assert.NoError(t, p.Validate(&k))
+
+	k.UserID = 500
+	err = p.Validate(&k)
+	assert.True(t, errors.Is(err, ErrRequestorMismatch), "requestor mismatch not detected: %v", err)
+	k.LogonDomainID = nil
+	assert.Error(t, p.Validate(&k), "missing logon domain ID not detected")
+}
@@ -0,0 +1,121 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// PAC signature types, the checksum types of MS-PAC section 2.8
+const (
+	PACSignatureTypeHMACMD5          int32 = -138 // KERB_CHECKSUM_HMAC_MD5
+	PACSignatureTypeHMACSHA196AES128 int32 = 15   // HMAC_SHA1_96_AES128
+	PACSignatureTypeHMACSHA196AES256 int32 = 16   // HMAC_SHA1_96_AES256
+)
+
+// PAC_SIGNATURE_DATA layout
+const (
+	pacSignatureDataHeaderSize         = SizeUint32
+	pacSignatureDataRODCIdentifierSize = SizeUint16
+)
+
+// PACSignatureLength returns the length, in bytes, of the signature of the signature type t, or zero if the type is
+// not known.
+func PACSignatureLength(t int32) int {
+	switch t {
+	case PACSignatureTypeHMACMD5:
+		return 16
+	case PACSignatureTypeHMACSHA196AES128, PACSignatureTypeHMACSHA196AES256:
+		return 12
+	}
+	return 0
+}
+
+// PACSignatureData implements the PAC_SIGNATURE_DATA buffer of the server, KDC, ticket and full PAC checksums
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/6e95edd3-af93-41d4-8303-6c7955297315
+type PACSignatureData struct {
+	ULType         uint32 // The PAC buffer type the signature is held in; defaults to PACBufferTypeServerChecksum.
+	SignatureType  int32  // One of the PACSignatureType constants.
+	Signature      []byte // The checksum, of the length of the SignatureType.
+	RODCIdentifier uint16 // The key version number of a read-only domain controller, only held by KDC signatures.
+	HasRODC        bool   // Whether the RODCIdentifier is present.
+}
+
+// PACBufferType returns the ULType of the signature, PACBufferTypeServerChecksum if it is not set.
+func (s *PACSignatureData) PACBufferType() uint32 {
+	if s.ULType == 0 {
+		return PACBufferTypeServerChecksum
+	}
+	return s.ULType
+}
+
+// UnmarshalBinary parses the PACSignatureData buffer b. The signature of an unknown SignatureType is taken to be the
+// rest of the buffer.
+func (s *PACSignatureData) UnmarshalBinary(b []byte) error {
+	if len(b) < pacSignatureDataHeaderSize {
+		return fmt.Errorf("PAC signature data of %d bytes is shorter than its header", len(b))
+	}
+	s.SignatureType = int32(binary.LittleEndian.Uint32(b))
+	b = b[pacSignatureDataHeaderSize:]
+	n := PACSignatureLength(s.SignatureType)
+	if n == 0 {
+		n = len(b)
+	}
+	if n > len(b) {
+		return fmt.Errorf("PAC signature of type %d is %d bytes, not %d", s.SignatureType, len(b), n)
+	}
+	s.Signature = append([]byte(nil), b[:n]...)
+	b = b[n:]
+	s.RODCIdentifier, s.HasRODC = 0, false
+	switch len(b) {
+	case 0:
+	case pacSignatureDataRODCIdentifierSize:
+		s.RODCIdentifier = binary.LittleEndian.Uint16(b)
+		s.HasRODC = true
+	default:
+		return fmt.Errorf("PAC signature data has %d bytes after the signature", len(b))
+	}
+	return nil
+}
+
+// MarshalBinary returns the PACSignatureData buffer. The RODCIdentifier is written if HasRODC is set.
+func (s *PACSignatureData) MarshalBinary() ([]byte, error) {
+	if n := PACSignatureLength(s.SignatureType); n != 0 && n != len(s.Signature) {
+		return nil, fmt.Errorf("PAC signature of type %d is %d bytes, not %d", s.SignatureType, len(s.Signature), n)
+	}
+	b := make([]byte, pacSignatureDataHeaderSize, pacSignatureDataHeaderSize+len(s.Signature)+pacSignatureDataRODCIdentifierSize)
+	binary.LittleEndian.PutUint32(b, uint32(s.SignatureType))
+	b = append(b, s.Signature...)
+	if s.HasRODC {
+		b = binary.LittleEndian.AppendUint16(b, s.RODCIdentifier)
+	}
+	return b, nil
+}
+
+// ZeroPACSignatures returns a copy of the PAC b with the Signature of its PAC_SIGNATURE_DATA buffers of the types
+// ulTypes set to zero, the data a checksum is computed and verified over. Without ulTypes the server and KDC
+// signatures are zeroed, as for the server checksum. The layout of the PAC is left as it is.
+func ZeroPACSignatures(b []byte, ulTypes ...uint32) ([]byte, error) {
+	if len(ulTypes) == 0 {
+		ulTypes = []uint32{PACBufferTypeServerChecksum, PACBufferTypeKDCChecksum}
+	}
+	var p PACType
+	err := p.UnmarshalBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	z := append([]byte(nil), b...)
+	for _, buf := range p.Buffers {
+		if !slices.Contains(ulTypes, buf.ULType) {
+			continue
+		}
+		var s PACSignatureData
+		err = s.UnmarshalBinary(buf.Data)
+		if err != nil {
+			return nil, fmt.Errorf("PAC buffer of type %d: %w", buf.ULType, err)
+		}
+		start := buf.Offset + pacSignatureDataHeaderSize
+		clear(z[start : start+uint64(len(s.Signature))])
+	}
+	return z, nil
+}
@@ -0,0 +1,98 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// TestPACSignatureServer is an HMAC_SHA1_96_AES256 server signature.
+	TestPACSignatureServer = "10000000" + "0102030405060708090a0b0c"
+	// TestPACSignatureKDC is an HMAC_MD5 KDC signature of a read-only domain controller.
+	TestPACSignatureKDC = "76ffffff" + "101112131415161718191a1b1c1d1e1f" + "0300"
+)
+
+func Test_PACSignatureDataUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPACSignatureServer)
+	var s PACSignatureData
+	err := s.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PACSignatureTypeHMACSHA196AES256, s.SignatureType, "signature type not as expected")
+	assert.Equal(t, b[4:], s.Signature, "signature not as expected")
+	assert.False(t, s.HasRODC, "RODC identifier presence not as expected")
+	assert.Equal(t, PACBufferTypeServerChecksum, s.PACBufferType(), "PAC buffer type not as expected")
+
+	b, _ = hex.DecodeString(TestPACSignatureKDC)
+	err = s.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PACSignatureTypeHMACMD5, s.SignatureType, "signature type not as expected")
+	assert.Equal(t, b[4:20], s.Signature, "signature not as expected")
+	assert.True(t, s.HasRODC, "RODC identifier presence not as expected")
+	assert.Equal(t, uint16(3), s.RODCIdentifier, "RODC identifier not as expected")
+
+	for i, test := range []string{
+		"100000",                              // short header
+		"10000000" + "0102030405060708090a0b", // short signature
+		"10000000" + "0102030405060708090a0b0c" + "01", // trailing byte
+	} {
+		b, _ := hex.DecodeString(test)
+		assert.Error(t, s.UnmarshalBinary(b), "test %d: invalid signature data not detected", i+1)
+	}
+}
+
+func Test_PACSignatureDataMarshal(t *testing.T) {
+	for _, test := range []string{TestPACSignatureServer, TestPACSignatureKDC} {
+		b, _ := hex.DecodeString(test)
+		var s PACSignatureData
+		err := s.UnmarshalBinary(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enc, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, test, hex.EncodeToString(enc), "encoding not as expected")
+	}
+	s := PACSignatureData{SignatureType: PACSignatureTypeHMACMD5, Signature: make([]byte, 12)}
+	_, err := s.MarshalBinary()
+	assert.Error(t, err, "signature length not checked")
+}
+
+func Test_ZeroPACSignatures(t *testing.T) {
+	var p PACType
+	server, _ := hex.DecodeString(TestPACSignatureServer)
+	kdc, _ := hex.DecodeString(TestPACSignatureKDC)
+	p.SetBufferData(PACBufferTypeClientInfo, []byte("abcd"))
+	p.SetBufferData(PACBufferTypeServerChecksum, server)
+	p.SetBufferData(PACBufferTypeKDCChecksum, kdc)
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := ZeroPACSignatures(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "abcd", string(b[p.Buffers[0].Offset:][:4]), "PAC modified")
+
+	var d PACType
+	assert.NoError(t, d.UnmarshalBinary(z))
+	assert.Equal(t, []byte("abcd"), d.Buffers[0].Data, "client info not as expected")
+	assert.Equal(t, "10000000"+"000000000000000000000000", hex.EncodeToString(d.Buffers[1].Data), "server signature not zeroed")
+	assert.Equal(t, "76ffffff"+"00000000000000000000000000000000"+"0300", hex.EncodeToString(d.Buffers[2].Data), "KDC signature not zeroed")
+
+	z, err = ZeroPACSignatures(b, PACBufferTypeKDCChecksum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, d.UnmarshalBinary(z))
+	assert.Equal(t, TestPACSignatureServer, hex.EncodeToString(d.Buffers[1].Data), "server signature zeroed")
+	assert.Equal(t, "76ffffff"+"00000000000000000000000000000000"+"0300", hex.EncodeToString(d.Buffers[2].Data), "KDC signature not zeroed")
+}
@@ -0,0 +1,131 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPAC is a PAC with a four byte client info buffer and an eight byte attributes buffer.
+const TestPAC = "02000000" + "00000000" +
+	"0a000000" + "04000000" + "2800000000000000" +
+	"11000000" + "08000000" + "3000000000000000" +
+	"61626364" + "00000000" +
+	"0200000001000000"
+
+func Test_PACTypeUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPAC)
+	var p PACType
+	assert.NoError(t, p.UnmarshalBinary(b))
+	assert.Equal(t, uint32(2), p.CBuffers)
+	assert.Equal(t, []PACInfoBuffer{
+		{ULType: PACBufferTypeClientInfo, CBBufferSize: 4, Offset: 40, Data: []byte("abcd")},
+		{ULType: PACBufferTypeAttributes, CBBufferSize: 8, Offset: 48, Data: []byte{2, 0, 0, 0, 1, 0, 0, 0}},
+	}, p.Buffers)
+	assert.Equal(t, &p.Buffers[1], p.Buffer(PACBufferTypeAttributes))
+	assert.Nil(t, p.Buffer(PACBufferTypeLogonInfo))
+
+	b[40] = 'x'
+	assert.Equal(t, []byte("abcd"), p.Buffers[0].Data, "buffer data not copied")
+}
+
+func Test_PACTypeUnmarshalInvalid(t *testing.T) {
+	var tests = []string{
+		"0000000",          // short header
+		"0000000001000000", // version
+		"0100000000000000", // buffer count exceeding the data
+		"01000000000000000a000000" + "04000000" + "1800000000000000" + "616263",   // buffer exceeding the data
+		"01000000000000000a000000" + "04000000" + "ffffffffffffffff" + "61626364", // offset exceeding the data
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test)
+		var p PACType
+		assert.Error(t, p.UnmarshalBinary(b), "test %d: invalid PAC not detected", i+1)
+	}
+}
+
+func Test_PACTypeMarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestPAC)
+	var p PACType
+	assert.NoError(t, p.UnmarshalBinary(b))
+	enc, err := p.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, TestPAC, hex.EncodeToString(enc))
+
+	// Growing the first buffer moves the second to the next multiple of 8 bytes
+	p.SetBufferData(PACBufferTypeClientInfo, []byte("abcdefghi"))
+	enc, err = p.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "02000000"+"00000000"+
+		"0a000000"+"09000000"+"2800000000000000"+
+		"11000000"+"08000000"+"3800000000000000"+
+		"616263646566676869"+"00000000000000"+
+		"0200000001000000", hex.EncodeToString(enc))
+	assert.Equal(t, uint64(56), p.Buffers[1].Offset)
+
+	p.SetBufferData(PACBufferTypeUPNDNSInfo, []byte{1})
+	enc, err = p.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), p.CBuffers)
+	assert.Equal(t, 88, len(enc), "last buffer not padded to 8 bytes")
+	var got PACType
+	assert.NoError(t, got.UnmarshalBinary(enc))
+	assert.Equal(t, p, got)
+
+	enc, err = new(PACType).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000000000000", hex.EncodeToString(enc))
+}
+
+func Test_PACTypeUnmarshalValidation(t *testing.T) {
+	var tests = []struct {
+		pac   string
+		err   error
+		index int
+	}{
+		{ // second buffer overlapping the first
+			"02000000" + "00000000" +
+				"0a000000" + "08000000" + "2800000000000000" +
+				"11000000" + "08000000" + "2c00000000000000" +
+				"6162636465666768" + "00000000",
+			ErrPACBufferOverlap, 1,
+		},
+		{ // buffer overlapping the directory
+			"01000000" + "00000000" +
+				"0a000000" + "08000000" + "1000000000000000",
+			ErrPACBufferOverlap, 0,
+		},
+		{ // duplicate buffer type
+			"02000000" + "00000000" +
+				"0a000000" + "04000000" + "2800000000000000" +
+				"0a000000" + "04000000" + "3000000000000000" +
+				"6162636400000000" + "61626364",
+			ErrPACDuplicateBuffer, 1,
+		},
+		{ // buffer exceeding the PAC
+			"01000000" + "00000000" +
+				"0a000000" + "08000000" + "1800000000000000" +
+				"61626364",
+			ErrPACBufferBounds, 0,
+		},
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test.pac)
+		var p PACType
+		err := p.UnmarshalBinary(b)
+		var pe *PACBufferError
+		if assert.True(t, errors.As(err, &pe), "test %d: PAC buffer error not returned: %v", i+1, err) {
+			assert.True(t, errors.Is(err, test.err), "test %d: error not as expected: %v", i+1, err)
+			assert.Equal(t, test.index, pe.Index, "test %d: index not as expected", i+1)
+		}
+	}
+
+	// Empty buffers do not overlap
+	b, _ := hex.DecodeString("02000000" + "00000000" +
+		"0a000000" + "00000000" + "0000000000000000" +
+		"11000000" + "00000000" + "2800000000000000")
+	var p PACType
+	assert.NoError(t, p.UnmarshalBinary(b))
+}
@@ -0,0 +1,172 @@
+package mstypes
+
+import (
+	"errors"
+	"io"
+)
+
+/*
+An NDR pipe transfers an unbounded sequence of elements as chunks. Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_03_11
+Each chunk is the element count followed by the elements, like a conformant array. The pipe is terminated by a chunk
+with no elements.
+*/
+
+// defaultPipeChunkSize is the number of bytes a PipeWriter buffers per chunk when no chunk size is given.
+const defaultPipeChunkSize = 4096
+
+// ReadPipeChunk reads a chunk of an NDR pipe calling fn to read each element. elemSize is the size of an element used
+// to check the chunk against the decode limits of r. Each chunk is checked on its own: as the chunk is handed to the
+// caller, it does not count towards the total bytes r allocates, so a pipe may stream more than the allocation limit.
+// A nil slice is returned for the chunk terminating the pipe.
+func ReadPipeChunk[T any](r *Reader, elemSize int, fn func(*T) error) ([]T, error) {
+	count, err := r.Conformance()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	v, err := ReadArray(r, count, elemSize, fn)
+	if err != nil {
+		return nil, err
+	}
+	r.release(len(v) * elemSize)
+	return v, nil
+}
+
+// ReadPipe reads all chunks of an NDR pipe up to and including the terminating chunk, calling fn to read each element
+// and chunk with the elements of each chunk.
+func ReadPipe[T any](r *Reader, elemSize int, fn func(*T) error, chunk func([]T) error) error {
+	for {
+		v, err := ReadPipeChunk(r, elemSize, fn)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return nil
+		}
+		err = chunk(v)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WritePipeChunk writes the elements of v as a chunk of an NDR pipe calling fn to write each element. Nothing is
+// written for an empty v as an empty chunk terminates the pipe; use EndPipe for that.
+func WritePipeChunk[T any](w *Writer, v []T, fn func(*T) error) error {
+	if len(v) == 0 {
+		return nil
+	}
+	return WriteConformantArray(w, v, fn)
+}
+
+// EndPipe writes the empty chunk terminating an NDR pipe.
+func (w *Writer) EndPipe() error {
+	return w.Conformance(0)
+}
+
+// PipeReader reads the bytes of an NDR pipe of bytes as an io.Reader. It returns io.EOF once the terminating chunk
+// has been read. Each chunk is checked against the decode limits of the Reader, but the stream may be longer than its
+// allocation limit.
+type PipeReader struct {
+	r      *Reader
+	remain int  // bytes of the current chunk not read yet
+	done   bool // the terminating chunk has been read
+}
+
+// NewPipeReader returns a PipeReader reading an NDR pipe of bytes from r.
+func NewPipeReader(r *Reader) *PipeReader {
+	return &PipeReader{r: r}
+}
+
+func (p *PipeReader) Read(b []byte) (n int, err error) {
+	for p.remain == 0 {
+		if p.done {
+			return 0, io.EOF
+		}
+		count, err := p.r.Conformance()
+		if err != nil {
+			return 0, err
+		}
+		if count == 0 {
+			p.done = true
+			continue
+		}
+		// The bytes are copied to the caller, so the chunk is only checked against the limits
+		p.remain, err = p.r.Allocate(count, SizeUint8)
+		if err != nil {
+			return 0, err
+		}
+		p.r.release(p.remain)
+	}
+	if len(b) > p.remain {
+		b = b[:p.remain]
+	}
+	n, err = p.r.Read(b)
+	p.remain -= n
+	if err == io.EOF {
+		// The stream ended in the middle of a chunk
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+// PipeWriter writes the bytes written to it as an NDR pipe of bytes. The bytes are buffered into chunks of the chunk
+// size; Close writes the buffered bytes and the terminating chunk.
+type PipeWriter struct {
+	w      *Writer
+	buf    []byte
+	closed bool
+}
+
+// NewPipeWriter returns a PipeWriter writing an NDR pipe of bytes to w in chunks of up to chunkSize bytes. The
+// default chunk size is used if chunkSize is not positive.
+func NewPipeWriter(w *Writer, chunkSize int) *PipeWriter {
+	if chunkSize <= 0 {
+		chunkSize = defaultPipeChunkSize
+	}
+	return &PipeWriter{w: w, buf: make([]byte, 0, chunkSize)}
+}
+
+func (p *PipeWriter) Write(b []byte) (n int, err error) {
+	if p.closed {
+		return 0, errors.New("write to closed pipe")
+	}
+	for len(b) > 0 {
+		m := copy(p.buf[len(p.buf):cap(p.buf)], b)
+		p.buf = p.buf[:len(p.buf)+m]
+		b = b[m:]
+		n += m
+		if len(p.buf) == cap(p.buf) {
+			err = p.Flush()
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Flush writes the buffered bytes as a chunk.
+func (p *PipeWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	err := p.w.writeConformantBytes(p.buf)
+	if err != nil {
+		return err
+	}
+	p.buf = p.buf[:0]
+	return nil
+}
+
+// Close writes the buffered bytes and the chunk terminating the pipe.
+func (p *PipeWriter) Close() error {
+	if p.closed {
+		return nil
+	}
+	err := p.Flush()
+	if err != nil {
+		return err
+	}
+	p.closed = true
+	return p.w.EndPipe()
+}
@@ -0,0 +1,85 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PipeChunks(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	fn := func(v *uint16) error {
+		return w.Uint16(*v)
+	}
+	assert.NoError(t, WritePipeChunk(w, []uint16{1, 2, 3}, fn))
+	assert.NoError(t, WritePipeChunk(w, []uint16{4}, fn))
+	assert.NoError(t, w.EndPipe())
+	assert.Equal(t, "03000000"+"010002000300"+"0000"+"01000000"+"0400"+"0000"+"00000000", hex.EncodeToString(buf.Bytes()))
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	var chunks [][]uint16
+	err := ReadPipe(r, SizeUint16, func(v *uint16) (err error) {
+		*v, err = r.Uint16()
+		return
+	}, func(v []uint16) error {
+		chunks = append(chunks, v)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]uint16{{1, 2, 3}, {4}}, chunks)
+}
+
+func Test_PipeReaderWriter(t *testing.T) {
+	data := bytes.Repeat([]byte("pipe data "), 10)
+	var buf bytes.Buffer
+	p := NewPipeWriter(NewWriter(&buf), 32)
+	n, err := p.Write(data)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.NoError(t, p.Close())
+	// 3 full chunks, a 4 byte chunk and the terminating chunk
+	assert.Equal(t, 3*(4+32)+4+4+4, buf.Len())
+
+	got, err := io.ReadAll(NewPipeReader(NewReader(&buf)))
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	b, _ := hex.DecodeString("0400000001")
+	_, err = io.ReadAll(NewPipeReader(NewReader(bytes.NewReader(b))))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "truncated chunk not detected")
+}
+
+func Test_PipeBeyondMaxAlloc(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 256)
+	var buf bytes.Buffer
+	p := NewPipeWriter(NewWriter(&buf), 512)
+	_, err := p.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Close())
+	b := buf.Bytes()
+
+	// The stream is 4096 bytes, the allocation limit 1024
+	got, err := io.ReadAll(NewPipeReader(NewReader(bytes.NewReader(b), WithMaxAlloc(1024))))
+	assert.NoError(t, err)
+	assert.Equal(t, data, got, "pipe data not as expected")
+
+	var chunks int
+	r := NewReader(bytes.NewReader(b), WithMaxAlloc(1024))
+	err = ReadPipe(r, SizeUint8, func(v *byte) (err error) {
+		*v, err = r.Uint8()
+		return
+	}, func(v []byte) error {
+		chunks++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, chunks, "chunks not as expected")
+
+	// A single chunk is still checked against the limit
+	_, err = io.ReadAll(NewPipeReader(NewReader(bytes.NewReader(b), WithMaxAlloc(256))))
+	assert.ErrorIs(t, err, ErrDecodeLimit, "chunk beyond the allocation limit not rejected")
+}
@@ -0,0 +1,181 @@
+package mstypes
+
+import (
+	"fmt"
+)
+
+/*
+Embedded pointers are marshaled as a 32bit referent ID in place of the pointer.
+Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_03_11
+A referent ID of zero is a null pointer. The referent (pointee) of a non-null pointer is deferred and
+marshaled after the enclosing structure, in the order the pointers appear. Referents that themselves
+contain embedded pointers are followed directly by their own referents.
+
+A unique pointer can not alias any other pointer. A full pointer may alias another full pointer, in which
+case the same referent ID is repeated and the referent is only marshaled once.
+*/
+
+// firstReferentID is the referent ID Windows assigns to the first embedded pointer. Each following pointer is
+// assigned the previous ID incremented by 4.
+const firstReferentID uint32 = 0x00020000
+
+// Pointer writes the referent ID of a unique pointer. A nil fn writes a null pointer, otherwise fn is deferred and
+// called to write the referent when Deferred is called.
+func (w *Writer) Pointer(fn func() error) error {
+	if fn == nil {
+		return w.nullPointer()
+	}
+	err := w.Align(SizePtr)
+	if err != nil {
+		return err
+	}
+	err = w.Uint32(w.nextRef)
+	if err != nil {
+		return err
+	}
+	w.nextRef += SizePtr
+	w.deferred = append(w.deferred, fn)
+	return nil
+}
+
+// FullPointer writes the referent ID of a full pointer. The key identifies the referent, normally the Go pointer
+// being marshaled. A nil key writes a null pointer. A key that has been written before reuses the referent ID
+// assigned to it and fn is not called, otherwise fn is deferred the same way as for Pointer.
+func (w *Writer) FullPointer(key interface{}, fn func() error) error {
+	if key == nil {
+		return w.nullPointer()
+	}
+	if id, ok := w.refs[key]; ok {
+		err := w.Align(SizePtr)
+		if err != nil {
+			return err
+		}
+		return w.Uint32(id)
+	}
+	if w.refs == nil {
+		w.refs = make(map[interface{}]uint32)
+	}
+	w.refs[key] = w.nextRef
+	return w.Pointer(fn)
+}
+
+func (w *Writer) nullPointer() error {
+	err := w.Align(SizePtr)
+	if err != nil {
+		return err
+	}
+	return w.Uint32(0)
+}
+
+// Deferred writes the referents of all pointers written since the last call to Deferred. It must be called once the
+// enclosing top-level structure has been written.
+func (w *Writer) Deferred() error {
+	d := w.deferred
+	w.deferred = nil
+	for _, fn := range d {
+		err := fn()
+		if err != nil {
+			return err
+		}
+		// Referents embedded in this referent follow it directly
+		err = w.Deferred()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pointer reads the referent ID of a unique pointer. If the pointer is not null fn is deferred and called to read the
+// referent when Deferred is called. An error is returned if the referent ID aliases a previously read pointer.
+func (r *Reader) Pointer(fn func() error) (ok bool, err error) {
+	id, err := r.referentID()
+	if err != nil || id == 0 {
+		return
+	}
+	if _, seen := r.refs[id]; seen {
+		err = fmt.Errorf("unique pointer referent ID 0x%08x aliases a previous pointer", id)
+		return
+	}
+	r.refs[id] = struct{}{}
+	r.deferred = append(r.deferred, r.deferPath(fn))
+	ok = true
+	return
+}
+
+// FullPointer reads the referent ID of a full pointer. If the pointer is not null and its referent ID has not been
+// read before fn is deferred the same way as for Pointer. If the referent ID has been read before, alias is true and
+// fn is not called as the referent was already read; the caller should resolve it from the returned ID.
+func (r *Reader) FullPointer(fn func() error) (id uint32, alias bool, err error) {
+	id, err = r.referentID()
+	if err != nil || id == 0 {
+		return
+	}
+	if _, seen := r.refs[id]; seen {
+		alias = true
+		return
+	}
+	r.refs[id] = struct{}{}
+	r.deferred = append(r.deferred, r.deferPath(fn))
+	return
+}
+
+func (r *Reader) referentID() (id uint32, err error) {
+	err = r.Align(SizePtr)
+	if err != nil {
+		return
+	}
+	id, err = r.Uint32()
+	if err != nil {
+		err = fmt.Errorf("could not read pointer: %w", err)
+		return
+	}
+	if r.refs == nil {
+		r.refs = make(map[uint32]struct{})
+	}
+	return
+}
+
+// Deferred reads the referents of all pointers read since the last call to Deferred. It must be called once the
+// enclosing top-level structure has been read.
+func (r *Reader) Deferred() error {
+	d := r.deferred
+	r.deferred = nil
+	for _, fn := range d {
+		err := fn()
+		if err != nil {
+			return err
+		}
+		// Referents embedded in this referent follow it directly
+		err = r.Deferred()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadPointerArray reads a conformant array of unique pointers to T. The referents are read, in array order, when
+// r.Deferred is called; a null pointer is returned as a nil element.
+func ReadPointerArray[T any, P element[T]](r *Reader) ([]*T, error) {
+	return ReadConformantArray(r, SizePtr, func(v **T) error {
+		_, err := r.Pointer(func() error {
+			*v = new(T)
+			return P(*v).FromReader(r)
+		})
+		return err
+	})
+}
+
+// WritePointerArray writes v as a conformant array of unique pointers to T. All pointers are written first and the
+// referents, in array order, are written when w.Deferred is called; a nil element is written as a null pointer.
+func WritePointerArray[T any, P element[T]](w *Writer, v []*T) error {
+	return WriteConformantArray(w, v, func(v **T) error {
+		if *v == nil {
+			return w.Pointer(nil)
+		}
+		return w.Pointer(func() error {
+			return P(*v).ToWriter(w)
+		})
+	})
+}
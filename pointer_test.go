@@ -0,0 +1,149 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPointerStruct mirrors struct { unsigned long *A; unsigned long *B; struct { unsigned long *C; } *Inner; }
+type testPointerStruct struct {
+	A     *uint32
+	B     *uint32
+	Inner *testPointerStruct
+}
+
+func (s *testPointerStruct) toWriter(w *Writer) error {
+	var fns [3]func() error
+	if s.A != nil {
+		fns[0] = func() error { return w.Uint32(*s.A) }
+	}
+	if s.B != nil {
+		fns[1] = func() error { return w.Uint32(*s.B) }
+	}
+	if s.Inner != nil {
+		fns[2] = func() error { return s.Inner.toWriter(w) }
+	}
+	for _, fn := range fns {
+		err := w.Pointer(fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testPointerStruct) fromReader(r *Reader) error {
+	_, err := r.Pointer(func() (err error) {
+		s.A = new(uint32)
+		*s.A, err = r.Uint32()
+		return
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.Pointer(func() (err error) {
+		s.B = new(uint32)
+		*s.B, err = r.Uint32()
+		return
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.Pointer(func() error {
+		s.Inner = new(testPointerStruct)
+		return s.Inner.fromReader(r)
+	})
+	return err
+}
+
+const testPointerStructHex = "00000200" + "00000000" + "04000200" + // A, null B, Inner
+	"11000000" + // *A
+	"08000200" + "00000000" + "00000000" + // *Inner
+	"22000000" // *Inner.A
+
+func Test_PointerDeferredOrder(t *testing.T) {
+	a, c := uint32(0x11), uint32(0x22)
+	s := testPointerStruct{A: &a, Inner: &testPointerStruct{A: &c}}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	err := s.toWriter(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testPointerStructHex, hex.EncodeToString(buf.Bytes()), "pointer layout not as expected")
+
+	var d testPointerStruct
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	err = d.fromReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, s, d, "decoded pointers not as expected")
+}
+
+func Test_PointerAliasing(t *testing.T) {
+	b, _ := hex.DecodeString("0000020000000200")
+	r := NewReader(bytes.NewReader(b))
+	noop := func() error { return nil }
+	_, err := r.Pointer(noop)
+	assert.NoError(t, err)
+	_, err = r.Pointer(noop)
+	assert.Error(t, err, "aliased unique pointer not detected")
+
+	r = NewReader(bytes.NewReader(b))
+	id, alias, err := r.FullPointer(noop)
+	assert.NoError(t, err)
+	assert.False(t, alias)
+	id2, alias, err := r.FullPointer(noop)
+	assert.NoError(t, err)
+	assert.True(t, alias, "aliased full pointer not reported")
+	assert.Equal(t, id, id2)
+}
+
+func Test_FullPointerWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	v := uint32(7)
+	called := 0
+	fn := func() error {
+		called++
+		return w.Uint32(v)
+	}
+	assert.NoError(t, w.FullPointer(&v, fn))
+	assert.NoError(t, w.FullPointer(&v, fn))
+	assert.NoError(t, w.FullPointer(nil, nil))
+	assert.NoError(t, w.Deferred())
+	assert.Equal(t, 1, called, "aliased referent written more than once")
+	assert.Equal(t, "00000200"+"00000200"+"00000000"+"07000000", hex.EncodeToString(buf.Bytes()))
+}
+
+func Test_PointerArray(t *testing.T) {
+	sid1, _ := ConvertStrToSID("S-1-5-32-544")
+	sid3, _ := ConvertStrToSID("S-1-5-18")
+	v := []*RPCSID{sid1, nil, sid3}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, WritePointerArray(w, v))
+	assert.NoError(t, w.Deferred())
+	// The pointers are followed by the referents in array order
+	assert.Equal(t, "03000000"+"00000200"+"00000000"+"04000200"+
+		"02000000"+"010200000000000520000000"+"20020000"+
+		"01000000"+"010100000000000512000000", hex.EncodeToString(buf.Bytes()))
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	got, err := ReadPointerArray[RPCSID](r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Deferred())
+	assert.Equal(t, v, got)
+}
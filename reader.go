@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 )
 
 // Byte sizes of primitive types
@@ -23,18 +24,70 @@ const (
 
 // Reader reads simple byte stream data into a Go representations
 type Reader struct {
-	r *bufio.Reader // source of the data
+	r        *bufio.Reader       // source of the data
+	opts     options             // encoding options
+	n        int                 // number of bytes consumed, used for alignment
+	alloc    int                 // bytes allocated for arrays, checked against the allocation limit
+	refs     map[uint32]struct{} // referent IDs of the pointers read so far
+	deferred []func() error      // referents of embedded pointers waiting to be read
+	path     []string            // path of the field being read, used to locate decoding errors
 }
 
 // NewReader creates a new instance of a simple Reader.
-func NewReader(r io.Reader) *Reader {
+// The data is consumed from r as it is needed through an internal buffer bounded by the buffer size option, so r can
+// be a stream such as a named pipe rather than a complete payload held in memory. As the buffer may read ahead of the
+// value being decoded, r should not be read from directly while the Reader is in use.
+func NewReader(r io.Reader, opts ...Option) *Reader {
 	reader := new(Reader)
-	reader.r = bufio.NewReader(r)
+	reader.opts = newOptions(opts)
+	reader.r = bufio.NewReaderSize(r, reader.opts.bufferSize)
 	return reader
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
-	return r.r.Read(p)
+	n, err = r.r.Read(p)
+	r.n += n
+	return
+}
+
+// SetByteOrder sets the byte order used to decode integers from the rest of the stream, for example as indicated by
+// the data representation of the stream.
+func (r *Reader) SetByteOrder(order binary.ByteOrder) {
+	r.opts.order = order
+}
+
+// ByteOrder returns the byte order used to decode integers.
+func (r *Reader) ByteOrder() binary.ByteOrder {
+	return r.opts.order
+}
+
+// Offset returns the number of bytes consumed from the stream.
+func (r *Reader) Offset() int {
+	return r.n
+}
+
+// Align discards the padding needed to place the next value on a multiple of n bytes. It does nothing if the Reader
+// is packed.
+func (r *Reader) Align(n int) error {
+	if r.opts.packed {
+		return nil
+	}
+	return r.Discard((n - r.n%n) % n)
+}
+
+// Discard skips the next n bytes of the stream.
+func (r *Reader) Discard(n int) error {
+	m, err := r.r.Discard(n)
+	r.n += m
+	if err != nil {
+		return fmt.Errorf("error discarding bytes from stream: %w", err)
+	}
+	return nil
+}
+
+// Buffered returns the number of bytes that have been read from the underlying stream but not yet consumed.
+func (r *Reader) Buffered() int {
+	return r.r.Buffered()
 }
 
 func (r *Reader) Uint8() (uint8, error) {
@@ -42,6 +95,7 @@ func (r *Reader) Uint8() (uint8, error) {
 	if err != nil {
 		return uint8(0), err
 	}
+	r.n++
 	return uint8(b), nil
 }
 
@@ -50,7 +104,7 @@ func (r *Reader) Uint16() (uint16, error) {
 	if err != nil {
 		return uint16(0), err
 	}
-	return binary.LittleEndian.Uint16(b), nil
+	return r.opts.order.Uint16(b), nil
 }
 
 func (r *Reader) Uint32() (uint32, error) {
@@ -58,7 +112,7 @@ func (r *Reader) Uint32() (uint32, error) {
 	if err != nil {
 		return uint32(0), err
 	}
-	return binary.LittleEndian.Uint32(b), nil
+	return r.opts.order.Uint32(b), nil
 }
 
 func (r *Reader) Uint64() (uint64, error) {
@@ -66,9 +120,11 @@ func (r *Reader) Uint64() (uint64, error) {
 	if err != nil {
 		return uint64(0), err
 	}
-	return binary.LittleEndian.Uint64(b), nil
+	return r.opts.order.Uint64(b), nil
 }
 
+// FileTime reads a FILETIME as its LowDateTime and HighDateTime without aligning the stream. FileTime.FromReader
+// aligns it to 4 bytes as NDR does.
 func (r *Reader) FileTime() (f FileTime, err error) {
 	f.LowDateTime, err = r.Uint32()
 	if err != nil {
@@ -81,17 +137,21 @@ func (r *Reader) FileTime() (f FileTime, err error) {
 	return
 }
 
+// RPCSid reads an RPC_SID without the max count of its conformant SubAuthority array, which precedes the enclosing
+// structure when the RPC_SID is embedded in another type.
 func (r *Reader) RPCSid() (sid RPCSID, err error) {
-	rb, err := r.r.ReadByte()
+	sid.Revision, err = r.Uint8()
 	if err != nil {
 		return
 	}
-	sid.Revision = rb
-	cb, err := r.r.ReadByte()
+	sid.SubAuthorityCount, err = r.Uint8()
+	if err != nil {
+		return
+	}
+	err = CheckRange("RPC_SID SubAuthorityCount", uint64(sid.SubAuthorityCount), 0, MaxSubAuthorities)
 	if err != nil {
 		return
 	}
-	sid.SubAuthorityCount = cb
 	ib, err := r.ReadBytes(6)
 	if err != nil {
 		return
@@ -110,27 +170,41 @@ func (r *Reader) RPCSid() (sid RPCSID, err error) {
 
 // UTF16String returns a string that is UTF16 encoded in a byte slice. n is the number of bytes representing the string
 func (r *Reader) UTF16String(n int) (str string, err error) {
-	//Length divided by 2 as each run is 16bits = 2bytes
-	s := make([]rune, n/2, n/2)
-	for i := 0; i < len(s); i++ {
-		var u uint16
-		u, err = r.Uint16()
-		if err != nil {
-			return
+	if n < 0 || uint64(n/2) > math.MaxUint32 {
+		err = fmt.Errorf("invalid UTF16 string length %d", n)
+		return
+	}
+	_, err = r.Allocate(uint32(n/2), SizeUint16)
+	if err != nil {
+		return
+	}
+	b, err := r.ReadBytes(n)
+	if err != nil {
+		return
+	}
+	if r.opts.order == binary.BigEndian {
+		for i := 0; i+1 < len(b); i += 2 {
+			b[i], b[i+1] = b[i+1], b[i]
 		}
-		s[i] = rune(u)
 	}
-	str = string(s)
-	return
+	return decodeUTF16LE(b, r.opts.strictUTF16)
 }
 
-// readBytes returns a number of bytes from the NDR byte stream.
+// ReadBytes returns a number of bytes from the NDR byte stream.
+// Counts larger than the buffer size are read a buffer at a time so memory is only committed as data arrives.
 func (r *Reader) ReadBytes(n int) ([]byte, error) {
-	//TODO make this take an int64 as input to allow for larger values on all systems?
-	b := make([]byte, n, n)
-	m, err := r.r.Read(b)
-	if err != nil || m != n {
-		return b, fmt.Errorf("error reading bytes from stream: %v", err)
+	if n < 0 {
+		return nil, fmt.Errorf("error reading bytes from stream: invalid count %d", n)
+	}
+	b := make([]byte, 0, min(n, r.opts.bufferSize))
+	for len(b) < n {
+		l := len(b)
+		b = append(b, make([]byte, min(n-l, r.opts.bufferSize))...)
+		m, err := io.ReadFull(r.r, b[l:])
+		r.n += m
+		if err != nil {
+			return b[:l+m], fmt.Errorf("error reading bytes from stream: %w", err)
+		}
 	}
 	return b, nil
 }
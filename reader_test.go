@@ -0,0 +1,38 @@
+package mstypes
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReaderStream(t *testing.T) {
+	b := make([]byte, 1000)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	// A stream that only returns a single byte for each read, as a transport might.
+	r := NewReader(iotest.OneByteReader(bytes.NewReader(b)), WithBufferSize(16))
+	u, err := r.Uint32()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x03020100), u)
+	p, err := r.ReadBytes(900)
+	assert.NoError(t, err)
+	assert.Equal(t, b[4:904], p, "bytes read across the buffer not as expected")
+	assert.NoError(t, r.Align(8))
+	assert.Equal(t, 904, r.Offset())
+	_, err = r.ReadBytes(200)
+	assert.Error(t, err, "short stream not detected")
+	assert.Equal(t, 1000, r.Offset())
+}
+
+func Test_ReaderStreamDataErr(t *testing.T) {
+	r := NewReader(iotest.DataErrReader(bytes.NewReader([]byte{1, 0, 0, 0, 2, 0})))
+	u, err := r.Uint32()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), u)
+	_, err = r.Uint32()
+	assert.Error(t, err, "truncated value not detected")
+}
@@ -0,0 +1,132 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+// RPCString implements the counted ANSI string RPC_STRING of MS-SAMR, also defined as STRING by MS-LSAD. The characters
+// are kept as transmitted; use Decode to convert them with the codepage of the peer.
+type RPCString struct {
+	Length        uint16 // The length, in bytes, of the string held by Buffer.
+	MaximumLength uint16 // The size, in bytes, of Buffer. This value MUST not be less than Length.
+	Buffer        []byte // The characters of the string in an ANSI or OEM codepage.
+}
+
+// OEMString is the OEM_STRING counted string. It has the layout of RPCString with characters in an OEM codepage such
+// as CodepageOEM437.
+type OEMString = RPCString
+
+// NewRPCString returns an RPCString holding s encoded with the codepage cp. An error wrapping ErrStringTooLong is
+// returned if the encoding exceeds MaxCountedStringLength.
+func NewRPCString(s string, cp Codepage) (RPCString, error) {
+	b, err := cp.Encode(s)
+	if err != nil {
+		return RPCString{}, err
+	}
+	length, err := countedLength(len(b))
+	if err != nil {
+		return RPCString{}, err
+	}
+	return RPCString{Length: length, MaximumLength: length, Buffer: b}, nil
+}
+
+// Decode returns the string converted from the codepage cp.
+func (s *RPCString) Decode(cp Codepage) (string, error) {
+	return cp.Decode(s.Buffer)
+}
+
+// String returns the string converted from ISO 8859-1.
+func (s *RPCString) String() string {
+	v, _ := s.Decode(CodepageLatin1)
+	return v
+}
+
+// FromReader reads the RPCString from r. The string buffer is read when r.Deferred is called.
+func (s *RPCString) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	s.Length, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	s.MaximumLength, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Buffer", func() error {
+		max, err := r.Conformance()
+		if err != nil {
+			return err
+		}
+		offset, actual, err := r.Variance()
+		if err != nil {
+			return err
+		}
+		if uint64(offset)+uint64(actual) > uint64(max) {
+			return fmt.Errorf("string offset %d and actual count %d exceed the maximum count %d", offset, actual, max)
+		}
+		n, err := r.Allocate(actual, SizeChar)
+		if err != nil {
+			return err
+		}
+		s.Buffer, err = r.ReadBytes(n)
+		if err != nil {
+			return err
+		}
+		if int(s.Length) < len(s.Buffer) {
+			s.Buffer = s.Buffer[:s.Length]
+		}
+		return nil
+	})
+	return
+}
+
+// ToWriter writes the RPCString to w. The Length written is taken from the Buffer and the MaximumLength written is
+// raised to the Length if it is smaller. An empty string with a MaximumLength of zero is written as a null pointer.
+func (s *RPCString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	length, err := countedLength(len(s.Buffer))
+	if err != nil {
+		return
+	}
+	maxLength := max(s.MaximumLength, length)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(maxLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if maxLength > 0 {
+		fn = func() error {
+			err := nw.Conformance(uint32(maxLength))
+			if err != nil {
+				return err
+			}
+			err = nw.Variance(0, uint32(length))
+			if err != nil {
+				return err
+			}
+			return nw.WriteBytes(s.Buffer)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of RPCString.
+func (s *RPCString) Size() int {
+	return ndrSize(s)
+}
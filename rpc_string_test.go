@@ -0,0 +1,25 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RPCString(t *testing.T) {
+	s, err := NewRPCString("café", CodepageLatin1)
+	assert.NoError(t, err)
+	b, err := Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, "0400040000000200"+"04000000"+"0000000004000000"+"636166e9", hex.EncodeToString(b))
+	assert.Equal(t, len(b), s.Size())
+
+	var got RPCString
+	assert.NoError(t, Unmarshal(b, &got))
+	assert.Equal(t, s, got)
+	assert.Equal(t, "café", got.String())
+
+	_, err = NewRPCString("€", CodepageLatin1)
+	assert.Error(t, err, "character outside the codepage not detected")
+}
@@ -1,5 +1,10 @@
 package mstypes
 
+import (
+	"fmt"
+	"io"
+)
+
 // RPCUnicodeString implements https://msdn.microsoft.com/en-us/library/cc230365.aspx
 type RPCUnicodeString struct {
 	Length        uint16 // The length, in bytes, of the string pointed to by the Buffer member, not including the terminating null character if any. The length MUST be a multiple of 2. The length SHOULD equal the entire size of the Buffer, in which case there is no terminating null character. Any method that accesses this structure MUST use the Length specified instead of relying on the presence or absence of a null character.
@@ -7,6 +12,16 @@ type RPCUnicodeString struct {
 	Value         string `ndr:"pointer,conformant,varying,skipnull"` // skipnull tag is a workaround for the NDR encoder/decoder
 }
 
+// NewRPCUnicodeString returns an RPCUnicodeString holding s with the Length and MaximumLength of its UTF-16 encoding.
+// An error wrapping ErrStringTooLong is returned if the encoding exceeds MaxCountedStringLength.
+func NewRPCUnicodeString(s string) (RPCUnicodeString, error) {
+	length, err := countedLength(len(wideChars(s, false)) * SizeUint16)
+	if err != nil {
+		return RPCUnicodeString{}, err
+	}
+	return RPCUnicodeString{Length: length, MaximumLength: length, Value: s}, nil
+}
+
 // String returns the RPCUnicodeString string value
 func (r *RPCUnicodeString) String() string {
 	return r.Value
@@ -15,3 +30,315 @@ func (r *RPCUnicodeString) String() string {
 type PRPCUnicodeString struct {
 	Data *RPCUnicodeString `ndr:"pointer"`
 }
+
+// FromReader reads the RPCUnicodeString from r. The string buffer is read when r.Deferred is called.
+func (r *RPCUnicodeString) FromReader(rd *Reader) (err error) {
+	err = rd.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	r.Length, err = rd.Uint16()
+	if err != nil {
+		return
+	}
+	r.MaximumLength, err = rd.Uint16()
+	if err != nil {
+		return
+	}
+	_, err = rd.fieldPointer("Buffer", func() error {
+		u, _, err := rd.wideChars()
+		if err != nil {
+			return err
+		}
+		// The string is Length bytes long whatever the buffer holds beyond it
+		if n := int(r.Length / SizeUint16); n < len(u) {
+			u = u[:n]
+		}
+		r.Value, err = rd.wideString(u, false)
+		return err
+	})
+	return
+}
+
+// ToWriter writes the RPCUnicodeString to w. The Length written is taken from the Value and the MaximumLength written
+// is raised to the Length if it is smaller. An empty string with a MaximumLength of zero is written as a null pointer.
+func (r *RPCUnicodeString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	u := wideChars(r.Value, false)
+	length, err := countedLength(len(u) * SizeUint16)
+	if err != nil {
+		return
+	}
+	maxLength := max(r.MaximumLength, length)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(maxLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if maxLength > 0 {
+		fn = func() error {
+			return nw.wideChars(u, uint32(maxLength/SizeUint16))
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of RPCUnicodeString.
+func (r *RPCUnicodeString) Size() int {
+	return ndrSize(r)
+}
+
+// FromReader reads the pointer to an RPCUnicodeString from r. The RPCUnicodeString is read when r.Deferred is called.
+func (p *PRPCUnicodeString) FromReader(r *Reader) (err error) {
+	_, err = r.Pointer(func() error {
+		p.Data = new(RPCUnicodeString)
+		return p.Data.FromReader(r)
+	})
+	return
+}
+
+// ToWriter writes the pointer to an RPCUnicodeString to w. A nil Data is written as a null pointer.
+func (p *PRPCUnicodeString) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	var fn func() error
+	if p.Data != nil {
+		fn = func() error {
+			return p.Data.ToWriter(nw)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of PRPCUnicodeString.
+func (p *PRPCUnicodeString) Size() int {
+	return ndrSize(p)
+}
+
+// RPCUnicodeStringExact is an RPC_UNICODE_STRING kept exactly as transmitted so it can be encoded again byte for
+// byte. Unlike RPCUnicodeString it holds the buffer characters beyond Length, such as a terminating null character or
+// stale data Windows left in the buffer, and the counts of the buffer array, which some password change and hashing
+// operations depend on.
+type RPCUnicodeStringExact struct {
+	Length        uint16   // The length, in bytes, of the string.
+	MaximumLength uint16   // The maximum size, in bytes, of the buffer.
+	NullBuffer    bool     // The buffer pointer is null.
+	MaxCount      uint32   // The maximum count of the buffer array, normally MaximumLength / 2.
+	Offset        uint32   // The offset of the buffer array, normally 0.
+	Buffer        []uint16 // The characters of the buffer array transmitted.
+}
+
+// NewRPCUnicodeStringExact returns the RPCUnicodeStringExact that s is encoded as. An error wrapping ErrStringTooLong
+// is returned if the string exceeds MaxCountedStringLength.
+func NewRPCUnicodeStringExact(s RPCUnicodeString) (RPCUnicodeStringExact, error) {
+	u := wideChars(s.Value, false)
+	length, err := countedLength(len(u) * SizeUint16)
+	if err != nil {
+		return RPCUnicodeStringExact{}, err
+	}
+	maxLength := max(s.MaximumLength, length)
+	return RPCUnicodeStringExact{
+		Length:        length,
+		MaximumLength: maxLength,
+		NullBuffer:    maxLength == 0,
+		MaxCount:      uint32(maxLength / SizeUint16),
+		Buffer:        u,
+	}, nil
+}
+
+// String returns the string of the first Length bytes of the buffer, replacing invalid UTF-16 by U+FFFD.
+func (s *RPCUnicodeStringExact) String() string {
+	u := s.Buffer
+	if n := int(s.Length / SizeUint16); n < len(u) {
+		u = u[:n]
+	}
+	v, _ := decodeUTF16(u, false)
+	return v
+}
+
+// RPCUnicodeString returns the RPCUnicodeString holding the string, keeping the MaximumLength.
+func (s *RPCUnicodeStringExact) RPCUnicodeString() RPCUnicodeString {
+	v := s.String()
+	return RPCUnicodeString{Length: uint16(len(wideChars(v, false)) * SizeUint16), MaximumLength: s.MaximumLength, Value: v}
+}
+
+// FromReader reads the RPCUnicodeStringExact from r. The string buffer is read when r.Deferred is called.
+func (s *RPCUnicodeStringExact) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	s.Length, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	s.MaximumLength, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	ok, err := r.fieldPointer("Buffer", func() (err error) {
+		s.MaxCount, err = r.Conformance()
+		if err != nil {
+			return
+		}
+		var actual uint32
+		s.Offset, actual, err = r.Variance()
+		if err != nil {
+			return
+		}
+		if uint64(s.Offset)+uint64(actual) > uint64(s.MaxCount) {
+			return fmt.Errorf("string offset %d and actual count %d exceed the maximum count %d", s.Offset, actual, s.MaxCount)
+		}
+		s.Buffer, err = r.wideCharArray(actual)
+		return
+	})
+	s.NullBuffer = !ok
+	return
+}
+
+// ToWriter writes the RPCUnicodeStringExact to w exactly as it is held.
+func (s *RPCUnicodeStringExact) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(s.Length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(s.MaximumLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if !s.NullBuffer {
+		fn = func() error {
+			err := nw.Conformance(s.MaxCount)
+			if err != nil {
+				return err
+			}
+			err = nw.Variance(s.Offset, uint32(len(s.Buffer)))
+			if err != nil {
+				return err
+			}
+			return nw.wideCharArray(s.Buffer)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of RPCUnicodeStringExact.
+func (s *RPCUnicodeStringExact) Size() int {
+	return ndrSize(s)
+}
+
+// ReadRPCUnicodeStrings reads a conformant array of RPC_UNICODE_STRING, such as the names passed to LsarLookupNames.
+// The string buffers are read, in array order, when r.Deferred is called.
+func ReadRPCUnicodeStrings(r *Reader) ([]RPCUnicodeString, error) {
+	return ReadConformantArray(r, 8, func(s *RPCUnicodeString) error {
+		return s.FromReader(r)
+	})
+}
+
+// WriteRPCUnicodeStrings writes v as a conformant array of RPC_UNICODE_STRING. The string buffers are written, in
+// array order, when w.Deferred is called.
+func WriteRPCUnicodeStrings(w *Writer, v []RPCUnicodeString) error {
+	return WriteConformantArray(w, v, func(s *RPCUnicodeString) error {
+		return s.ToWriter(w)
+	})
+}
+
+// RPCUnicodeStringArray is an element count and a pointer to a conformant array of RPC_UNICODE_STRING, the layout of
+// SAMR name lists such as SAMPR_RETURNED_USTRING_ARRAY.
+type RPCUnicodeStringArray struct {
+	Count   uint32
+	Element []RPCUnicodeString // A nil Element is written as a null pointer.
+}
+
+// NewRPCUnicodeStringArray returns an RPCUnicodeStringArray holding the strings v. An error wrapping
+// ErrStringTooLong is returned if a string exceeds MaxCountedStringLength.
+func NewRPCUnicodeStringArray(v []string) (a RPCUnicodeStringArray, err error) {
+	a = RPCUnicodeStringArray{Count: uint32(len(v)), Element: make([]RPCUnicodeString, len(v))}
+	for i, s := range v {
+		a.Element[i], err = NewRPCUnicodeString(s)
+		if err != nil {
+			return RPCUnicodeStringArray{}, fmt.Errorf("string %d: %w", i, err)
+		}
+	}
+	return
+}
+
+// Strings returns the strings of the array.
+func (a *RPCUnicodeStringArray) Strings() []string {
+	v := make([]string, len(a.Element))
+	for i := range a.Element {
+		v[i] = a.Element[i].Value
+	}
+	return v
+}
+
+// FromReader reads the RPCUnicodeStringArray from r. The array is read when r.Deferred is called.
+func (a *RPCUnicodeStringArray) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	a.Count, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	_, err = r.fieldPointer("Element", func() (err error) {
+		a.Element, err = ReadRPCUnicodeStrings(r)
+		return
+	})
+	return
+}
+
+// ToWriter writes the RPCUnicodeStringArray to w.
+func (a *RPCUnicodeStringArray) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(a.Count)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if a.Element != nil {
+		fn = func() error {
+			return WriteRPCUnicodeStrings(nw, a.Element)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of RPCUnicodeStringArray.
+func (a *RPCUnicodeStringArray) Size() int {
+	return ndrSize(a)
+}
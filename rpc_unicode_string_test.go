@@ -3,6 +3,7 @@ package mstypes
 import (
 	"bytes"
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/jfjallid/ndr"
@@ -30,3 +31,81 @@ func Test_RPCUnicodeString(t *testing.T) {
 	}
 	assert.Equal(t, TestRPCUnicodeStringValue, a.RPCStr.Value, "String value not as expected")
 }
+
+func Test_NewRPCUnicodeString(t *testing.T) {
+	s, err := NewRPCUnicodeString(TestRPCUnicodeStringValue)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(18), s.Length)
+	assert.Equal(t, uint16(18), s.MaximumLength)
+	assert.Equal(t, TestRPCUnicodeStringValue, s.String())
+
+	// Characters beyond Length are not part of the string
+	b, err := Marshal(&s)
+	assert.NoError(t, err)
+	b[0] = 8
+	var got RPCUnicodeString
+	assert.NoError(t, Unmarshal(b, &got))
+	assert.Equal(t, "test", got.Value)
+
+	// A surrogate pair counts as two UTF-16 characters
+	s, err = NewRPCUnicodeString("\U0001F600")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(4), s.Length)
+}
+
+func Test_CountedStringLimits(t *testing.T) {
+	long := strings.Repeat("a", MaxCountedStringLength/2+1)
+	_, err := NewRPCUnicodeString(long)
+	assert.ErrorIs(t, err, ErrStringTooLong)
+	_, err = NewRPCUnicodeString(long[1:])
+	assert.NoError(t, err)
+	_, err = NewRPCUnicodeStringArray([]string{"a", long})
+	assert.ErrorIs(t, err, ErrStringTooLong)
+	_, err = Marshal(&RPCUnicodeString{Value: long})
+	assert.ErrorIs(t, err, ErrStringTooLong, "long string truncated when encoding")
+	_, err = NewRPCUnicodeStringExact(RPCUnicodeString{Value: long})
+	assert.ErrorIs(t, err, ErrStringTooLong)
+
+	_, err = NewRPCString(strings.Repeat("a", MaxCountedStringLength+1), CodepageLatin1)
+	assert.ErrorIs(t, err, ErrStringTooLong)
+	_, err = Marshal(&RPCString{Buffer: make([]byte, MaxCountedStringLength+1)})
+	assert.ErrorIs(t, err, ErrStringTooLong)
+}
+
+func Test_RPCUnicodeStringExact(t *testing.T) {
+	// Length excludes the terminating null character held in a larger buffer
+	b, _ := hex.DecodeString("0400080000000200" + "04000000" + "0000000003000000" + "610062000000")
+	var s RPCUnicodeStringExact
+	assert.NoError(t, Unmarshal(b, &s))
+	assert.Equal(t, "ab", s.String())
+	got, err := Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, b, got, "not re-encoded byte-identically")
+
+	u := s.RPCUnicodeString()
+	assert.Equal(t, uint16(8), u.MaximumLength, "MaximumLength not kept")
+	e, err := NewRPCUnicodeStringExact(u)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(4), e.MaxCount)
+	assert.Equal(t, []uint16{'a', 'b'}, e.Buffer)
+
+	e, err = NewRPCUnicodeStringExact(RPCUnicodeString{})
+	assert.NoError(t, err)
+	assert.True(t, e.NullBuffer, "empty string not encoded with a null buffer")
+}
+
+func Test_RPCUnicodeStringArray(t *testing.T) {
+	a, err := NewRPCUnicodeStringArray([]string{"ab", "c"})
+	assert.NoError(t, err)
+	b, err := Marshal(&a)
+	assert.NoError(t, err)
+	// The string structures are followed by their buffers in array order
+	assert.Equal(t, "02000000"+"00000200"+"02000000"+
+		"0400040004000200"+"0200020008000200"+
+		"02000000"+"0000000002000000"+"61006200"+
+		"01000000"+"0000000001000000"+"6300", hex.EncodeToString(b))
+
+	var got RPCUnicodeStringArray
+	assert.NoError(t, Unmarshal(b, &got))
+	assert.Equal(t, []string{"ab", "c"}, got.Strings())
+}
@@ -0,0 +1,91 @@
+package mstypes
+
+import (
+	"io"
+)
+
+// S4UDelegationInfo implements the S4U_DELEGATION_INFO buffer of MS-PAC listing the services a ticket was delegated
+// through.
+type S4UDelegationInfo struct {
+	S4U2proxyTarget      RPCUnicodeString   // The name of the principal to which the application can forward the ticket.
+	TransitedListSize    uint32             // The number of entries in S4UTransitedServices.
+	S4UTransitedServices []RPCUnicodeString // A pointer to TransitedListSize service names, nil if the pointer is null.
+}
+
+// PACBufferType returns PACBufferTypeS4UDelegationInfo.
+func (s *S4UDelegationInfo) PACBufferType() uint32 {
+	return PACBufferTypeS4UDelegationInfo
+}
+
+// UnmarshalBinary reads the S4UDelegationInfo from the type serialized PAC buffer b.
+func (s *S4UDelegationInfo) UnmarshalBinary(b []byte) error {
+	return UnmarshalTypeSerialized(b, s)
+}
+
+// MarshalBinary returns the S4UDelegationInfo as a type serialized PAC buffer.
+func (s *S4UDelegationInfo) MarshalBinary() ([]byte, error) {
+	return MarshalTypeSerialized(s)
+}
+
+// FromReader reads the S4UDelegationInfo from r. The referents of its pointers are read when r.Deferred is called.
+func (s *S4UDelegationInfo) FromReader(r *Reader) (err error) {
+	err = r.Field("S4U2proxyTarget", func() error {
+		return s.S4U2proxyTarget.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	s.TransitedListSize, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	s.S4UTransitedServices = nil
+	_, err = r.fieldPointer("S4UTransitedServices", func() (err error) {
+		s.S4UTransitedServices, err = ReadConformantArray(r, 8, func(u *RPCUnicodeString) error {
+			return u.FromReader(r)
+		})
+		return
+	})
+	return
+}
+
+// ToWriter writes the S4UDelegationInfo to w. The TransitedListSize is written as held; nil S4UTransitedServices are
+// written as a null pointer.
+func (s *S4UDelegationInfo) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = s.S4U2proxyTarget.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.TransitedListSize)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if s.S4UTransitedServices != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, s.S4UTransitedServices, func(u *RPCUnicodeString) error {
+				return u.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of S4UDelegationInfo.
+func (s *S4UDelegationInfo) Size() int {
+	return ndrSize(s)
+}
+
+// TransitedServices returns the names of the services the ticket was delegated through, in order.
+func (s *S4UDelegationInfo) TransitedServices() []string {
+	v := make([]string, len(s.S4UTransitedServices))
+	for i := range s.S4UTransitedServices {
+		v[i] = s.S4UTransitedServices[i].Value
+	}
+	return v
+}
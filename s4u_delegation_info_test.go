@@ -0,0 +1,34 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestS4UDelegationInfo is a type serialized S4U_DELEGATION_INFO of a ticket to cifs/fs.test.gokrb5 delegated through
+// two services.
+const TestS4UDelegationInfo = "01100800cccccccce000000000000000000002002600260004000200020000000800020013000000000000001300000063006900660073002f00660073002e00" +
+	"74006500730074002e0067006f006b00720062003500000002000000280028000c00020040004000100002001400000000000000140000006800740074007000" +
+	"2f007700650062002e0074006500730074002e0067006f006b0072006200350020000000000000002000000068006f00730074002f007300760063002e007400" +
+	"6500730074002e0067006f006b00720062003500400054004500530054002e0047004f004b0052004200350000000000"
+
+func Test_S4UDelegationInfo(t *testing.T) {
+	b, _ := hex.DecodeString(TestS4UDelegationInfo)
+	var s S4UDelegationInfo
+	err := s.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "cifs/fs.test.gokrb5", s.S4U2proxyTarget.Value, "S4U2proxy target not as expected")
+	assert.Equal(t, uint32(2), s.TransitedListSize, "transited list size not as expected")
+	assert.Equal(t, []string{"http/web.test.gokrb5", "host/svc.test.gokrb5@TEST.GOKRB5"}, s.TransitedServices(), "transited services not as expected")
+	assert.Equal(t, PACBufferTypeS4UDelegationInfo, s.PACBufferType(), "PAC buffer type not as expected")
+
+	enc, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestS4UDelegationInfo, hex.EncodeToString(enc), "encoding not as expected")
+}
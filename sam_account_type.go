@@ -0,0 +1,94 @@
+package mstypes
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SAMAccountType implements the SAM_ACCOUNT_TYPE values of the sAMAccountType attribute of MS-ADTS, which classifies
+// a security principal by the kind of SAM object it is.
+type SAMAccountType uint32
+
+// SAM_ACCOUNT_TYPE values
+const (
+	SAMDomainObject           SAMAccountType = 0x00000000 // SAM_DOMAIN_OBJECT
+	SAMGroupObject            SAMAccountType = 0x10000000 // SAM_GROUP_OBJECT
+	SAMNonSecurityGroupObject SAMAccountType = 0x10000001 // SAM_NON_SECURITY_GROUP_OBJECT
+	SAMAliasObject            SAMAccountType = 0x20000000 // SAM_ALIAS_OBJECT
+	SAMNonSecurityAliasObject SAMAccountType = 0x20000001 // SAM_NON_SECURITY_ALIAS_OBJECT
+	SAMUserObject             SAMAccountType = 0x30000000 // SAM_USER_OBJECT, also SAM_NORMAL_USER_ACCOUNT
+	SAMMachineAccount         SAMAccountType = 0x30000001 // SAM_MACHINE_ACCOUNT
+	SAMTrustAccount           SAMAccountType = 0x30000002 // SAM_TRUST_ACCOUNT
+	SAMAppBasicGroup          SAMAccountType = 0x40000000 // SAM_APP_BASIC_GROUP
+	SAMAppQueryGroup          SAMAccountType = 0x40000001 // SAM_APP_QUERY_GROUP
+	SAMAccountTypeMax         SAMAccountType = 0x7fffffff // SAM_ACCOUNT_TYPE_MAX
+	SAMNormalUserAccount                     = SAMUserObject
+)
+
+// ParseSAMAccountType parses the decimal string LDAP returns the sAMAccountType attribute as.
+func ParseSAMAccountType(s string) (SAMAccountType, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse sAMAccountType %q: %w", s, err)
+	}
+	return SAMAccountType(v), nil
+}
+
+// IsNormalUser reports whether the account is a user account that is not a machine or trust account.
+func (t SAMAccountType) IsNormalUser() bool {
+	return t == SAMNormalUserAccount
+}
+
+// IsMachineAccount reports whether the account is the account of a workstation, server or domain controller.
+func (t SAMAccountType) IsMachineAccount() bool {
+	return t == SAMMachineAccount
+}
+
+// IsTrustAccount reports whether the account is the account of an interdomain trust.
+func (t SAMAccountType) IsTrustAccount() bool {
+	return t == SAMTrustAccount
+}
+
+// IsUser reports whether the object is a user object of any kind: a normal user, machine or trust account.
+func (t SAMAccountType) IsUser() bool {
+	return t == SAMNormalUserAccount || t == SAMMachineAccount || t == SAMTrustAccount
+}
+
+// IsGroup reports whether the object is a group or alias, of any kind.
+func (t SAMAccountType) IsGroup() bool {
+	switch t {
+	case SAMGroupObject, SAMNonSecurityGroupObject, SAMAliasObject, SAMNonSecurityAliasObject, SAMAppBasicGroup,
+		SAMAppQueryGroup:
+		return true
+	}
+	return false
+}
+
+// String returns the name of the account type.
+func (t SAMAccountType) String() string {
+	switch t {
+	case SAMDomainObject:
+		return "DomainObject"
+	case SAMGroupObject:
+		return "GroupObject"
+	case SAMNonSecurityGroupObject:
+		return "NonSecurityGroupObject"
+	case SAMAliasObject:
+		return "AliasObject"
+	case SAMNonSecurityAliasObject:
+		return "NonSecurityAliasObject"
+	case SAMNormalUserAccount:
+		return "NormalUserAccount"
+	case SAMMachineAccount:
+		return "MachineAccount"
+	case SAMTrustAccount:
+		return "TrustAccount"
+	case SAMAppBasicGroup:
+		return "AppBasicGroup"
+	case SAMAppQueryGroup:
+		return "AppQueryGroup"
+	case SAMAccountTypeMax:
+		return "AccountTypeMax"
+	}
+	return fmt.Sprintf("SAMAccountType(0x%x)", uint32(t))
+}
@@ -0,0 +1,44 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SAMAccountType(t *testing.T) {
+	var tests = []struct {
+		s       string
+		want    SAMAccountType
+		name    string
+		user    bool
+		machine bool
+		trust   bool
+		group   bool
+	}{
+		{"805306368", SAMNormalUserAccount, "NormalUserAccount", true, false, false, false},
+		{"805306369", SAMMachineAccount, "MachineAccount", false, true, false, false},
+		{"805306370", SAMTrustAccount, "TrustAccount", false, false, true, false},
+		{"268435456", SAMGroupObject, "GroupObject", false, false, false, true},
+		{"536870912", SAMAliasObject, "AliasObject", false, false, false, true},
+		{"0", SAMDomainObject, "DomainObject", false, false, false, false},
+	}
+	for _, test := range tests {
+		a, err := ParseSAMAccountType(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, test.want, a, "sAMAccountType %s not as expected", test.s)
+		assert.Equal(t, test.name, a.String(), "name of sAMAccountType %s not as expected", test.s)
+		assert.Equal(t, test.user, a.IsNormalUser(), "IsNormalUser of %s not as expected", a)
+		assert.Equal(t, test.machine, a.IsMachineAccount(), "IsMachineAccount of %s not as expected", a)
+		assert.Equal(t, test.trust, a.IsTrustAccount(), "IsTrustAccount of %s not as expected", a)
+		assert.Equal(t, test.user || test.machine || test.trust, a.IsUser(), "IsUser of %s not as expected", a)
+		assert.Equal(t, test.group, a.IsGroup(), "IsGroup of %s not as expected", a)
+	}
+	assert.Equal(t, "SAMAccountType(0x30000003)", SAMAccountType(0x30000003).String(), "unknown type not as expected")
+	for _, s := range []string{"", "-1", "4294967296", "0x30000000"} {
+		_, err := ParseSAMAccountType(s)
+		assert.Error(t, err, "invalid sAMAccountType %q not rejected", s)
+	}
+}
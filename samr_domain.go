@@ -0,0 +1,83 @@
+package mstypes
+
+//go:generate go run ./cmd/ndrgen -type DomainPasswordInformation samr_domain.go
+
+import (
+	"fmt"
+	"time"
+)
+
+// PasswordProperties are the DOMAIN_PASSWORD_ flags of the PasswordProperties of DOMAIN_PASSWORD_INFORMATION of
+// MS-SAMR and the pwdProperties attribute of MS-ADTS.
+type PasswordProperties uint32
+
+// Password property flags
+const (
+	PasswordComplex        PasswordProperties = 0x00000001 // DOMAIN_PASSWORD_COMPLEX
+	PasswordNoAnonChange   PasswordProperties = 0x00000002 // DOMAIN_PASSWORD_NO_ANON_CHANGE
+	PasswordNoClearChange  PasswordProperties = 0x00000004 // DOMAIN_PASSWORD_NO_CLEAR_CHANGE
+	PasswordLockoutAdmins  PasswordProperties = 0x00000008 // DOMAIN_LOCKOUT_ADMINS
+	PasswordStoreCleartext PasswordProperties = 0x00000010 // DOMAIN_PASSWORD_STORE_CLEARTEXT
+	PasswordRefuseChange   PasswordProperties = 0x00000020 // DOMAIN_REFUSE_PASSWORD_CHANGE
+)
+
+var passwordPropertyNames = []flagName[PasswordProperties]{
+	{PasswordComplex, "Complex"},
+	{PasswordNoAnonChange, "NoAnonChange"},
+	{PasswordNoClearChange, "NoClearChange"},
+	{PasswordLockoutAdmins, "LockoutAdmins"},
+	{PasswordStoreCleartext, "StoreCleartext"},
+	{PasswordRefuseChange, "RefuseChange"},
+}
+
+// Has reports whether all the flags f are set.
+func (p PasswordProperties) Has(f PasswordProperties) bool {
+	return p&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (p PasswordProperties) String() string {
+	return formatFlags(p, passwordPropertyNames)
+}
+
+// DomainPasswordInformation implements DOMAIN_PASSWORD_INFORMATION of MS-SAMR, the password policy of a domain
+// returned by SamrGetUserDomainPasswordInformation and the DomainPasswordInformation class of
+// SamrQueryInformationDomain. The ages are negative FILETIME intervals: use MaxAge and MinAge for their durations.
+type DomainPasswordInformation struct {
+	MinPasswordLength     uint16
+	PasswordHistoryLength uint16
+	PasswordProperties    PasswordProperties
+	MaxPasswordAge        FileTime // An OLD_LARGE_INTEGER interval, the most negative one or 0 if passwords never expire.
+	MinPasswordAge        FileTime // An OLD_LARGE_INTEGER interval, 0 if passwords can be changed at once.
+}
+
+// PasswordsExpire reports whether the policy sets a maximum password age.
+func (p *DomainPasswordInformation) PasswordsExpire() bool {
+	return !p.MaxPasswordAge.IsZero() && !p.MaxPasswordAge.IsNeverInterval()
+}
+
+// MaxAge returns the maximum password age, 0 if passwords never expire.
+func (p *DomainPasswordInformation) MaxAge() time.Duration {
+	if !p.PasswordsExpire() {
+		return 0
+	}
+	return p.MaxPasswordAge.Duration()
+}
+
+// MinAge returns the minimum password age, 0 if passwords can be changed at once.
+func (p *DomainPasswordInformation) MinAge() time.Duration {
+	if p.MinPasswordAge.IsZero() || p.MinPasswordAge.IsNeverInterval() {
+		return 0
+	}
+	return p.MinPasswordAge.Duration()
+}
+
+// String returns the policy in a form suited to an audit report.
+func (p *DomainPasswordInformation) String() string {
+	maxAge := "never"
+	if p.PasswordsExpire() {
+		maxAge = p.MaxAge().String()
+	}
+	return fmt.Sprintf("MinLength=%d History=%d MaxAge=%s MinAge=%s Properties=%s", p.MinPasswordLength,
+		p.PasswordHistoryLength, maxAge, p.MinAge(), p.PasswordProperties)
+}
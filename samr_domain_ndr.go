@@ -0,0 +1,121 @@
+// Code generated by ndrgen; DO NOT EDIT.
+
+package mstypes
+
+import (
+	"io"
+)
+
+// FromReader reads the NDR representation of DomainPasswordInformation from r.
+func (s *DomainPasswordInformation) FromReader(r *Reader) (err error) {
+	err = r.Align(4)
+	if err != nil {
+		return
+	}
+	err = r.Field("MinPasswordLength", func() (err error) {
+		{
+			v, err := r.Uint16()
+			if err != nil {
+				return err
+			}
+			s.MinPasswordLength = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("PasswordHistoryLength", func() (err error) {
+		{
+			v, err := r.Uint16()
+			if err != nil {
+				return err
+			}
+			s.PasswordHistoryLength = v
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("PasswordProperties", func() (err error) {
+		{
+			v, err := r.Uint32()
+			if err != nil {
+				return err
+			}
+			s.PasswordProperties = PasswordProperties(v)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("MaxPasswordAge", func() (err error) {
+		err = s.MaxPasswordAge.FromReader(r)
+		if err != nil {
+			return
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("MinPasswordAge", func() (err error) {
+		err = s.MinPasswordAge.FromReader(r)
+		if err != nil {
+			return
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of DomainPasswordInformation to w.
+func (s *DomainPasswordInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(4)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(s.MinPasswordLength)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(s.PasswordHistoryLength)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(uint32(s.PasswordProperties))
+	if err != nil {
+		return
+	}
+	err = s.MaxPasswordAge.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = s.MinPasswordAge.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of DomainPasswordInformation.
+func (s *DomainPasswordInformation) Size() int {
+	n := 0
+	n += (2 - n%2) % 2
+	n += 2
+	n += (2 - n%2) % 2
+	n += 2
+	n += (4 - n%4) % 4
+	n += 4
+	n += (4 - n%4) % 4
+	n += s.MaxPasswordAge.Size()
+	n += (4 - n%4) % 4
+	n += s.MinPasswordAge.Size()
+	return n
+}
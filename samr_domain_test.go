@@ -0,0 +1,47 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DomainPasswordInformation(t *testing.T) {
+	p := DomainPasswordInformation{
+		MinPasswordLength:     7,
+		PasswordHistoryLength: 24,
+		PasswordProperties:    PasswordComplex | PasswordStoreCleartext,
+		MaxPasswordAge:        FileTimeFromInterval(42 * 24 * time.Hour),
+		MinPasswordAge:        FileTimeFromInterval(24 * time.Hour),
+	}
+	e := encodeTypeSerialized(t, &p)
+	assert.Equal(t, "00000200"+"0700"+"1800"+"11000000"+"0080a60affdeffff"+"004096d536ffffff"+"00000000",
+		hex.EncodeToString(e[16:]), "encoding not as expected")
+	var d DomainPasswordInformation
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, p, d, "decoded password information not as expected")
+	assert.Equal(t, p.Size(), 24, "size not as expected")
+
+	assert.True(t, d.PasswordsExpire(), "passwords do not expire")
+	assert.Equal(t, 42*24*time.Hour, d.MaxAge(), "maximum age not as expected")
+	assert.Equal(t, 24*time.Hour, d.MinAge(), "minimum age not as expected")
+	assert.Equal(t, "MinLength=7 History=24 MaxAge=1008h0m0s MinAge=24h0m0s Properties=Complex|StoreCleartext",
+		d.String(), "string not as expected")
+
+	for _, max := range []FileTime{{}, fileTimeFromTicks(-1 << 63)} {
+		n := DomainPasswordInformation{MaxPasswordAge: max}
+		assert.False(t, n.PasswordsExpire(), "passwords with maximum age %d expire", max.Interval())
+		assert.Equal(t, time.Duration(0), n.MaxAge(), "maximum age not as expected")
+		assert.Equal(t, "MinLength=0 History=0 MaxAge=never MinAge=0s Properties=0", n.String(),
+			"string not as expected")
+	}
+}
+
+func Test_PasswordProperties(t *testing.T) {
+	p := PasswordComplex | PasswordRefuseChange | 0x100
+	assert.Equal(t, "Complex|RefuseChange|0x100", p.String(), "string not as expected")
+	assert.True(t, p.Has(PasswordRefuseChange), "flag not found")
+	assert.False(t, p.Has(PasswordLockoutAdmins), "flag found")
+}
@@ -0,0 +1,123 @@
+package mstypes
+
+import (
+	"io"
+)
+
+// SAMRIDEnumeration implements SAMPR_RID_ENUMERATION of MS-SAMR, the relative ID and name of an account returned by
+// SamrEnumerateUsersInDomain, SamrEnumerateGroupsInDomain and SamrEnumerateAliasesInDomain.
+type SAMRIDEnumeration struct {
+	RelativeID uint32
+	Name       RPCUnicodeString
+}
+
+// FromReader reads the SAMRIDEnumeration from r. The name is read when r.Deferred is called.
+func (e *SAMRIDEnumeration) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	e.RelativeID, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	return r.Field("Name", func() error {
+		return e.Name.FromReader(r)
+	})
+}
+
+// ToWriter writes the SAMRIDEnumeration to w.
+func (e *SAMRIDEnumeration) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(e.RelativeID)
+	if err != nil {
+		return
+	}
+	err = e.Name.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMRIDEnumeration.
+func (e *SAMRIDEnumeration) Size() int {
+	return ndrSize(e)
+}
+
+// SAMEnumerationBuffer implements SAMPR_ENUMERATION_BUFFER of MS-SAMR, a page of accounts returned by the SAMR
+// enumeration methods.
+type SAMEnumerationBuffer struct {
+	EntriesRead uint32
+	Buffer      []SAMRIDEnumeration // A pointer to EntriesRead SAMPR_RID_ENUMERATION, nil if the pointer is null.
+}
+
+// RelativeID returns the relative ID of the account name, compared case insensitively as SAM does, false if it is not
+// in the buffer.
+func (b *SAMEnumerationBuffer) RelativeID(name string) (uint32, bool) {
+	for i := range b.Buffer {
+		if EqualFoldWindows(b.Buffer[i].Name.Value, name) {
+			return b.Buffer[i].RelativeID, true
+		}
+	}
+	return 0, false
+}
+
+// FromReader reads the SAMEnumerationBuffer from r. The entries are read when r.Deferred is called. EntriesRead must
+// match the number of entries, 0 for a null pointer.
+func (b *SAMEnumerationBuffer) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	b.EntriesRead, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Buffer", "EntriesRead", b.EntriesRead, &b.Buffer, func() ([]SAMRIDEnumeration, error) {
+		return ReadConformantArray(r, 12, func(e *SAMRIDEnumeration) error {
+			return e.FromReader(r)
+		})
+	})
+	return
+}
+
+// ToWriter writes the SAMEnumerationBuffer to w. An error is returned if EntriesRead does not match the number of
+// entries.
+func (b *SAMEnumerationBuffer) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("EntriesRead", b.EntriesRead, len(b.Buffer))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(b.EntriesRead)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if b.Buffer != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, b.Buffer, func(e *SAMRIDEnumeration) error {
+				return e.ToWriter(nw)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMEnumerationBuffer.
+func (b *SAMEnumerationBuffer) Size() int {
+	return ndrSize(b)
+}
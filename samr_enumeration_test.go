@@ -0,0 +1,42 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SAMEnumerationBuffer(t *testing.T) {
+	b := SAMEnumerationBuffer{
+		EntriesRead: 2,
+		Buffer: []SAMRIDEnumeration{
+			{RelativeID: 500, Name: RPCUnicodeString{Length: 2, MaximumLength: 2, Value: "A"}},
+			{RelativeID: 501, Name: RPCUnicodeString{Length: 4, MaximumLength: 4, Value: "Gb"}},
+		},
+	}
+	e := encodeTypeSerialized(t, &b)
+	assert.Equal(t, "00000200"+"02000000"+"04000200"+"02000000"+
+		"f4010000"+"0200"+"0200"+"08000200"+
+		"f5010000"+"0400"+"0400"+"0c000200"+
+		"01000000"+"00000000"+"01000000"+"4100"+"0000"+
+		"02000000"+"00000000"+"02000000"+"47006200", hex.EncodeToString(e[16:]), "encoding not as expected")
+
+	var d SAMEnumerationBuffer
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, b, d, "decoded value not as expected")
+
+	rid, ok := d.RelativeID("gB")
+	assert.True(t, ok, "account not found")
+	assert.Equal(t, uint32(501), rid, "relative ID not as expected")
+	_, ok = d.RelativeID("Guest")
+	assert.False(t, ok, "missing account found")
+
+	// EntriesRead must match the entries
+	e[20]++
+	err := UnmarshalTypeSerialized(e, &d)
+	assert.ErrorContains(t, err, "EntriesRead", "expected error decoding EntriesRead not matching")
+	b.EntriesRead = 1
+	_, err = MarshalTypeSerialized(&b)
+	assert.ErrorContains(t, err, "EntriesRead", "expected error encoding EntriesRead not matching")
+}
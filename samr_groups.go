@@ -0,0 +1,268 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+// SAMGetGroupsBuffer implements SAMPR_GET_GROUPS_BUFFER of MS-SAMR, the groups of a user returned by
+// SamrGetGroupsForUser.
+type SAMGetGroupsBuffer struct {
+	MembershipCount uint32
+	Groups          GroupMemberships // A pointer to MembershipCount GROUP_MEMBERSHIP, nil if the pointer is null.
+}
+
+// FromReader reads the SAMGetGroupsBuffer from r. The groups are read when r.Deferred is called. MembershipCount
+// must match the number of elements of Groups, 0 for a null pointer.
+func (b *SAMGetGroupsBuffer) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	b.MembershipCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Groups", "MembershipCount", b.MembershipCount, &b.Groups,
+		func() (GroupMemberships, error) {
+			return readGroupMemberships(r)
+		})
+	return
+}
+
+// ToWriter writes the SAMGetGroupsBuffer to w. An error is returned if MembershipCount does not match the number of
+// elements of Groups.
+func (b *SAMGetGroupsBuffer) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("MembershipCount", b.MembershipCount, len(b.Groups))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(b.MembershipCount)
+	if err != nil {
+		return
+	}
+	err = writeGroupMembershipsPointer(nw, b.Groups)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMGetGroupsBuffer.
+func (b *SAMGetGroupsBuffer) Size() int {
+	return ndrSize(b)
+}
+
+// SAMGetMembersBuffer implements SAMPR_GET_MEMBERS_BUFFER of MS-SAMR, the members of a group returned by
+// SamrGetMembersInGroup as parallel arrays of relative IDs and attributes.
+type SAMGetMembersBuffer struct {
+	MemberCount uint32
+	Members     []uint32 // A pointer to MemberCount relative IDs, nil if the pointer is null.
+	Attributes  []uint32 // A pointer to the MemberCount SE_GROUP attributes of the Members, nil if the pointer is null.
+}
+
+// Memberships returns the members with their attributes. An error is returned if the arrays differ in length.
+func (b *SAMGetMembersBuffer) Memberships() (GroupMemberships, error) {
+	if len(b.Members) != len(b.Attributes) {
+		return nil, fmt.Errorf("%d members with %d attributes", len(b.Members), len(b.Attributes))
+	}
+	g := make(GroupMemberships, len(b.Members))
+	for i := range b.Members {
+		g[i] = GroupMembership{RelativeID: b.Members[i], Attributes: b.Attributes[i]}
+	}
+	return g, nil
+}
+
+// FromReader reads the SAMGetMembersBuffer from r. The arrays are read when r.Deferred is called. MemberCount must
+// match the number of elements of both Members and Attributes, 0 for a null pointer.
+func (b *SAMGetMembersBuffer) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	b.MemberCount, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Members", "MemberCount", b.MemberCount, &b.Members, r.readConformantUint32s)
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Attributes", "MemberCount", b.MemberCount, &b.Attributes, r.readConformantUint32s)
+	return
+}
+
+// ToWriter writes the SAMGetMembersBuffer to w. An error is returned if MemberCount does not match the number of
+// elements of both Members and Attributes.
+func (b *SAMGetMembersBuffer) ToWriter(w io.Writer) (err error) {
+	for _, v := range [][]uint32{b.Members, b.Attributes} {
+		err = checkArrayCount("MemberCount", b.MemberCount, len(v))
+		if err != nil {
+			return
+		}
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(b.MemberCount)
+	if err != nil {
+		return
+	}
+	for _, v := range [][]uint32{b.Members, b.Attributes} {
+		err = nw.Pointer(conformantUint32sFn(nw, v))
+		if err != nil {
+			return
+		}
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMGetMembersBuffer.
+func (b *SAMGetMembersBuffer) Size() int {
+	return ndrSize(b)
+}
+
+// SAMULongArray implements SAMPR_ULONG_ARRAY of MS-SAMR, the relative IDs of the aliases returned by
+// SamrGetAliasMembership.
+type SAMULongArray struct {
+	Count   uint32
+	Element []uint32 // A pointer to Count integers, nil if the pointer is null.
+}
+
+// FromReader reads the SAMULongArray from r. The elements are read when r.Deferred is called. Count must match the
+// number of elements, 0 for a null pointer.
+func (a *SAMULongArray) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	a.Count, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "Element", "Count", a.Count, &a.Element, r.readConformantUint32s)
+	return
+}
+
+// ToWriter writes the SAMULongArray to w. An error is returned if Count does not match the number of elements.
+func (a *SAMULongArray) ToWriter(w io.Writer) (err error) {
+	err = checkArrayCount("Count", a.Count, len(a.Element))
+	if err != nil {
+		return
+	}
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(a.Count)
+	if err != nil {
+		return
+	}
+	err = nw.Pointer(conformantUint32sFn(nw, a.Element))
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMULongArray.
+func (a *SAMULongArray) Size() int {
+	return ndrSize(a)
+}
+
+// SAMPSIDArrayMaxCount is the most SIDs a SAMPR_PSID_ARRAY holds.
+const SAMPSIDArrayMaxCount = 1024
+
+// SAMPSIDArray implements SAMPR_PSID_ARRAY of MS-SAMR, the SIDs of the members of an alias returned by
+// SamrGetMembersInAlias and passed to SamrGetAliasMembership. Each SAMPR_SID_INFORMATION is held as its SID pointer.
+type SAMPSIDArray struct {
+	Count uint32    // The number of SIDs, at most SAMPSIDArrayMaxCount.
+	SIDs  []*RPCSID // A pointer to Count pointers to SIDs, nil if the pointer is null. A nil SID is a null pointer.
+}
+
+// NewSAMPSIDArray returns the SAMPSIDArray holding the SIDs sids.
+func NewSAMPSIDArray(sids ...*RPCSID) SAMPSIDArray {
+	return SAMPSIDArray{Count: uint32(len(sids)), SIDs: sids}
+}
+
+// FromReader reads the SAMPSIDArray from r. The SIDs are read when r.Deferred is called. Count must match the number
+// of SIDs, 0 for a null pointer.
+func (a *SAMPSIDArray) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	a.Count, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = CheckRange("Count", uint64(a.Count), 0, SAMPSIDArrayMaxCount)
+	if err != nil {
+		return
+	}
+	err = readCountedPointer(r, "SIDs", "Count", a.Count, &a.SIDs, func() ([]*RPCSID, error) {
+		return ReadConformantArray(r, 4, func(s **RPCSID) (err error) {
+			*s, err = readSIDPointer(r, "SidPointer")
+			return
+		})
+	})
+	return
+}
+
+// ToWriter writes the SAMPSIDArray to w. An error is returned if Count exceeds SAMPSIDArrayMaxCount or does not match
+// the number of SIDs.
+func (a *SAMPSIDArray) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = CheckRange("Count", uint64(a.Count), 0, SAMPSIDArrayMaxCount)
+	if err != nil {
+		return
+	}
+	err = checkArrayCount("Count", a.Count, len(a.SIDs))
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(a.Count)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if a.SIDs != nil {
+		fn = func() error {
+			return WriteConformantArray(nw, a.SIDs, func(s **RPCSID) error {
+				return writeSIDPointer(nw, *s)
+			})
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMPSIDArray.
+func (a *SAMPSIDArray) Size() int {
+	return ndrSize(a)
+}
+
+// conformantUint32sFn returns the function writing v as the referent of a pointer, or nil if v is nil.
+func conformantUint32sFn(w *Writer, v []uint32) func() error {
+	if v == nil {
+		return nil
+	}
+	return func() error {
+		return w.writeConformantUint32s(v)
+	}
+}
@@ -0,0 +1,100 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SAMGetGroupsBuffer(t *testing.T) {
+	b := SAMGetGroupsBuffer{MembershipCount: 2, Groups: NewGroupMemberships(uint32(SIDAttributesDefaultGroup), 513, 512)}
+	e := encodeTypeSerialized(t, &b)
+	assert.Equal(t, "00000200"+"02000000"+"04000200"+"02000000"+"01020000"+"07000000"+"00020000"+"07000000",
+		hex.EncodeToString(e[16:]), "encoding not as expected")
+	var d SAMGetGroupsBuffer
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, b, d, "decoded value not as expected")
+}
+
+func Test_SAMGetMembersBuffer(t *testing.T) {
+	b := SAMGetMembersBuffer{MemberCount: 2, Members: []uint32{500, 1105}, Attributes: []uint32{7, 7}}
+	e := encodeTypeSerialized(t, &b)
+	assert.Equal(t, "00000200"+"02000000"+"04000200"+"08000200"+"02000000"+"f4010000"+"51040000"+
+		"02000000"+"07000000"+"07000000", hex.EncodeToString(e[16:]), "encoding not as expected")
+	var d SAMGetMembersBuffer
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, b, d, "decoded value not as expected")
+	g, err := d.Memberships()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []uint32{500, 1105}, g.RelativeIDs(), "members not as expected")
+
+	d.Attributes = d.Attributes[:1]
+	_, err = d.Memberships()
+	assert.Error(t, err, "arrays of different lengths not rejected")
+
+	var empty SAMGetMembersBuffer
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &SAMGetMembersBuffer{}), &empty)
+	assert.Nil(t, empty.Members, "null members not decoded as nil")
+}
+
+func Test_SAMULongArray(t *testing.T) {
+	a := SAMULongArray{Count: 3, Element: []uint32{544, 545, 555}}
+	var d SAMULongArray
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &a), &d)
+	assert.Equal(t, a, d, "decoded value not as expected")
+}
+
+func Test_SAMPSIDArray(t *testing.T) {
+	admin, _ := ConvertStrToSID("S-1-5-21-1-2-3-500")
+	user, _ := ConvertStrToSID("S-1-5-21-1-2-3-1105")
+	a := NewSAMPSIDArray(admin, nil, user)
+	e := encodeTypeSerialized(t, &a)
+	var d SAMPSIDArray
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, a, d, "decoded value not as expected")
+	assert.Equal(t, "00000200"+"03000000"+"04000200"+"03000000"+"08000200"+"00000000"+"0c000200",
+		hex.EncodeToString(e[16:44]), "encoding not as expected")
+
+	a.Count = SAMPSIDArrayMaxCount + 1
+	_, err := MarshalTypeSerialized(&a)
+	assert.True(t, errors.Is(err, ErrOutOfRange), "count out of range not rejected: %v", err)
+}
+
+func Test_SAMGroupsCountMismatch(t *testing.T) {
+	sid, _ := ConvertStrToSID("S-1-5-21-1-2-3-500")
+	g := SAMGetGroupsBuffer{MembershipCount: 1, Groups: GroupMemberships{{RelativeID: 513, Attributes: 7}}}
+	m := SAMGetMembersBuffer{MemberCount: 1, Members: []uint32{500}, Attributes: []uint32{7}}
+	l := SAMULongArray{Count: 1, Element: []uint32{544}}
+	p := NewSAMPSIDArray(sid)
+	var tests = []struct {
+		name  string
+		v     NDRType
+		count *uint32
+		d     NDRType
+	}{
+		{"MembershipCount", &g, &g.MembershipCount, &SAMGetGroupsBuffer{}},
+		{"MemberCount", &m, &m.MemberCount, &SAMGetMembersBuffer{}},
+		{"Count", &l, &l.Count, &SAMULongArray{}},
+		{"Count", &p, &p.Count, &SAMPSIDArray{}},
+	}
+	for _, test := range tests {
+		e := encodeTypeSerialized(t, test.v)
+		// The count follows the top level pointer
+		e[20]++
+		err := UnmarshalTypeSerialized(e, test.d)
+		assert.ErrorContains(t, err, test.name, "expected error decoding %T with %s not matching", test.d, test.name)
+
+		*test.count = 2
+		_, err = MarshalTypeSerialized(test.v)
+		assert.ErrorContains(t, err, test.name, "expected error encoding %T with %s not matching", test.v, test.name)
+	}
+
+	// The attributes are as many as the members
+	m = SAMGetMembersBuffer{MemberCount: 2, Members: []uint32{500, 1105}, Attributes: []uint32{7}}
+	_, err := MarshalTypeSerialized(&m)
+	assert.ErrorContains(t, err, "MemberCount", "expected error encoding fewer attributes than members")
+}
@@ -0,0 +1,112 @@
+package mstypes
+
+//go:generate go run ./cmd/ndrgen -type SAMEncryptedUserPassword,SAMEncryptedUserPasswordNew samr_password.go
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// SAMR password buffer sizes
+const (
+	// SAMMaxPasswordLength is the longest password, in UTF-16 characters, a SAMPR_USER_PASSWORD holds.
+	SAMMaxPasswordLength = 256
+	// SAMUserPasswordSize is the size of SAMPR_USER_PASSWORD and SAMPR_ENCRYPTED_USER_PASSWORD.
+	SAMUserPasswordSize = SAMMaxPasswordLength*SizeUint16 + SizeUint32
+	// SAMPasswordSaltSize is the size of the ClearSalt of SAMPR_USER_PASSWORD_NEW.
+	SAMPasswordSaltSize = 16
+	// SAMUserPasswordNewSize is the size of SAMPR_USER_PASSWORD_NEW and SAMPR_ENCRYPTED_USER_PASSWORD_NEW.
+	SAMUserPasswordNewSize = SAMUserPasswordSize + SAMPasswordSaltSize
+)
+
+// SAMEncryptedUserPassword implements SAMPR_ENCRYPTED_USER_PASSWORD of MS-SAMR, an encrypted SAMPR_USER_PASSWORD.
+type SAMEncryptedUserPassword struct {
+	Buffer [SAMUserPasswordSize]byte
+}
+
+// SAMEncryptedUserPasswordNew implements SAMPR_ENCRYPTED_USER_PASSWORD_NEW of MS-SAMR, a SAMPR_USER_PASSWORD_NEW
+// whose SAMPR_USER_PASSWORD is encrypted with a key derived from the salt that follows it in the clear.
+type SAMEncryptedUserPasswordNew struct {
+	Buffer [SAMUserPasswordNewSize]byte
+}
+
+// NewSAMUserPassword returns the SAMPR_USER_PASSWORD of MS-SAMR holding password: its UTF-16LE encoding at the end
+// of the 512 byte buffer with the bytes before it random, followed by its length in bytes.
+func NewSAMUserPassword(password string) ([SAMUserPasswordSize]byte, error) {
+	var b [SAMUserPasswordSize]byte
+	u := wideChars(password, false)
+	if len(u) > SAMMaxPasswordLength {
+		return b, fmt.Errorf("password of %d characters exceeds %d characters: %w", len(u), SAMMaxPasswordLength,
+			ErrStringTooLong)
+	}
+	n := len(u) * SizeUint16
+	start := SAMMaxPasswordLength*SizeUint16 - n
+	_, err := rand.Read(b[:start])
+	if err != nil {
+		return b, err
+	}
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[start+i*SizeUint16:], c)
+	}
+	binary.LittleEndian.PutUint32(b[SAMMaxPasswordLength*SizeUint16:], uint32(n))
+	return b, nil
+}
+
+// ParseSAMUserPassword returns the password held by the decrypted SAMPR_USER_PASSWORD b.
+func ParseSAMUserPassword(b [SAMUserPasswordSize]byte) (string, error) {
+	n := binary.LittleEndian.Uint32(b[SAMMaxPasswordLength*SizeUint16:])
+	if n > SAMMaxPasswordLength*SizeUint16 || n%SizeUint16 != 0 {
+		return "", fmt.Errorf("invalid SAMPR_USER_PASSWORD length %d", n)
+	}
+	u := make([]uint16, n/SizeUint16)
+	start := SAMMaxPasswordLength*SizeUint16 - int(n)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[start+i*SizeUint16:])
+	}
+	s, _ := decodeUTF16(u, false)
+	return s, nil
+}
+
+// NewSAMEncryptedUserPassword returns the SAMPR_ENCRYPTED_USER_PASSWORD of password, the SAMPR_USER_PASSWORD built
+// by NewSAMUserPassword encrypted in place by encrypt, typically RC4 with the session key.
+func NewSAMEncryptedUserPassword(password string, encrypt func(b []byte) error) (SAMEncryptedUserPassword, error) {
+	var p SAMEncryptedUserPassword
+	b, err := NewSAMUserPassword(password)
+	if err != nil {
+		return p, err
+	}
+	err = encrypt(b[:])
+	if err != nil {
+		return p, err
+	}
+	p.Buffer = b
+	return p, nil
+}
+
+// NewSAMEncryptedUserPasswordNew returns the SAMPR_ENCRYPTED_USER_PASSWORD_NEW of password, the SAMPR_USER_PASSWORD
+// built by NewSAMUserPassword encrypted in place by encrypt with the random salt, typically RC4 keyed by the MD5 of the
+// salt and the session key, followed by the salt.
+func NewSAMEncryptedUserPasswordNew(password string, encrypt func(b, salt []byte) error) (SAMEncryptedUserPasswordNew, error) {
+	var p SAMEncryptedUserPasswordNew
+	b, err := NewSAMUserPassword(password)
+	if err != nil {
+		return p, err
+	}
+	salt := p.Buffer[SAMUserPasswordSize:]
+	_, err = rand.Read(salt)
+	if err != nil {
+		return p, err
+	}
+	err = encrypt(b[:], salt)
+	if err != nil {
+		return p, err
+	}
+	copy(p.Buffer[:], b[:])
+	return p, nil
+}
+
+// Salt returns the clear salt following the encrypted SAMPR_USER_PASSWORD.
+func (p *SAMEncryptedUserPasswordNew) Salt() []byte {
+	return p.Buffer[SAMUserPasswordSize:]
+}
@@ -0,0 +1,77 @@
+// Code generated by ndrgen; DO NOT EDIT.
+
+package mstypes
+
+import (
+	"io"
+)
+
+// FromReader reads the NDR representation of SAMEncryptedUserPassword from r.
+func (s *SAMEncryptedUserPassword) FromReader(r *Reader) (err error) {
+	err = r.Field("Buffer", func() (err error) {
+		{
+			b, err := r.ReadBytes(len(s.Buffer))
+			if err != nil {
+				return err
+			}
+			copy(s.Buffer[:], b)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of SAMEncryptedUserPassword to w.
+func (s *SAMEncryptedUserPassword) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.WriteBytes(s.Buffer[:])
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of SAMEncryptedUserPassword.
+func (s *SAMEncryptedUserPassword) Size() int {
+	n := 0
+	n += len(s.Buffer) * 1
+	return n
+}
+
+// FromReader reads the NDR representation of SAMEncryptedUserPasswordNew from r.
+func (s *SAMEncryptedUserPasswordNew) FromReader(r *Reader) (err error) {
+	err = r.Field("Buffer", func() (err error) {
+		{
+			b, err := r.ReadBytes(len(s.Buffer))
+			if err != nil {
+				return err
+			}
+			copy(s.Buffer[:], b)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of SAMEncryptedUserPasswordNew to w.
+func (s *SAMEncryptedUserPasswordNew) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.WriteBytes(s.Buffer[:])
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of SAMEncryptedUserPasswordNew.
+func (s *SAMEncryptedUserPasswordNew) Size() int {
+	n := 0
+	n += len(s.Buffer) * 1
+	return n
+}
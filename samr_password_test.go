@@ -0,0 +1,90 @@
+package mstypes
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewSAMUserPassword(t *testing.T) {
+	b, err := NewSAMUserPassword("Pässw0rd\U0001f600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(20), binary.LittleEndian.Uint32(b[512:]), "length not as expected")
+	assert.Equal(t, []byte{'P', 0, 0xe4, 0, 's', 0, 's', 0, 'w', 0, '0', 0, 'r', 0, 'd', 0, 0x3d, 0xd8, 0x00, 0xde},
+		b[492:512], "password encoding not as expected")
+	assert.NotEqual(t, make([]byte, 492), b[:492], "buffer not filled")
+	s, err := ParseSAMUserPassword(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Pässw0rd\U0001f600", s, "parsed password not as expected")
+
+	_, err = NewSAMUserPassword(strings.Repeat("a", SAMMaxPasswordLength))
+	assert.NoError(t, err, "password of the maximum length rejected")
+	_, err = NewSAMUserPassword(strings.Repeat("a", SAMMaxPasswordLength+1))
+	assert.True(t, errors.Is(err, ErrStringTooLong), "password too long not rejected: %v", err)
+
+	binary.LittleEndian.PutUint32(b[512:], 513)
+	_, err = ParseSAMUserPassword(b)
+	assert.Error(t, err, "invalid length not rejected")
+}
+
+func Test_NewSAMEncryptedUserPassword(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	rc4Encrypt := func(k []byte) func(b []byte) error {
+		return func(b []byte) error {
+			c, err := rc4.NewCipher(k)
+			if err != nil {
+				return err
+			}
+			c.XORKeyStream(b, b)
+			return nil
+		}
+	}
+	p, err := NewSAMEncryptedUserPassword("Secret1", rc4Encrypt(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b [SAMUserPasswordSize]byte
+	copy(b[:], p.Buffer[:])
+	assert.NoError(t, rc4Encrypt(key)(b[:]))
+	s, err := ParseSAMUserPassword(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Secret1", s, "decrypted password not as expected")
+
+	pn, err := NewSAMEncryptedUserPasswordNew("Secret2", func(b, salt []byte) error {
+		h := md5.Sum(append(append([]byte{}, salt...), key...))
+		return rc4Encrypt(h[:])(b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := md5.Sum(append(append([]byte{}, pn.Salt()...), key...))
+	copy(b[:], pn.Buffer[:SAMUserPasswordSize])
+	assert.NoError(t, rc4Encrypt(h[:])(b[:]))
+	s, err = ParseSAMUserPassword(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Secret2", s, "decrypted password not as expected")
+	assert.Len(t, pn.Salt(), SAMPasswordSaltSize, "salt not as expected")
+
+	encErr := errors.New("no session key")
+	_, err = NewSAMEncryptedUserPassword("Secret1", func([]byte) error { return encErr })
+	assert.ErrorIs(t, err, encErr)
+
+	var buf bytes.Buffer
+	assert.NoError(t, p.ToWriter(&buf))
+	assert.Equal(t, p.Buffer[:], buf.Bytes(), "encoding not as expected")
+	assert.Equal(t, SAMUserPasswordNewSize, pn.Size(), "size not as expected")
+}
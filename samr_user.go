@@ -0,0 +1,545 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// UserAllFields is the WhichFields of SAMPR_USER_ALL_INFORMATION of MS-SAMR, selecting the fields that are set.
+type UserAllFields uint32
+
+// USER_ALL WhichFields flags
+const (
+	UserAllUserName           UserAllFields = 0x00000001
+	UserAllFullName           UserAllFields = 0x00000002
+	UserAllUserID             UserAllFields = 0x00000004
+	UserAllPrimaryGroupID     UserAllFields = 0x00000008
+	UserAllAdminComment       UserAllFields = 0x00000010
+	UserAllUserComment        UserAllFields = 0x00000020
+	UserAllHomeDirectory      UserAllFields = 0x00000040
+	UserAllHomeDirectoryDrive UserAllFields = 0x00000080
+	UserAllScriptPath         UserAllFields = 0x00000100
+	UserAllProfilePath        UserAllFields = 0x00000200
+	UserAllWorkStations       UserAllFields = 0x00000400
+	UserAllLastLogon          UserAllFields = 0x00000800
+	UserAllLastLogoff         UserAllFields = 0x00001000
+	UserAllLogonHours         UserAllFields = 0x00002000
+	UserAllBadPasswordCount   UserAllFields = 0x00004000
+	UserAllLogonCount         UserAllFields = 0x00008000
+	UserAllPasswordCanChange  UserAllFields = 0x00010000
+	UserAllPasswordMustChange UserAllFields = 0x00020000
+	UserAllPasswordLastSet    UserAllFields = 0x00040000
+	UserAllAccountExpires     UserAllFields = 0x00080000
+	UserAllUserAccountControl UserAllFields = 0x00100000
+	UserAllParameters         UserAllFields = 0x00200000
+	UserAllCountryCode        UserAllFields = 0x00400000
+	UserAllCodePage           UserAllFields = 0x00800000
+	UserAllNTPasswordPresent  UserAllFields = 0x01000000 // The NtOwfPassword is set, or the NtPasswordPresent on a query.
+	UserAllLMPasswordPresent  UserAllFields = 0x02000000 // The LmOwfPassword is set, or the LmPasswordPresent on a query.
+	UserAllPrivateData        UserAllFields = 0x04000000
+	UserAllPasswordExpired    UserAllFields = 0x08000000
+	UserAllSecurityDescriptor UserAllFields = 0x10000000
+	UserAllOWFPassword        UserAllFields = 0x20000000 // The password fields hold OWF hashes rather than cleartext.
+	UserAllUndefinedMask      UserAllFields = 0xc0000000 // Bits that MUST NOT be set.
+)
+
+// USER_ALL masks of the fields readable with each access right
+const (
+	UserAllReadGeneralMask = UserAllUserName | UserAllFullName | UserAllUserID | UserAllPrimaryGroupID |
+		UserAllAdminComment | UserAllUserComment
+	UserAllReadLogonMask = UserAllHomeDirectory | UserAllHomeDirectoryDrive | UserAllScriptPath | UserAllProfilePath |
+		UserAllWorkStations | UserAllLastLogon | UserAllLastLogoff | UserAllLogonHours | UserAllBadPasswordCount |
+		UserAllLogonCount | UserAllPasswordCanChange | UserAllPasswordMustChange
+	UserAllReadAccountMask = UserAllPasswordLastSet | UserAllAccountExpires | UserAllUserAccountControl |
+		UserAllParameters
+	UserAllReadPreferencesMask = UserAllCountryCode | UserAllCodePage
+)
+
+var userAllFieldNames = []flagName[UserAllFields]{
+	{UserAllUserName, "UserName"},
+	{UserAllFullName, "FullName"},
+	{UserAllUserID, "UserID"},
+	{UserAllPrimaryGroupID, "PrimaryGroupID"},
+	{UserAllAdminComment, "AdminComment"},
+	{UserAllUserComment, "UserComment"},
+	{UserAllHomeDirectory, "HomeDirectory"},
+	{UserAllHomeDirectoryDrive, "HomeDirectoryDrive"},
+	{UserAllScriptPath, "ScriptPath"},
+	{UserAllProfilePath, "ProfilePath"},
+	{UserAllWorkStations, "WorkStations"},
+	{UserAllLastLogon, "LastLogon"},
+	{UserAllLastLogoff, "LastLogoff"},
+	{UserAllLogonHours, "LogonHours"},
+	{UserAllBadPasswordCount, "BadPasswordCount"},
+	{UserAllLogonCount, "LogonCount"},
+	{UserAllPasswordCanChange, "PasswordCanChange"},
+	{UserAllPasswordMustChange, "PasswordMustChange"},
+	{UserAllPasswordLastSet, "PasswordLastSet"},
+	{UserAllAccountExpires, "AccountExpires"},
+	{UserAllUserAccountControl, "UserAccountControl"},
+	{UserAllParameters, "Parameters"},
+	{UserAllCountryCode, "CountryCode"},
+	{UserAllCodePage, "CodePage"},
+	{UserAllNTPasswordPresent, "NTPasswordPresent"},
+	{UserAllLMPasswordPresent, "LMPasswordPresent"},
+	{UserAllPrivateData, "PrivateData"},
+	{UserAllPasswordExpired, "PasswordExpired"},
+	{UserAllSecurityDescriptor, "SecurityDescriptor"},
+	{UserAllOWFPassword, "OWFPassword"},
+}
+
+// Has reports whether all the flags f are set.
+func (f UserAllFields) Has(flags UserAllFields) bool {
+	return f&flags == flags
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (f UserAllFields) String() string {
+	return formatFlags(f, userAllFieldNames)
+}
+
+// RPCShortBlob implements RPC_SHORT_BLOB of MS-SAMR, a counted buffer of 16 bit words holding the OWF password
+// hashes of SAMPR_USER_ALL_INFORMATION. The words are held as their little-endian bytes.
+type RPCShortBlob struct {
+	Length        uint16 // The length, in bytes, of the Buffer.
+	MaximumLength uint16 // The size, in bytes, of the buffer array.
+	Buffer        []byte // The bytes of the buffer, nil if the pointer is null.
+}
+
+// NewRPCShortBlob returns the RPCShortBlob holding b, which must be of an even length.
+func NewRPCShortBlob(b []byte) (RPCShortBlob, error) {
+	length, err := countedLength(len(b))
+	if err != nil {
+		return RPCShortBlob{}, err
+	}
+	if length%SizeUint16 != 0 {
+		return RPCShortBlob{}, fmt.Errorf("short blob of %d bytes is not a multiple of %d", length, SizeUint16)
+	}
+	return RPCShortBlob{Length: length, MaximumLength: length, Buffer: b}, nil
+}
+
+// FromReader reads the RPCShortBlob from r. The buffer is read when r.Deferred is called.
+func (s *RPCShortBlob) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	s.Length, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	s.MaximumLength, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	s.Buffer = nil
+	_, err = r.fieldPointer("Buffer", func() error {
+		u, _, err := r.wideChars()
+		if err != nil {
+			return err
+		}
+		s.Buffer = make([]byte, 0, len(u)*SizeUint16)
+		for _, c := range u {
+			s.Buffer = binary.LittleEndian.AppendUint16(s.Buffer, c)
+		}
+		return nil
+	})
+	return
+}
+
+// ToWriter writes the RPCShortBlob to w. The Length written is taken from the Buffer and the MaximumLength written is
+// raised to the Length if it is smaller.
+func (s *RPCShortBlob) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	length, err := countedLength(len(s.Buffer))
+	if err != nil {
+		return
+	}
+	if length%SizeUint16 != 0 {
+		return fmt.Errorf("short blob of %d bytes is not a multiple of %d", length, SizeUint16)
+	}
+	maxLength := max(s.MaximumLength, length) &^ 1
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(length)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(maxLength)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if s.Buffer != nil {
+		fn = func() error {
+			u := make([]uint16, len(s.Buffer)/SizeUint16)
+			for i := range u {
+				u[i] = binary.LittleEndian.Uint16(s.Buffer[i*SizeUint16:])
+			}
+			return nw.wideChars(u, uint32(maxLength/SizeUint16))
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of RPCShortBlob.
+func (s *RPCShortBlob) Size() int {
+	return ndrSize(s)
+}
+
+// MaxSAMSecurityDescriptorLength is the largest security descriptor SAMPR_SR_SECURITY_DESCRIPTOR holds.
+const MaxSAMSecurityDescriptorLength = 256 * 1024
+
+// SAMSRSecurityDescriptor implements SAMPR_SR_SECURITY_DESCRIPTOR of MS-SAMR, a self-relative security descriptor.
+type SAMSRSecurityDescriptor struct {
+	Length             uint32 // The length of the security descriptor, at most MaxSAMSecurityDescriptorLength.
+	SecurityDescriptor []byte // A pointer to the self-relative security descriptor, nil if the pointer is null.
+}
+
+// FromReader reads the SAMSRSecurityDescriptor from r. The security descriptor is read when r.Deferred is called.
+func (s *SAMSRSecurityDescriptor) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	s.Length, err = r.Uint32()
+	if err != nil {
+		return
+	}
+	err = CheckRange("Length", uint64(s.Length), 0, MaxSAMSecurityDescriptorLength)
+	if err != nil {
+		return
+	}
+	s.SecurityDescriptor = nil
+	_, err = r.fieldPointer("SecurityDescriptor", func() (err error) {
+		s.SecurityDescriptor, err = r.readConformantBytes()
+		return
+	})
+	return
+}
+
+// ToWriter writes the SAMSRSecurityDescriptor to w.
+func (s *SAMSRSecurityDescriptor) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = CheckRange("Length", uint64(s.Length), 0, MaxSAMSecurityDescriptorLength)
+	if err != nil {
+		return
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint32(s.Length)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if s.SecurityDescriptor != nil {
+		fn = func() error {
+			return nw.writeConformantBytes(s.SecurityDescriptor)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMSRSecurityDescriptor.
+func (s *SAMSRSecurityDescriptor) Size() int {
+	return ndrSize(s)
+}
+
+// SAMLogonHoursMaxLength is the size of the buffer array of SAMPR_LOGON_HOURS, enough for a bit per minute of a week.
+const SAMLogonHoursMaxLength = 1260
+
+// SAMLogonHours implements SAMPR_LOGON_HOURS of MS-SAMR, the times of the week an account may log on as a bitmap of
+// UnitsPerWeek units.
+type SAMLogonHours struct {
+	UnitsPerWeek uint16
+	LogonHours   []byte // The (UnitsPerWeek+7)/8 bytes of the bitmap, nil if the pointer is null.
+}
+
+// FromReader reads the SAMLogonHours from r. The bitmap is read when r.Deferred is called.
+func (h *SAMLogonHours) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	h.UnitsPerWeek, err = r.Uint16()
+	if err != nil {
+		return
+	}
+	h.LogonHours = nil
+	_, err = r.fieldPointer("LogonHours", func() error {
+		max, err := r.Conformance()
+		if err != nil {
+			return err
+		}
+		offset, actual, err := r.Variance()
+		if err != nil {
+			return err
+		}
+		if uint64(offset)+uint64(actual) > uint64(max) {
+			return fmt.Errorf("logon hours offset %d and actual count %d exceed the maximum count %d", offset, actual, max)
+		}
+		n, err := r.Allocate(actual, SizeUint8)
+		if err != nil {
+			return err
+		}
+		h.LogonHours, err = r.ReadBytes(n)
+		return err
+	})
+	return
+}
+
+// ToWriter writes the SAMLogonHours to w.
+func (h *SAMLogonHours) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	if len(h.LogonHours) > SAMLogonHoursMaxLength {
+		return fmt.Errorf("logon hours of %d bytes exceed %d bytes", len(h.LogonHours), SAMLogonHoursMaxLength)
+	}
+	err = nw.Align(SizeUint32)
+	if err != nil {
+		return
+	}
+	err = nw.Uint16(h.UnitsPerWeek)
+	if err != nil {
+		return
+	}
+	var fn func() error
+	if h.LogonHours != nil {
+		fn = func() error {
+			err := nw.Conformance(SAMLogonHoursMaxLength)
+			if err != nil {
+				return err
+			}
+			err = nw.Variance(0, uint32(len(h.LogonHours)))
+			if err != nil {
+				return err
+			}
+			return nw.WriteBytes(h.LogonHours)
+		}
+	}
+	err = nw.Pointer(fn)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMLogonHours.
+func (h *SAMLogonHours) Size() int {
+	return ndrSize(h)
+}
+
+// SAMUserAllInformation implements SAMPR_USER_ALL_INFORMATION of MS-SAMR, the UserAllInformation class queried and
+// set with SamrQueryInformationUser and SamrSetInformationUser. WhichFields selects the fields that are set.
+type SAMUserAllInformation struct {
+	LastLogon            FileTime
+	LastLogoff           FileTime
+	PasswordLastSet      FileTime
+	AccountExpires       FileTime
+	PasswordCanChange    FileTime
+	PasswordMustChange   FileTime
+	UserName             RPCUnicodeString
+	FullName             RPCUnicodeString
+	HomeDirectory        RPCUnicodeString
+	HomeDirectoryDrive   RPCUnicodeString
+	ScriptPath           RPCUnicodeString
+	ProfilePath          RPCUnicodeString
+	AdminComment         RPCUnicodeString
+	WorkStations         RPCUnicodeString
+	UserComment          RPCUnicodeString
+	Parameters           RPCUnicodeString
+	LMOWFPassword        RPCShortBlob
+	NTOWFPassword        RPCShortBlob
+	PrivateData          RPCUnicodeStringExact // Opaque data kept as transmitted.
+	SecurityDescriptor   SAMSRSecurityDescriptor
+	UserID               uint32
+	PrimaryGroupID       uint32
+	UserAccountControl   UserAccountFlags
+	WhichFields          UserAllFields
+	LogonHours           SAMLogonHours
+	BadPasswordCount     uint16
+	LogonCount           uint16
+	CountryCode          uint16
+	CodePage             uint16
+	LMPasswordPresent    uint8
+	NTPasswordPresent    uint8
+	PasswordExpired      uint8
+	PrivateDataSensitive uint8
+}
+
+// FromReader reads the SAMUserAllInformation from r. The referents of its pointers are read when r.Deferred is
+// called.
+func (u *SAMUserAllInformation) FromReader(r *Reader) (err error) {
+	for _, v := range []struct {
+		name string
+		ft   *FileTime
+	}{
+		{"LastLogon", &u.LastLogon},
+		{"LastLogoff", &u.LastLogoff},
+		{"PasswordLastSet", &u.PasswordLastSet},
+		{"AccountExpires", &u.AccountExpires},
+		{"PasswordCanChange", &u.PasswordCanChange},
+		{"PasswordMustChange", &u.PasswordMustChange},
+	} {
+		err = r.Field(v.name, func() error {
+			return v.ft.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	for _, v := range []struct {
+		name string
+		s    *RPCUnicodeString
+	}{
+		{"UserName", &u.UserName},
+		{"FullName", &u.FullName},
+		{"HomeDirectory", &u.HomeDirectory},
+		{"HomeDirectoryDrive", &u.HomeDirectoryDrive},
+		{"ScriptPath", &u.ScriptPath},
+		{"ProfilePath", &u.ProfilePath},
+		{"AdminComment", &u.AdminComment},
+		{"WorkStations", &u.WorkStations},
+		{"UserComment", &u.UserComment},
+		{"Parameters", &u.Parameters},
+	} {
+		err = r.Field(v.name, func() error {
+			return v.s.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	for _, v := range []struct {
+		name string
+		b    *RPCShortBlob
+	}{
+		{"LMOWFPassword", &u.LMOWFPassword},
+		{"NTOWFPassword", &u.NTOWFPassword},
+	} {
+		err = r.Field(v.name, func() error {
+			return v.b.FromReader(r)
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = r.Field("PrivateData", func() error {
+		return u.PrivateData.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	err = r.Field("SecurityDescriptor", func() error {
+		return u.SecurityDescriptor.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	var v [4]uint32
+	for i := range v {
+		v[i], err = r.Uint32()
+		if err != nil {
+			return
+		}
+	}
+	u.UserID, u.PrimaryGroupID = v[0], v[1]
+	u.UserAccountControl = UserAccountFlags(v[2])
+	u.WhichFields = UserAllFields(v[3])
+	err = r.Field("LogonHours", func() error {
+		return u.LogonHours.FromReader(r)
+	})
+	if err != nil {
+		return
+	}
+	for _, p := range []*uint16{&u.BadPasswordCount, &u.LogonCount, &u.CountryCode, &u.CodePage} {
+		*p, err = r.Uint16()
+		if err != nil {
+			return
+		}
+	}
+	for _, p := range []*uint8{&u.LMPasswordPresent, &u.NTPasswordPresent, &u.PasswordExpired, &u.PrivateDataSensitive} {
+		*p, err = r.Uint8()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ToWriter writes the SAMUserAllInformation to w.
+func (u *SAMUserAllInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	for _, ft := range []*FileTime{
+		&u.LastLogon, &u.LastLogoff, &u.PasswordLastSet,
+		&u.AccountExpires, &u.PasswordCanChange, &u.PasswordMustChange,
+	} {
+		err = ft.ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	for _, s := range []*RPCUnicodeString{
+		&u.UserName, &u.FullName, &u.HomeDirectory, &u.HomeDirectoryDrive, &u.ScriptPath,
+		&u.ProfilePath, &u.AdminComment, &u.WorkStations, &u.UserComment, &u.Parameters,
+	} {
+		err = s.ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	for _, b := range []*RPCShortBlob{&u.LMOWFPassword, &u.NTOWFPassword} {
+		err = b.ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	err = u.PrivateData.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = u.SecurityDescriptor.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, v := range []uint32{u.UserID, u.PrimaryGroupID, uint32(u.UserAccountControl), uint32(u.WhichFields)} {
+		err = nw.Uint32(v)
+		if err != nil {
+			return
+		}
+	}
+	err = u.LogonHours.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	for _, v := range []uint16{u.BadPasswordCount, u.LogonCount, u.CountryCode, u.CodePage} {
+		err = nw.Uint16(v)
+		if err != nil {
+			return
+		}
+	}
+	for _, v := range []uint8{u.LMPasswordPresent, u.NTPasswordPresent, u.PasswordExpired, u.PrivateDataSensitive} {
+		err = nw.Uint8(v)
+		if err != nil {
+			return
+		}
+	}
+	return nw.topLevel(w)
+}
+
+// Size returns the number of bytes of the NDR representation of SAMUserAllInformation.
+func (u *SAMUserAllInformation) Size() int {
+	return ndrSize(u)
+}
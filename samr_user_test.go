@@ -0,0 +1,81 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserAllFieldsString(t *testing.T) {
+	assert.Equal(t, "UserName|FullName|UserID|PrimaryGroupID|AdminComment|UserComment",
+		UserAllReadGeneralMask.String(), "general mask not as expected")
+	assert.Equal(t, "NTPasswordPresent|OWFPassword|0x80000000",
+		(UserAllNTPasswordPresent | UserAllOWFPassword | 0x80000000).String(), "fields not as expected")
+	assert.True(t, UserAllReadLogonMask.Has(UserAllLogonHours|UserAllLastLogon), "logon fields not found")
+	assert.False(t, UserAllReadAccountMask.Has(UserAllCodePage), "code page found in the account mask")
+}
+
+func Test_RPCShortBlob(t *testing.T) {
+	nt, _ := hex.DecodeString("8846f7eaee8fb117ad06bdd830b7586c")
+	b, err := NewRPCShortBlob(nt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := encodeTypeSerialized(t, &b)
+	assert.Equal(t, "00000200"+"1000"+"1000"+"04000200"+"08000000"+"00000000"+"08000000"+
+		"8846f7eaee8fb117ad06bdd830b7586c", hex.EncodeToString(e[16:]), "encoding not as expected")
+	var d RPCShortBlob
+	decodeTypeSerialized(t, e, &d)
+	assert.Equal(t, b, d, "decoded value not as expected")
+
+	_, err = NewRPCShortBlob([]byte{1, 2, 3})
+	assert.Error(t, err, "odd length not rejected")
+}
+
+func Test_SAMUserAllInformation(t *testing.T) {
+	nt, _ := hex.DecodeString("8846f7eaee8fb117ad06bdd830b7586c")
+	ntBlob, err := NewRPCShortBlob(nt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hours := make([]byte, 21)
+	for i := range hours {
+		hours[i] = 0xff
+	}
+	u := SAMUserAllInformation{
+		LastLogon:          GetFileTime(time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)),
+		PasswordLastSet:    GetFileTime(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)),
+		AccountExpires:     FileTime{LowDateTime: 0xffffffff, HighDateTime: 0x7fffffff},
+		UserName:           RPCUnicodeString{Value: "jdoe"},
+		FullName:           RPCUnicodeString{Value: "John Doe"},
+		HomeDirectory:      RPCUnicodeString{Value: `\\fs\home\jdoe`},
+		AdminComment:       RPCUnicodeString{Value: "Test account"},
+		NTOWFPassword:      ntBlob,
+		PrivateData:        RPCUnicodeStringExact{NullBuffer: true},
+		SecurityDescriptor: SAMSRSecurityDescriptor{Length: 4, SecurityDescriptor: []byte{1, 0, 4, 0x80}},
+		UserID:             1105,
+		PrimaryGroupID:     513,
+		UserAccountControl: UserAccountNormal | UserAccountDontExpirePassword,
+		WhichFields:        UserAllReadGeneralMask | UserAllLogonHours | UserAllNTPasswordPresent | UserAllOWFPassword,
+		LogonHours:         SAMLogonHours{UnitsPerWeek: 168, LogonHours: hours},
+		BadPasswordCount:   2,
+		LogonCount:         40,
+		NTPasswordPresent:  1,
+	}
+	var d SAMUserAllInformation
+	decodeTypeSerialized(t, encodeTypeSerialized(t, &u), &d)
+	// Decoding sets the counted string lengths the encoding derived from the values
+	for _, s := range []*RPCUnicodeString{&u.UserName, &u.FullName, &u.HomeDirectory, &u.AdminComment} {
+		s.Length = uint16(len(s.Value) * 2)
+		s.MaximumLength = s.Length
+	}
+	assert.Equal(t, u, d, "decoded value not as expected")
+	assert.Equal(t, 2+2+4, d.LMOWFPassword.Size(), "null LM OWF password not as expected")
+
+	u.SecurityDescriptor.Length = MaxSAMSecurityDescriptorLength + 1
+	_, err = MarshalTypeSerialized(&u)
+	assert.True(t, errors.Is(err, ErrOutOfRange), "security descriptor length out of range not rejected: %v", err)
+}
@@ -0,0 +1,83 @@
+package mstypes
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// confusableScripts are the scripts whose letters are commonly mistaken for Latin letters.
+var confusableScripts = []*unicode.RangeTable{unicode.Cyrillic, unicode.Greek, unicode.Armenian, unicode.Cherokee}
+
+// Sanitize returns s made safe to print to a terminal or report, since strings decoded from the wire, such as account
+// names and descriptions, are attacker controlled. Invalid UTF-8 bytes, control characters, format characters
+// including bidirectional overrides and zero width characters, line and paragraph separators, whitespace other than
+// the space and private use characters are escaped as \xNN, \uNNNN or \UNNNNNNNN. Fullwidth forms and mathematical
+// letters that render as ASCII are escaped, as are Cyrillic, Greek, Armenian and Cherokee letters in a string that
+// also holds Latin letters. A backslash is escaped as \\ so the escaping is unambiguous.
+func Sanitize(s string) string {
+	mixed := mixesConfusableScripts(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		c, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == utf8.RuneError && n == 1:
+			fmt.Fprintf(&b, `\x%02x`, s[i])
+		case c == '\\':
+			b.WriteString(`\\`)
+		case unsafeRune(c), mixed && isConfusable(c):
+			if c <= 0xffff {
+				fmt.Fprintf(&b, `\u%04x`, c)
+			} else {
+				fmt.Fprintf(&b, `\U%08x`, c)
+			}
+		default:
+			b.WriteString(s[i : i+n])
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// unsafeRune reports whether c is escaped by Sanitize whatever the rest of the string holds.
+func unsafeRune(c rune) bool {
+	switch {
+	case c == ' ':
+		return false
+	case c < 0x20, c == 0x7f:
+		return true
+	case c < 0x7f:
+		return false
+	case c >= 0xff01 && c <= 0xff5e: // Fullwidth ASCII variants
+		return true
+	case c >= 0x1d400 && c <= 0x1d7ff: // Mathematical alphanumeric symbols
+		return true
+	}
+	return unicode.In(c, unicode.Cc, unicode.Cf, unicode.Co, unicode.Zl, unicode.Zp, unicode.Zs) ||
+		!unicode.IsGraphic(c)
+}
+
+// isConfusable reports whether c is a letter of a script commonly mistaken for Latin.
+func isConfusable(c rune) bool {
+	return c >= 0x80 && unicode.IsLetter(c) && unicode.In(c, confusableScripts...)
+}
+
+// mixesConfusableScripts reports whether s holds both Latin letters and letters of a script commonly mistaken for
+// Latin.
+func mixesConfusableScripts(s string) bool {
+	var latin, confusable bool
+	for _, c := range s {
+		switch {
+		case unicode.Is(unicode.Latin, c):
+			latin = true
+		case isConfusable(c):
+			confusable = true
+		}
+		if latin && confusable {
+			return true
+		}
+	}
+	return false
+}
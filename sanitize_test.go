@@ -0,0 +1,34 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sanitize(t *testing.T) {
+	var tests = []struct {
+		In, Out string
+	}{
+		{"Administrator", "Administrator"},
+		{"Domain Admins", "Domain Admins"},
+		{"Åsa Öberg", "Åsa Öberg"},
+		{"Администратор", "Администратор"},
+		{"αβγ", "αβγ"},
+		{"user\x1b[2Jname", `user\u001b[2Jname`},
+		{"line\r\nbreak\t", `line\u000d\u000abreak\u0009`},
+		{"evil\u202etxt.exe", "evil\\u202etxt.exe"}, // right-to-left override
+		{"ad\u200bmin", "ad\\u200bmin"},             // zero width space
+		{"no\u00a0break", "no\\u00a0break"},
+		{"\ue000", "\\ue000"},
+		{"\u0430dmin", "\\u0430dmin"}, // Cyrillic a mixed with Latin
+		{"\uff21\uff24", "\\uff21\\uff24"},
+		{"\U0001d41a", `\U0001d41a`},
+		{"", ``},
+		{"bad\xffutf8", `bad\xffutf8`},
+		{`DOMAIN\user`, `DOMAIN\\user`},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.Out, Sanitize(test.In), "%q not sanitized as expected", test.In)
+	}
+}
@@ -1,19 +1,24 @@
 package mstypes
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+// MaxSubAuthorities is the maximum number of sub authorities of a SID.
+const MaxSubAuthorities = 15
+
 // RPCSID implements https://msdn.microsoft.com/en-us/library/cc230364.aspx
 type RPCSID struct {
 	Revision            uint8    // An 8-bit unsigned integer that specifies the revision level of the SID. This value MUST be set to 0x01.
-	SubAuthorityCount   uint8    // An 8-bit unsigned integer that specifies the number of elements in the SubAuthority array. The maximum number of elements allowed is 15.
+	SubAuthorityCount   uint8    `ndr:"range:0,15"` // An 8-bit unsigned integer that specifies the number of elements in the SubAuthority array. The maximum number of elements allowed is 15.
 	IdentifierAuthority [6]byte  // An RPC_SID_IDENTIFIER_AUTHORITY structure that indicates the authority under which the SID was created. It describes the entity that created the SID. The Identifier Authority value {0,0,0,0,0,5} denotes SIDs created by the NT SID authority.
 	SubAuthority        []uint32 `ndr:"conformant"` // A variable length array of unsigned 32-bit integers that uniquely identifies a principal relative to the IdentifierAuthority. Its length is determined by SubAuthorityCount.
 }
@@ -37,27 +42,23 @@ func (s *RPCSID) String() string {
 	return strb.String()
 }
 
-func (s *RPCSID) ToWriter(w io.Writer) (err error) {
-	err = binary.Write(w, binary.LittleEndian, s.Revision)
-	if err != nil {
-		return
-	}
+// Equal reports whether the SIDs s and o are the same SID.
+func (s *RPCSID) Equal(o *RPCSID) bool {
+	return s.Revision == o.Revision && s.SubAuthorityCount == o.SubAuthorityCount &&
+		s.IdentifierAuthority == o.IdentifierAuthority && slices.Equal(s.SubAuthority, o.SubAuthority)
+}
 
-	err = binary.Write(w, binary.LittleEndian, s.SubAuthorityCount)
-	if err != nil {
-		return
-	}
-	err = binary.Write(w, binary.LittleEndian, s.IdentifierAuthority)
-	if err != nil {
-		return
+// WithRID returns a copy of the domain SID s with the relative ID rid appended, the SID of an account of the domain.
+func (s *RPCSID) WithRID(rid uint32) (*RPCSID, error) {
+	if len(s.SubAuthority) >= MaxSubAuthorities {
+		return nil, fmt.Errorf("SID %s already has %d sub authorities", s, len(s.SubAuthority))
 	}
-	for i := 0; i < int(s.SubAuthorityCount); i++ {
-		err = binary.Write(w, binary.LittleEndian, s.SubAuthority[i])
-		if err != nil {
-			return
-		}
-	}
-	return
+	return &RPCSID{
+		Revision:            s.Revision,
+		SubAuthorityCount:   uint8(len(s.SubAuthority) + 1),
+		IdentifierAuthority: s.IdentifierAuthority,
+		SubAuthority:        append(slices.Clip(s.SubAuthority), rid),
+	}, nil
 }
 
 func ConvertStrToSID(s string) (sid *RPCSID, err error) {
@@ -93,3 +94,65 @@ func ConvertStrToSID(s string) (sid *RPCSID, err error) {
 	sid.SubAuthorityCount = subCount
 	return
 }
+
+// FromReader reads the RPC_SID from r including the max count of its conformant SubAuthority array, which NDR hoists
+// to the front of the structure. Use Reader.RPCSid to read the RPC_SID without it.
+func (s *RPCSID) FromReader(r *Reader) (err error) {
+	max, err := r.Conformance()
+	if err != nil {
+		return
+	}
+	*s, err = r.RPCSid()
+	if err != nil {
+		return
+	}
+	if max != uint32(s.SubAuthorityCount) {
+		err = fmt.Errorf("RPC_SID conformance max count %d does not match the sub authority count %d", max, s.SubAuthorityCount)
+	}
+	return
+}
+
+// ToWriter writes the RPC_SID to w including the max count of its conformant SubAuthority array ahead of the
+// structure. Use Writer.RPCSid to write the RPC_SID without it.
+func (s *RPCSID) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Conformance(uint32(s.SubAuthorityCount))
+	if err != nil {
+		return
+	}
+	err = nw.RPCSid(*s)
+	if err != nil {
+		return
+	}
+	return nw.topLevel(w)
+}
+
+// MarshalBinary returns the SID in its packet representation, the RPC_SID without the max count, as held by the
+// PAC and security descriptors.
+func (s *RPCSID) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	err := NewWriter(&buf).RPCSid(*s)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary sets the RPCSID from the SID packet representation b.
+func (s *RPCSID) UnmarshalBinary(b []byte) error {
+	r := NewReader(bytes.NewReader(b))
+	sid, err := r.RPCSid()
+	if err != nil {
+		return fmt.Errorf("could not read SID: %w", err)
+	}
+	if n := 8 + SizeUint32*int(sid.SubAuthorityCount); n != len(b) {
+		return fmt.Errorf("SID of %d sub authorities is %d bytes, not %d", sid.SubAuthorityCount, n, len(b))
+	}
+	*s = sid
+	return nil
+}
+
+// Size returns the number of bytes of the NDR representation of RPC_SID.
+func (s *RPCSID) Size() int {
+	return SizeUint32 + 8 + SizeUint32*int(s.SubAuthorityCount)
+}
@@ -0,0 +1,48 @@
+package mstypes
+
+import "fmt"
+
+// SIDNameUse implements the SID_NAME_USE enumeration of MS-LSAT and MS-SAMR, the type of the account or object a SID
+// or name identifies. As an NDR enum it is transmitted as 16 bits.
+type SIDNameUse uint16
+
+// SID_NAME_USE values
+const (
+	SIDTypeUser           SIDNameUse = 1
+	SIDTypeGroup          SIDNameUse = 2
+	SIDTypeDomain         SIDNameUse = 3
+	SIDTypeAlias          SIDNameUse = 4
+	SIDTypeWellKnownGroup SIDNameUse = 5
+	SIDTypeDeletedAccount SIDNameUse = 6
+	SIDTypeInvalid        SIDNameUse = 7
+	SIDTypeUnknown        SIDNameUse = 8
+	SIDTypeComputer       SIDNameUse = 9
+	SIDTypeLabel          SIDNameUse = 10
+)
+
+// String returns the name of the SID type.
+func (u SIDNameUse) String() string {
+	switch u {
+	case SIDTypeUser:
+		return "User"
+	case SIDTypeGroup:
+		return "Group"
+	case SIDTypeDomain:
+		return "Domain"
+	case SIDTypeAlias:
+		return "Alias"
+	case SIDTypeWellKnownGroup:
+		return "WellKnownGroup"
+	case SIDTypeDeletedAccount:
+		return "DeletedAccount"
+	case SIDTypeInvalid:
+		return "Invalid"
+	case SIDTypeUnknown:
+		return "Unknown"
+	case SIDTypeComputer:
+		return "Computer"
+	case SIDTypeLabel:
+		return "Label"
+	}
+	return fmt.Sprintf("SIDNameUse(%d)", uint16(u))
+}
@@ -0,0 +1,25 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SIDNameUseString(t *testing.T) {
+	var tests = []struct {
+		u    SIDNameUse
+		want string
+	}{
+		{SIDTypeUser, "User"},
+		{SIDTypeWellKnownGroup, "WellKnownGroup"},
+		{SIDTypeDeletedAccount, "DeletedAccount"},
+		{SIDTypeComputer, "Computer"},
+		{SIDTypeLabel, "Label"},
+		{0, "SIDNameUse(0)"},
+		{11, "SIDNameUse(11)"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.u.String(), "name of %d not as expected", uint16(test.u))
+	}
+}
@@ -49,3 +49,55 @@ func Test_RPCSIDDecode(t *testing.T) {
 
 	}
 }
+
+func Test_RPCSIDConformance(t *testing.T) {
+	b, _ := hex.DecodeString("050000000105000000000005150000004c86cebca07160e63fdce8875a040000")
+	var sid RPCSID
+	err := Unmarshal(b, &sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "S-1-5-21-3167651404-3865080224-2280184895-1114", sid.String())
+	assert.Equal(t, len(b), sid.Size())
+	got, err := Marshal(&sid)
+	assert.NoError(t, err)
+	assert.Equal(t, b, got, "conformance max count not written ahead of the RPC_SID")
+
+	// The max count must match the sub authority count
+	b[0] = 4
+	assert.Error(t, Unmarshal(b, &sid), "conformance mismatch not detected")
+}
+
+func Test_RPCSIDBinary(t *testing.T) {
+	sid, err := ConvertStrToSID("S-1-5-21-1-2-3-1105")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := sid.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "01050000000000051500000001000000020000000300000051040000", hex.EncodeToString(b), "encoding not as expected")
+	var d RPCSID
+	assert.NoError(t, d.UnmarshalBinary(b))
+	assert.Equal(t, *sid, d, "decoded value not as expected")
+	assert.Error(t, d.UnmarshalBinary(append(b, 0)), "trailing byte not detected")
+	assert.Error(t, d.UnmarshalBinary(b[:len(b)-1]), "truncated SID not detected")
+}
+
+func Test_RPCSIDWithRID(t *testing.T) {
+	domain, _ := ConvertStrToSID("S-1-5-21-1-2-3")
+	sid, err := domain.WithRID(1105)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", sid.String(), "SID not as expected")
+	assert.Equal(t, "S-1-5-21-1-2-3", domain.String(), "domain SID modified")
+	user, _ := ConvertStrToSID("S-1-5-21-1-2-3-1105")
+	assert.True(t, sid.Equal(user), "SIDs not equal")
+	assert.False(t, sid.Equal(domain), "SIDs equal")
+
+	full, _ := ConvertStrToSID("S-1-5-1-2-3-4-5-6-7-8-9-10-11-12-13-14-15")
+	_, err = full.WithRID(1)
+	assert.Error(t, err, "sub authority limit not checked")
+}
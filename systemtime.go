@@ -0,0 +1,155 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SystemTimeSize is the number of bytes of a SYSTEMTIME.
+const SystemTimeSize = 8 * SizeUint16
+
+// SystemTime implements the Microsoft SYSTEMTIME type https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-dtyp/2fefe8dd-ab48-4e33-a7d5-7171455a9289
+type SystemTime struct {
+	Year         uint16 // The year, from 1601 to 30827.
+	Month        uint16 // The month, from 1 for January to 12 for December.
+	DayOfWeek    uint16 // The day of the week, from 0 for Sunday to 6 for Saturday.
+	Day          uint16 // The day of the month, from 1 to 31.
+	Hour         uint16
+	Minute       uint16
+	Second       uint16
+	Milliseconds uint16
+}
+
+// NewSystemTime returns the SystemTime of t in UTC, truncated to milliseconds.
+func NewSystemTime(t time.Time) SystemTime {
+	t = t.UTC()
+	return SystemTime{
+		Year:         uint16(t.Year()),
+		Month:        uint16(t.Month()),
+		DayOfWeek:    uint16(t.Weekday()),
+		Day:          uint16(t.Day()),
+		Hour:         uint16(t.Hour()),
+		Minute:       uint16(t.Minute()),
+		Second:       uint16(t.Second()),
+		Milliseconds: uint16(t.Nanosecond() / int(time.Millisecond)),
+	}
+}
+
+// IsZero reports whether all the fields of the SystemTime are zero, which Windows uses for a time that is not set.
+func (st SystemTime) IsZero() bool {
+	return st == SystemTime{}
+}
+
+// Validate returns an error if a field of the SystemTime is out of range. The DayOfWeek is not checked against the
+// date since Windows ignores it when converting a SYSTEMTIME.
+func (st SystemTime) Validate() error {
+	switch {
+	case st.Year < 1601 || st.Year > 30827:
+		return fmt.Errorf("SYSTEMTIME year %d out of range", st.Year)
+	case st.Month < 1 || st.Month > 12:
+		return fmt.Errorf("SYSTEMTIME month %d out of range", st.Month)
+	case st.DayOfWeek > 6:
+		return fmt.Errorf("SYSTEMTIME day of week %d out of range", st.DayOfWeek)
+	case st.Day < 1 || int(st.Day) > daysIn(time.Month(st.Month), int(st.Year)):
+		return fmt.Errorf("SYSTEMTIME day %d out of range for %d-%02d", st.Day, st.Year, st.Month)
+	case st.Hour > 23 || st.Minute > 59 || st.Second > 59 || st.Milliseconds > 999:
+		return fmt.Errorf("SYSTEMTIME time %02d:%02d:%02d.%03d out of range", st.Hour, st.Minute, st.Second, st.Milliseconds)
+	}
+	return nil
+}
+
+// Time returns the SystemTime as a time.Time in UTC. A zero SystemTime is returned as the zero time.Time. Fields out
+// of range are normalized as time.Date does; use Validate to detect them.
+func (st SystemTime) Time() time.Time {
+	if st.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(int(st.Year), time.Month(st.Month), int(st.Day), int(st.Hour), int(st.Minute), int(st.Second),
+		int(st.Milliseconds)*int(time.Millisecond), time.UTC)
+}
+
+// FileTime returns the SystemTime as a FileTime. An error is returned if a field is out of range.
+func (st SystemTime) FileTime() (FileTime, error) {
+	err := st.Validate()
+	if err != nil {
+		return FileTime{}, err
+	}
+	return GetFileTime(st.Time()), nil
+}
+
+// SystemTime returns the FileTime as a SystemTime, truncated to milliseconds. A zero FileTime, or one that IsNever, is
+// returned as a zero SystemTime.
+func (ft FileTime) SystemTime() SystemTime {
+	if ft.IsZero() || ft.IsNever() {
+		return SystemTime{}
+	}
+	return NewSystemTime(ft.Time())
+}
+
+// MarshalBinary returns the 16 byte little-endian encoding of the SystemTime.
+func (st SystemTime) MarshalBinary() ([]byte, error) {
+	b := make([]byte, SystemTimeSize)
+	for i, v := range st.fields() {
+		binary.LittleEndian.PutUint16(b[i*SizeUint16:], *v)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary sets the SystemTime from its 16 byte little-endian encoding.
+func (st *SystemTime) UnmarshalBinary(b []byte) error {
+	if len(b) != SystemTimeSize {
+		return fmt.Errorf("SYSTEMTIME encoding is %d bytes, not %d", len(b), SystemTimeSize)
+	}
+	for i, v := range st.fields() {
+		*v = binary.LittleEndian.Uint16(b[i*SizeUint16:])
+	}
+	return nil
+}
+
+// FromReader reads the SystemTime from r.
+func (st *SystemTime) FromReader(r *Reader) (err error) {
+	err = r.Align(SizeUint16)
+	if err != nil {
+		return
+	}
+	for _, v := range st.fields() {
+		*v, err = r.Uint16()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ToWriter writes the SystemTime to w.
+func (st *SystemTime) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.Align(SizeUint16)
+	if err != nil {
+		return
+	}
+	for _, v := range st.fields() {
+		err = nw.Uint16(*v)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Size returns the number of bytes of the NDR representation of SystemTime.
+func (st *SystemTime) Size() int {
+	return SystemTimeSize
+}
+
+// fields returns the fields of the SystemTime in the order they are encoded.
+func (st *SystemTime) fields() [8]*uint16 {
+	return [8]*uint16{&st.Year, &st.Month, &st.DayOfWeek, &st.Day, &st.Hour, &st.Minute, &st.Second, &st.Milliseconds}
+}
+
+// daysIn returns the number of days of the month m of the year.
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
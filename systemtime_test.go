@@ -0,0 +1,57 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SystemTime(t *testing.T) {
+	tt := time.Date(2007, 2, 22, 17, 0, 1, 638215500, time.UTC)
+	st := NewSystemTime(tt)
+	assert.Equal(t, SystemTime{2007, 2, 4, 22, 17, 0, 1, 638}, st)
+	assert.NoError(t, st.Validate())
+	assert.Equal(t, tt.Truncate(time.Millisecond), st.Time())
+
+	b, err := st.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, "d707"+"0200"+"0400"+"1600"+"1100"+"0000"+"0100"+"7e02", hex.EncodeToString(b))
+	var got SystemTime
+	assert.NoError(t, got.UnmarshalBinary(b))
+	assert.Equal(t, st, got)
+	assert.Error(t, got.UnmarshalBinary(b[:15]), "short encoding not detected")
+
+	nb, err := Marshal(&st)
+	assert.NoError(t, err)
+	assert.Equal(t, b, nb, "NDR encoding not as expected")
+	got = SystemTime{}
+	assert.NoError(t, Unmarshal(nb, &got))
+	assert.Equal(t, st, got)
+
+	ft, err := st.FileTime()
+	assert.NoError(t, err)
+	assert.Equal(t, GetFileTime(tt.Truncate(time.Millisecond)), ft)
+	assert.Equal(t, st, GetFileTime(tt).SystemTime())
+	assert.Equal(t, SystemTime{}, FileTimeNever.SystemTime())
+}
+
+func Test_SystemTimeValidate(t *testing.T) {
+	var tests = []SystemTime{
+		{1600, 12, 0, 31, 0, 0, 0, 0},
+		{2023, 13, 0, 1, 0, 0, 0, 0},
+		{2023, 2, 0, 29, 0, 0, 0, 0},
+		{2023, 1, 7, 1, 0, 0, 0, 0},
+		{2023, 1, 0, 1, 24, 0, 0, 0},
+		{2023, 1, 0, 1, 0, 0, 0, 1000},
+		{},
+	}
+	for _, test := range tests {
+		assert.Error(t, test.Validate(), "%+v not detected as invalid", test)
+		_, err := test.FileTime()
+		assert.Error(t, err)
+	}
+	assert.NoError(t, SystemTime{2024, 2, 4, 29, 23, 59, 59, 999}.Validate())
+	assert.True(t, SystemTime{}.Time().IsZero())
+}
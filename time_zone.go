@@ -0,0 +1,270 @@
+package mstypes
+
+import (
+	"fmt"
+	"io"
+)
+
+// Time zone structure sizes
+const (
+	TimeZoneNameLength                = 32  // WCHAR of the StandardName and DaylightName
+	TimeZoneKeyNameLength             = 128 // WCHAR of the TimeZoneKeyName
+	TimeZoneInformationSize           = 172
+	DynamicTimeZoneInformationSize    = 432 // including the padding after DynamicDaylightTimeDisabled
+	dynamicTimeZoneInformationNDRSize = 429
+	RegTZISize                        = 44
+)
+
+// TimeZoneInformation implements TIME_ZONE_INFORMATION https://learn.microsoft.com/en-us/windows/win32/api/timezoneapi/ns-timezoneapi-time_zone_information
+//
+// The biases are in minutes with UTC = local time + bias. A StandardDate or DaylightDate with a zero Year is a
+// recurring rule: the transition happens on the Day'th DayOfWeek of the Month, 5 meaning the last one. A zero Month
+// means the time zone has no daylight saving time.
+type TimeZoneInformation struct {
+	Bias         int32
+	StandardName string
+	StandardDate SystemTime
+	StandardBias int32
+	DaylightName string
+	DaylightDate SystemTime
+	DaylightBias int32
+}
+
+// DynamicTimeZoneInformation implements DYNAMIC_TIME_ZONE_INFORMATION https://learn.microsoft.com/en-us/windows/win32/api/timezoneapi/ns-timezoneapi-dynamic_time_zone_information
+type DynamicTimeZoneInformation struct {
+	TimeZoneInformation
+	TimeZoneKeyName             string
+	DynamicDaylightTimeDisabled bool
+}
+
+// RegTZI implements the REG_TZI_FORMAT blob held by the TZI value of the time zone keys under
+// HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Time Zones.
+type RegTZI struct {
+	Bias         int32
+	StandardBias int32
+	DaylightBias int32
+	StandardDate SystemTime
+	DaylightDate SystemTime
+}
+
+// HasDaylightTime reports whether the time zone has daylight saving time.
+func (tz *TimeZoneInformation) HasDaylightTime() bool {
+	return tz.DaylightDate.Month != 0
+}
+
+// FromReader reads the TimeZoneInformation from r.
+func (tz *TimeZoneInformation) FromReader(r *Reader) (err error) {
+	tz.Bias, err = readInt32(r)
+	if err != nil {
+		return
+	}
+	tz.StandardName, err = r.fixedWideString(TimeZoneNameLength)
+	if err != nil {
+		return
+	}
+	err = tz.StandardDate.FromReader(r)
+	if err != nil {
+		return
+	}
+	tz.StandardBias, err = readInt32(r)
+	if err != nil {
+		return
+	}
+	tz.DaylightName, err = r.fixedWideString(TimeZoneNameLength)
+	if err != nil {
+		return
+	}
+	err = tz.DaylightDate.FromReader(r)
+	if err != nil {
+		return
+	}
+	tz.DaylightBias, err = readInt32(r)
+	return
+}
+
+// ToWriter writes the TimeZoneInformation to w.
+func (tz *TimeZoneInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = writeInt32(nw, tz.Bias)
+	if err != nil {
+		return
+	}
+	err = nw.fixedWideString(tz.StandardName, TimeZoneNameLength)
+	if err != nil {
+		return fmt.Errorf("StandardName: %w", err)
+	}
+	err = tz.StandardDate.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = writeInt32(nw, tz.StandardBias)
+	if err != nil {
+		return
+	}
+	err = nw.fixedWideString(tz.DaylightName, TimeZoneNameLength)
+	if err != nil {
+		return fmt.Errorf("DaylightName: %w", err)
+	}
+	err = tz.DaylightDate.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	return writeInt32(nw, tz.DaylightBias)
+}
+
+// Size returns the number of bytes of the NDR representation of TimeZoneInformation.
+func (tz *TimeZoneInformation) Size() int {
+	return TimeZoneInformationSize
+}
+
+// MarshalBinary returns the little-endian in-memory layout of the TimeZoneInformation.
+func (tz *TimeZoneInformation) MarshalBinary() ([]byte, error) {
+	return Marshal(tz)
+}
+
+// UnmarshalBinary sets the TimeZoneInformation from its little-endian in-memory layout.
+func (tz *TimeZoneInformation) UnmarshalBinary(b []byte) error {
+	if len(b) != TimeZoneInformationSize {
+		return fmt.Errorf("TIME_ZONE_INFORMATION is %d bytes, not %d", len(b), TimeZoneInformationSize)
+	}
+	return Unmarshal(b, tz)
+}
+
+// FromReader reads the DynamicTimeZoneInformation from r.
+func (tz *DynamicTimeZoneInformation) FromReader(r *Reader) (err error) {
+	err = tz.TimeZoneInformation.FromReader(r)
+	if err != nil {
+		return
+	}
+	tz.TimeZoneKeyName, err = r.fixedWideString(TimeZoneKeyNameLength)
+	if err != nil {
+		return
+	}
+	b, err := r.Uint8()
+	tz.DynamicDaylightTimeDisabled = b != 0
+	return
+}
+
+// ToWriter writes the DynamicTimeZoneInformation to w.
+func (tz *DynamicTimeZoneInformation) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = tz.TimeZoneInformation.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	err = nw.fixedWideString(tz.TimeZoneKeyName, TimeZoneKeyNameLength)
+	if err != nil {
+		return fmt.Errorf("TimeZoneKeyName: %w", err)
+	}
+	var b uint8
+	if tz.DynamicDaylightTimeDisabled {
+		b = 1
+	}
+	return nw.Uint8(b)
+}
+
+// Size returns the number of bytes of the NDR representation of DynamicTimeZoneInformation, which unlike the in-memory
+// layout has no padding after DynamicDaylightTimeDisabled.
+func (tz *DynamicTimeZoneInformation) Size() int {
+	return dynamicTimeZoneInformationNDRSize
+}
+
+// MarshalBinary returns the little-endian in-memory layout of the DynamicTimeZoneInformation.
+func (tz *DynamicTimeZoneInformation) MarshalBinary() ([]byte, error) {
+	b, err := Marshal(tz)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, make([]byte, DynamicTimeZoneInformationSize-len(b))...), nil
+}
+
+// UnmarshalBinary sets the DynamicTimeZoneInformation from its little-endian in-memory layout. The padding after
+// DynamicDaylightTimeDisabled may be omitted.
+func (tz *DynamicTimeZoneInformation) UnmarshalBinary(b []byte) error {
+	if len(b) != DynamicTimeZoneInformationSize && len(b) != dynamicTimeZoneInformationNDRSize {
+		return fmt.Errorf("DYNAMIC_TIME_ZONE_INFORMATION is %d bytes, not %d", len(b), DynamicTimeZoneInformationSize)
+	}
+	return Unmarshal(b[:dynamicTimeZoneInformationNDRSize], tz)
+}
+
+// TimeZoneInformation returns the TimeZoneInformation of the REG_TZI_FORMAT blob with the names provided, which the
+// registry holds in the Std and Dlt values of the time zone key.
+func (t *RegTZI) TimeZoneInformation(standardName, daylightName string) TimeZoneInformation {
+	return TimeZoneInformation{
+		Bias:         t.Bias,
+		StandardName: standardName,
+		StandardDate: t.StandardDate,
+		StandardBias: t.StandardBias,
+		DaylightName: daylightName,
+		DaylightDate: t.DaylightDate,
+		DaylightBias: t.DaylightBias,
+	}
+}
+
+// FromReader reads the RegTZI from r.
+func (t *RegTZI) FromReader(r *Reader) (err error) {
+	for _, v := range []*int32{&t.Bias, &t.StandardBias, &t.DaylightBias} {
+		*v, err = readInt32(r)
+		if err != nil {
+			return
+		}
+	}
+	err = t.StandardDate.FromReader(r)
+	if err != nil {
+		return
+	}
+	return t.DaylightDate.FromReader(r)
+}
+
+// ToWriter writes the RegTZI to w.
+func (t *RegTZI) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	for _, v := range []int32{t.Bias, t.StandardBias, t.DaylightBias} {
+		err = writeInt32(nw, v)
+		if err != nil {
+			return
+		}
+	}
+	err = t.StandardDate.ToWriter(nw)
+	if err != nil {
+		return
+	}
+	return t.DaylightDate.ToWriter(nw)
+}
+
+// Size returns the number of bytes of the RegTZI.
+func (t *RegTZI) Size() int {
+	return RegTZISize
+}
+
+// MarshalBinary returns the REG_TZI_FORMAT blob of the RegTZI.
+func (t *RegTZI) MarshalBinary() ([]byte, error) {
+	return Marshal(t)
+}
+
+// UnmarshalBinary sets the RegTZI from a REG_TZI_FORMAT blob.
+func (t *RegTZI) UnmarshalBinary(b []byte) error {
+	if len(b) != RegTZISize {
+		return fmt.Errorf("REG_TZI_FORMAT is %d bytes, not %d", len(b), RegTZISize)
+	}
+	return Unmarshal(b, t)
+}
+
+// readInt32 reads an aligned LONG.
+func readInt32(r *Reader) (int32, error) {
+	err := r.Align(SizeUint32)
+	if err != nil {
+		return 0, err
+	}
+	v, err := r.Uint32()
+	return int32(v), err
+}
+
+// writeInt32 writes an aligned LONG.
+func writeInt32(w *Writer, v int32) error {
+	err := w.Align(SizeUint32)
+	if err != nil {
+		return err
+	}
+	return w.Uint32(uint32(v))
+}
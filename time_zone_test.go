@@ -0,0 +1,57 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TZI value of the W. Europe Standard Time key
+const TestRegTZI = "c4ffffff00000000c4ffffff" + "00000a00000005000300000000000000" + "00000300000005000200000000000000"
+
+func Test_RegTZI(t *testing.T) {
+	b, _ := hex.DecodeString(TestRegTZI)
+	var tzi RegTZI
+	assert.NoError(t, tzi.UnmarshalBinary(b))
+	assert.Equal(t, RegTZI{
+		Bias:         -60,
+		StandardBias: 0,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 10, Day: 5, Hour: 3},
+		DaylightDate: SystemTime{Month: 3, Day: 5, Hour: 2},
+	}, tzi)
+	enc, err := tzi.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, b, enc)
+	assert.Error(t, tzi.UnmarshalBinary(b[:40]), "short blob not detected")
+
+	tz := tzi.TimeZoneInformation("W. Europe Standard Time", "W. Europe Daylight Time")
+	assert.True(t, tz.HasDaylightTime())
+	enc, err = tz.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, TimeZoneInformationSize, len(enc))
+	var got TimeZoneInformation
+	assert.NoError(t, got.UnmarshalBinary(enc))
+	assert.Equal(t, tz, got)
+}
+
+func Test_DynamicTimeZoneInformation(t *testing.T) {
+	tz := DynamicTimeZoneInformation{
+		TimeZoneInformation: TimeZoneInformation{Bias: -330, StandardName: "India Standard Time", DaylightName: "India Daylight Time"},
+		TimeZoneKeyName:     "India Standard Time",
+	}
+	assert.False(t, tz.HasDaylightTime())
+	b, err := tz.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, DynamicTimeZoneInformationSize, len(b))
+	assert.Equal(t, "b6feffff"+"49006e00", hex.EncodeToString(b[:8]))
+	var got DynamicTimeZoneInformation
+	assert.NoError(t, got.UnmarshalBinary(b))
+	assert.Equal(t, tz, got)
+	assert.NoError(t, got.UnmarshalBinary(b[:429]))
+
+	tz.TimeZoneKeyName = string(make([]rune, TimeZoneKeyNameLength))
+	_, err = tz.MarshalBinary()
+	assert.Error(t, err, "overlong key name not detected")
+}
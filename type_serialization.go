@@ -0,0 +1,238 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+/*
+Type Serialization Version 1 is used to marshal NDR types outside of an RPC call, for example the PAC buffers.
+Ref: https://msdn.microsoft.com/en-us/library/cc243563.aspx
+The serialized top-level type is preceded by an 8 byte common type header and an 8 byte private header. The
+serialized type is padded to a multiple of 8 bytes.
+*/
+
+// Type serialization header values
+const (
+	TypeSerializationVersion      uint8  = 1
+	TypeSerializationLittleEndian uint8  = 0x10
+	TypeSerializationBigEndian    uint8  = 0x00
+	CommonTypeHeaderLength        uint16 = 8
+	CommonTypeHeaderFiller        uint32 = 0xcccccccc
+	PrivateHeaderLength                  = 8
+)
+
+// CommonTypeHeader implements https://msdn.microsoft.com/en-us/library/cc243890.aspx
+type CommonTypeHeader struct {
+	Version            uint8  // MUST be 0x01.
+	Endianness         uint8  // 0x10 for little-endian and 0x00 for big-endian.
+	CommonHeaderLength uint16 // MUST be 0x0008.
+	Filler             uint32 // MUST be 0xcccccccc on marshaling and SHOULD be ignored on unmarshaling.
+}
+
+// PrivateHeader implements https://msdn.microsoft.com/en-us/library/cc243919.aspx
+type PrivateHeader struct {
+	ObjectBufferLength uint32 // The length of the serialized top-level type including padding, excluding the headers.
+	Filler             uint32 // MUST be 0 on marshaling and SHOULD be ignored on unmarshaling.
+}
+
+// NewCommonTypeHeader returns a little-endian CommonTypeHeader.
+func NewCommonTypeHeader() CommonTypeHeader {
+	return CommonTypeHeader{
+		Version:            TypeSerializationVersion,
+		Endianness:         TypeSerializationLittleEndian,
+		CommonHeaderLength: CommonTypeHeaderLength,
+		Filler:             CommonTypeHeaderFiller,
+	}
+}
+
+// NewCommonTypeHeaderWithOrder returns a CommonTypeHeader indicating the byte order provided.
+func NewCommonTypeHeaderWithOrder(order binary.ByteOrder) CommonTypeHeader {
+	h := NewCommonTypeHeader()
+	if order == binary.BigEndian {
+		h.Endianness = TypeSerializationBigEndian
+	}
+	return h
+}
+
+// ByteOrder returns the byte order indicated by the header.
+func (h CommonTypeHeader) ByteOrder() binary.ByteOrder {
+	if h.Endianness == TypeSerializationBigEndian {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// CommonTypeHeader reads and validates a CommonTypeHeader. The byte order of the Reader is set to the byte order
+// indicated by the header so the serialized type that follows is decoded accordingly.
+func (r *Reader) CommonTypeHeader() (h CommonTypeHeader, err error) {
+	b, err := r.ReadBytes(int(CommonTypeHeaderLength))
+	if err != nil {
+		err = fmt.Errorf("could not read common type header: %w", err)
+		return
+	}
+	h.Version = b[0]
+	h.Endianness = b[1] & 0xf0
+	if h.Version != TypeSerializationVersion {
+		err = fmt.Errorf("common type header version %d is not %d", h.Version, TypeSerializationVersion)
+		return
+	}
+	if h.Endianness != TypeSerializationLittleEndian && h.Endianness != TypeSerializationBigEndian {
+		err = fmt.Errorf("common type header does not indicate a valid endianness: 0x%02x", b[1])
+		return
+	}
+	order := h.ByteOrder()
+	h.CommonHeaderLength = order.Uint16(b[2:4])
+	if h.CommonHeaderLength != CommonTypeHeaderLength {
+		err = fmt.Errorf("common type header length %d is not %d", h.CommonHeaderLength, CommonTypeHeaderLength)
+		return
+	}
+	h.Filler = order.Uint32(b[4:8])
+	r.SetByteOrder(order)
+	return
+}
+
+// PrivateHeader reads a PrivateHeader using the byte order indicated by the CommonTypeHeader.
+func (r *Reader) PrivateHeader(ch CommonTypeHeader) (h PrivateHeader, err error) {
+	b, err := r.ReadBytes(PrivateHeaderLength)
+	if err != nil {
+		err = fmt.Errorf("could not read private header: %w", err)
+		return
+	}
+	order := ch.ByteOrder()
+	h.ObjectBufferLength = order.Uint32(b[0:4])
+	h.Filler = order.Uint32(b[4:8])
+	if h.ObjectBufferLength%8 != 0 {
+		err = fmt.Errorf("private header object buffer length %d is not a multiple of 8", h.ObjectBufferLength)
+	}
+	return
+}
+
+// CommonTypeHeader writes the CommonTypeHeader h.
+func (w *Writer) CommonTypeHeader(h CommonTypeHeader) error {
+	order := h.ByteOrder()
+	b := make([]byte, CommonTypeHeaderLength)
+	b[0] = h.Version
+	b[1] = h.Endianness
+	order.PutUint16(b[2:4], h.CommonHeaderLength)
+	order.PutUint32(b[4:8], h.Filler)
+	return w.WriteBytes(b)
+}
+
+// PrivateHeader writes the PrivateHeader h using the byte order indicated by the CommonTypeHeader.
+func (w *Writer) PrivateHeader(ch CommonTypeHeader, h PrivateHeader) error {
+	order := ch.ByteOrder()
+	b := make([]byte, PrivateHeaderLength)
+	order.PutUint32(b[0:4], h.ObjectBufferLength)
+	order.PutUint32(b[4:8], h.Filler)
+	return w.WriteBytes(b)
+}
+
+// WrapTypeSerialized prefixes the NDR encoded top-level type b with little-endian type serialization headers and pads
+// it to a multiple of 8 bytes.
+func WrapTypeSerialized(b []byte) []byte {
+	pad := (8 - len(b)%8) % 8
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	ch := NewCommonTypeHeader()
+	// Writing to a bytes.Buffer can not fail
+	w.CommonTypeHeader(ch)
+	w.PrivateHeader(ch, PrivateHeader{ObjectBufferLength: uint32(len(b) + pad)})
+	w.WriteBytes(b)
+	w.WriteBytes(make([]byte, pad))
+	return buf.Bytes()
+}
+
+// UnwrapTypeSerialized validates the type serialization headers at the start of b and returns the common type header,
+// whose ByteOrder is that of the serialized type, and the serialized top-level type they describe, including its
+// padding.
+func UnwrapTypeSerialized(b []byte) (ch CommonTypeHeader, obj []byte, err error) {
+	r := NewReader(bytes.NewReader(b))
+	ch, err = r.CommonTypeHeader()
+	if err != nil {
+		return
+	}
+	ph, err := r.PrivateHeader(ch)
+	if err != nil {
+		return
+	}
+	start := int(CommonTypeHeaderLength) + PrivateHeaderLength
+	if uint64(ph.ObjectBufferLength) > uint64(len(b)-start) {
+		err = fmt.Errorf("private header object buffer length %d exceeds the %d bytes available", ph.ObjectBufferLength, len(b)-start)
+		return
+	}
+	obj = b[start : start+int(ph.ObjectBufferLength)]
+	return
+}
+
+// UnmarshalTypeSerialized reads v from b, a type serialized top-level pointer to v as found in the PAC buffers,
+// including the referents of the pointers embedded in v. The type is decoded in the byte order indicated by the common
+// type header, with the Reader options opts. A decoding failure is returned as a DecodeError.
+func UnmarshalTypeSerialized(b []byte, v interface{ FromReader(r *Reader) error }, opts ...Option) error {
+	ch, obj, err := UnwrapTypeSerialized(b)
+	if err != nil {
+		return err
+	}
+	r := NewReader(bytes.NewReader(obj), append(opts, WithByteOrder(ch.ByteOrder()))...)
+	return r.Field(typeName(v), func() error {
+		_, err := r.Pointer(func() error {
+			return v.FromReader(r)
+		})
+		if err != nil {
+			return err
+		}
+		return r.Deferred()
+	})
+}
+
+// MarshalTypeSerialized returns v as a type serialized top-level pointer, as found in the PAC buffers.
+func MarshalTypeSerialized(v NDRType) ([]byte, error) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	err := w.Pointer(func() error {
+		return v.ToWriter(w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = w.Deferred()
+	if err != nil {
+		return nil, err
+	}
+	return WrapTypeSerialized(buf.Bytes()), nil
+}
+
+// FromReader reads and validates the CommonTypeHeader from r.
+func (h *CommonTypeHeader) FromReader(r *Reader) (err error) {
+	*h, err = r.CommonTypeHeader()
+	return
+}
+
+// ToWriter writes the CommonTypeHeader to w.
+func (h *CommonTypeHeader) ToWriter(w io.Writer) error {
+	return AsWriter(w).CommonTypeHeader(*h)
+}
+
+// Size returns the number of bytes of the CommonTypeHeader.
+func (h *CommonTypeHeader) Size() int {
+	return int(CommonTypeHeaderLength)
+}
+
+// FromReader reads the PrivateHeader from r using the byte order of r.
+func (h *PrivateHeader) FromReader(r *Reader) (err error) {
+	*h, err = r.PrivateHeader(NewCommonTypeHeaderWithOrder(r.ByteOrder()))
+	return
+}
+
+// ToWriter writes the PrivateHeader to w using the byte order of w.
+func (h *PrivateHeader) ToWriter(w io.Writer) error {
+	nw := AsWriter(w)
+	return nw.PrivateHeader(NewCommonTypeHeaderWithOrder(nw.ByteOrder()), *h)
+}
+
+// Size returns the number of bytes of the PrivateHeader.
+func (h *PrivateHeader) Size() int {
+	return PrivateHeaderLength
+}
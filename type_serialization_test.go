@@ -0,0 +1,104 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnwrapTypeSerialized(t *testing.T) {
+	b, _ := hex.DecodeString(ClientClaimsInfoStr)
+	_, obj, err := UnwrapTypeSerialized(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 256, len(obj), "object buffer length not as expected")
+	assert.Equal(t, b[16:], obj, "object buffer not as expected")
+	assert.Equal(t, b, WrapTypeSerialized(obj), "wrapped bytes not as expected")
+}
+
+func Test_WrapTypeSerializedPadding(t *testing.T) {
+	b := WrapTypeSerialized([]byte{1, 2, 3})
+	assert.Equal(t, "01100800cccccccc"+"0800000000000000"+"0102030000000000", hex.EncodeToString(b))
+	_, obj, err := UnwrapTypeSerialized(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 0, 0, 0, 0, 0}, obj)
+}
+
+func Test_UnwrapTypeSerializedInvalid(t *testing.T) {
+	var tests = []string{
+		"02100800cccccccc0000000000000000",   // version
+		"01100400cccccccc0000000000000000",   // common header length
+		"01100800cccccccc0400000000000000",   // object buffer length not a multiple of 8
+		"01100800cccccccc1000000000000000",   // object buffer length exceeds the data
+		"01100800cccccccc08000000000000",     // short private header
+		"01100800cccccccc0800000000000000aa", // short object buffer
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test)
+		_, _, err := UnwrapTypeSerialized(b)
+		assert.Error(t, err, "test %d: invalid headers not detected", i+1)
+	}
+}
+
+func Test_TypeSerializedBigEndian(t *testing.T) {
+	b, _ := hex.DecodeString("01000008cccccccc" + "0000000800000000" + "0102030400000000")
+	r := NewReader(bytes.NewReader(b))
+	ch, err := r.CommonTypeHeader()
+	assert.NoError(t, err)
+	assert.Equal(t, binary.BigEndian, r.ByteOrder(), "reader byte order not taken from the header")
+	ph, err := r.PrivateHeader(ch)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(8), ph.ObjectBufferLength)
+	u, err := r.Uint32()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x01020304), u)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithByteOrder(binary.BigEndian))
+	ch = NewCommonTypeHeaderWithOrder(w.ByteOrder())
+	assert.NoError(t, w.CommonTypeHeader(ch))
+	assert.NoError(t, w.PrivateHeader(ch, PrivateHeader{ObjectBufferLength: 8}))
+	assert.NoError(t, w.Uint32(0x01020304))
+	assert.NoError(t, w.Align(8))
+	assert.Equal(t, b, buf.Bytes())
+}
+
+func Test_UnmarshalTypeSerializedBigEndian(t *testing.T) {
+	b, _ := hex.DecodeString(TestKerbValidationInfo)
+	var k KerbValidationInfo
+	err := k.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj bytes.Buffer
+	w := NewWriter(&obj, WithByteOrder(binary.BigEndian))
+	err = w.Pointer(func() error {
+		return k.ToWriter(w)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Deferred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, w.Align(8))
+	var buf bytes.Buffer
+	hw := NewWriter(&buf)
+	ch := NewCommonTypeHeaderWithOrder(binary.BigEndian)
+	assert.NoError(t, hw.CommonTypeHeader(ch))
+	assert.NoError(t, hw.PrivateHeader(ch, PrivateHeader{ObjectBufferLength: uint32(obj.Len())}))
+	assert.NoError(t, hw.WriteBytes(obj.Bytes()))
+
+	var d KerbValidationInfo
+	err = UnmarshalTypeSerialized(buf.Bytes(), &d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, k.LogOnTime, d.LogOnTime, "logon time not as expected")
+	assert.Equal(t, k, d, "big-endian logon information not as expected")
+}
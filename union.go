@@ -0,0 +1,131 @@
+package mstypes
+
+import (
+	"fmt"
+)
+
+/*
+Unions are a discriminant, or tag, selecting one of several arms. Ref: https://pubs.opengroup.org/onlinepubs/9629399/chap14.htm#tagcjh_19_03_08
+A non-encapsulated union takes its discriminant from a field of the enclosing structure which is repeated ahead of
+the arm. An encapsulated union carries its discriminant inline, followed by the arm aligned to the largest alignment
+of any of the arms of the union.
+*/
+
+// UnionArmFunc returns the arm of a union selected by the discriminant tag. A nil NDRType selects an empty arm.
+type UnionArmFunc func(tag uint32) (NDRType, error)
+
+// NonEncapsulatedUnion reads the discriminant repeated ahead of the arm of a non-encapsulated union, checks it matches
+// tag and reads the arm. tagSize is the size in bytes of the discriminant.
+func (r *Reader) NonEncapsulatedUnion(tagSize int, tag uint32, arm UnionArmFunc) error {
+	t, err := r.unionTag(tagSize)
+	if err != nil {
+		return err
+	}
+	if t != tag {
+		return fmt.Errorf("union discriminant %d does not match the expected discriminant %d", t, tag)
+	}
+	return r.unionArm(tag, arm)
+}
+
+// EncapsulatedUnion reads the discriminant of an encapsulated union and the arm it selects, aligned to armAlign.
+// tagSize is the size in bytes of the discriminant.
+func (r *Reader) EncapsulatedUnion(tagSize, armAlign int, arm UnionArmFunc) (tag uint32, err error) {
+	tag, err = r.unionTag(tagSize)
+	if err != nil {
+		return
+	}
+	err = r.Align(armAlign)
+	if err != nil {
+		return
+	}
+	err = r.unionArm(tag, arm)
+	return
+}
+
+// NonEncapsulatedUnion writes the discriminant tag ahead of the arm of a non-encapsulated union and the arm.
+// tagSize is the size in bytes of the discriminant.
+func (w *Writer) NonEncapsulatedUnion(tagSize int, tag uint32, arm UnionArmFunc) error {
+	a, err := arm(tag)
+	if err != nil {
+		return err
+	}
+	err = w.unionTag(tagSize, tag)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return nil
+	}
+	return a.ToWriter(w)
+}
+
+// EncapsulatedUnion writes the discriminant tag of an encapsulated union and the arm it selects, aligned to armAlign.
+// tagSize is the size in bytes of the discriminant.
+func (w *Writer) EncapsulatedUnion(tagSize, armAlign int, tag uint32, arm UnionArmFunc) error {
+	a, err := arm(tag)
+	if err != nil {
+		return err
+	}
+	err = w.unionTag(tagSize, tag)
+	if err != nil {
+		return err
+	}
+	err = w.Align(armAlign)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return nil
+	}
+	return a.ToWriter(w)
+}
+
+// unionTag reads a union discriminant of size bytes.
+func (r *Reader) unionTag(size int) (uint32, error) {
+	if size != SizeUint8 && size != SizeUint16 && size != SizeUint32 {
+		return 0, fmt.Errorf("invalid union discriminant size %d", size)
+	}
+	err := r.Align(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case SizeUint8:
+		v, err := r.Uint8()
+		return uint32(v), err
+	case SizeUint16:
+		v, err := r.Uint16()
+		return uint32(v), err
+	}
+	return r.Uint32()
+}
+
+// unionArm reads the arm of a union selected by tag.
+func (r *Reader) unionArm(tag uint32, arm UnionArmFunc) error {
+	a, err := arm(tag)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return nil
+	}
+	return a.FromReader(r)
+}
+
+// unionTag writes a union discriminant of size bytes.
+func (w *Writer) unionTag(size int, tag uint32) error {
+	if size != SizeUint8 && size != SizeUint16 && size != SizeUint32 {
+		return fmt.Errorf("invalid union discriminant size %d", size)
+	}
+	err := w.Align(size)
+	if err != nil {
+		return err
+	}
+	switch size {
+	case SizeUint8:
+		return w.Uint8(uint8(tag))
+	case SizeUint16:
+		return w.Uint16(uint16(tag))
+	}
+	return w.Uint32(tag)
+}
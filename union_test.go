@@ -0,0 +1,56 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUnion struct {
+	Time FileTime
+	Key  CypherBlock
+}
+
+func (u *testUnion) arm(tag uint32) (NDRType, error) {
+	switch tag {
+	case 1:
+		return &u.Time, nil
+	case 2:
+		return &u.Key, nil
+	case 3:
+		return nil, nil
+	}
+	return nil, errors.New("unknown arm")
+}
+
+func Test_EncapsulatedUnion(t *testing.T) {
+	u := testUnion{Time: FileTime{LowDateTime: 0x11223344, HighDateTime: 0x55667788}}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, w.EncapsulatedUnion(SizeUint16, SizeUint32, 1, u.arm))
+	assert.Equal(t, "01000000"+"4433221188776655", hex.EncodeToString(buf.Bytes()))
+
+	var got testUnion
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	tag, err := r.EncapsulatedUnion(SizeUint16, SizeUint32, got.arm)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), tag)
+	assert.Equal(t, u.Time, got.Time)
+
+	buf.Reset()
+	w = NewWriter(&buf)
+	assert.NoError(t, w.EncapsulatedUnion(SizeUint32, SizeUint32, 3, u.arm))
+	assert.Equal(t, "03000000", hex.EncodeToString(buf.Bytes()), "empty arm not written as the discriminant only")
+
+	assert.Error(t, w.EncapsulatedUnion(SizeUint32, SizeUint32, 4, u.arm), "unknown arm not detected")
+}
+
+func Test_NonEncapsulatedUnionMismatch(t *testing.T) {
+	var u testUnion
+	b, _ := hex.DecodeString("0200")
+	r := NewReader(bytes.NewReader(b))
+	assert.Error(t, r.NonEncapsulatedUnion(SizeUint16, 1, u.arm), "discriminant mismatch not detected")
+}
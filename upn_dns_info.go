@@ -0,0 +1,181 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// UPN_DNS_INFO flags
+const (
+	UPNDNSInfoFlagNoUPN    uint32 = 0x1 // U: the user has no UPN and the UPN is constructed from the SamName and domain.
+	UPNDNSInfoFlagExtended uint32 = 0x2 // S: the buffer holds the SamName and SID of UPN_DNS_INFO_EX.
+)
+
+// UPN_DNS_INFO layout
+const (
+	upnDNSInfoHeaderSize   = 4*SizeUint16 + SizeUint32
+	upnDNSInfoExtendedSize = 4 * SizeUint16
+	upnDNSInfoAlign        = 8
+)
+
+// UPNDNSInfo implements the UPN_DNS_INFO buffer and its UPN_DNS_INFO_EX extension
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-pac/1c0d6e11-6443-4846-b744-f9f810a504eb
+//
+// The lengths and offsets locate the strings and SID from the start of the buffer. They are set by UnmarshalBinary
+// and updated by MarshalBinary to the layout it writes; the UPN, DNSDomainName, SamName and SID are the values.
+type UPNDNSInfo struct {
+	UPNLength           uint16 // The length, in bytes, of the UTF-16LE UPN.
+	UPNOffset           uint16
+	DNSDomainNameLength uint16 // The length, in bytes, of the UTF-16LE DNSDomainName.
+	DNSDomainNameOffset uint16
+	Flags               uint32 // A combination of the UPNDNSInfoFlag constants.
+	SamNameLength       uint16 // The length, in bytes, of the UTF-16LE SamName. Only present with the S flag.
+	SamNameOffset       uint16
+	SIDLength           uint16 // The length, in bytes, of the SID. Only present with the S flag.
+	SIDOffset           uint16
+	UPN                 string
+	DNSDomainName       string
+	SamName             string
+	SID                 *RPCSID // The SID of the user, nil without the S flag.
+}
+
+// PACBufferType returns PACBufferTypeUPNDNSInfo.
+func (u *UPNDNSInfo) PACBufferType() uint32 {
+	return PACBufferTypeUPNDNSInfo
+}
+
+// NoUPN reports whether the U flag is set, the user having no UPN.
+func (u *UPNDNSInfo) NoUPN() bool {
+	return u.Flags&UPNDNSInfoFlagNoUPN != 0
+}
+
+// Extended reports whether the S flag is set, the buffer holding the SamName and SID.
+func (u *UPNDNSInfo) Extended() bool {
+	return u.Flags&UPNDNSInfoFlagExtended != 0
+}
+
+// UnmarshalBinary parses the UPNDNSInfo buffer b. The strings and SID are checked to lie within b.
+func (u *UPNDNSInfo) UnmarshalBinary(b []byte) (err error) {
+	if len(b) < upnDNSInfoHeaderSize {
+		return fmt.Errorf("UPN_DNS_INFO of %d bytes is shorter than its header", len(b))
+	}
+	u.UPNLength = binary.LittleEndian.Uint16(b)
+	u.UPNOffset = binary.LittleEndian.Uint16(b[2:])
+	u.DNSDomainNameLength = binary.LittleEndian.Uint16(b[4:])
+	u.DNSDomainNameOffset = binary.LittleEndian.Uint16(b[6:])
+	u.Flags = binary.LittleEndian.Uint32(b[8:])
+	u.UPN, err = upnDNSInfoString(b, "UPN", u.UPNOffset, u.UPNLength)
+	if err != nil {
+		return
+	}
+	u.DNSDomainName, err = upnDNSInfoString(b, "DNS domain name", u.DNSDomainNameOffset, u.DNSDomainNameLength)
+	if err != nil {
+		return
+	}
+	u.SamNameLength, u.SamNameOffset, u.SIDLength, u.SIDOffset = 0, 0, 0, 0
+	u.SamName = ""
+	u.SID = nil
+	if !u.Extended() {
+		return nil
+	}
+	if len(b) < upnDNSInfoHeaderSize+upnDNSInfoExtendedSize {
+		return fmt.Errorf("UPN_DNS_INFO_EX of %d bytes is shorter than its header", len(b))
+	}
+	u.SamNameLength = binary.LittleEndian.Uint16(b[12:])
+	u.SamNameOffset = binary.LittleEndian.Uint16(b[14:])
+	u.SIDLength = binary.LittleEndian.Uint16(b[16:])
+	u.SIDOffset = binary.LittleEndian.Uint16(b[18:])
+	u.SamName, err = upnDNSInfoString(b, "SAM name", u.SamNameOffset, u.SamNameLength)
+	if err != nil {
+		return
+	}
+	sid, err := upnDNSInfoData(b, "SID", u.SIDOffset, u.SIDLength)
+	if err != nil {
+		return
+	}
+	u.SID = new(RPCSID)
+	return u.SID.UnmarshalBinary(sid)
+}
+
+// MarshalBinary returns the UPNDNSInfo buffer. The UPN_DNS_INFO_EX fields are written if the S flag is set. The
+// strings and SID follow the header in order, each starting on a multiple of 8 bytes, and the lengths and offsets are
+// updated to the layout written.
+func (u *UPNDNSInfo) MarshalBinary() ([]byte, error) {
+	size := upnDNSInfoHeaderSize
+	if u.Extended() {
+		size += upnDNSInfoExtendedSize
+	}
+	b := make([]byte, size)
+	var err error
+	u.UPNOffset, u.UPNLength, b, err = appendUPNDNSInfoData(b, "UPN", EncodeUTF16LE(u.UPN))
+	if err != nil {
+		return nil, err
+	}
+	u.DNSDomainNameOffset, u.DNSDomainNameLength, b, err = appendUPNDNSInfoData(b, "DNS domain name", EncodeUTF16LE(u.DNSDomainName))
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint16(b, u.UPNLength)
+	binary.LittleEndian.PutUint16(b[2:], u.UPNOffset)
+	binary.LittleEndian.PutUint16(b[4:], u.DNSDomainNameLength)
+	binary.LittleEndian.PutUint16(b[6:], u.DNSDomainNameOffset)
+	binary.LittleEndian.PutUint32(b[8:], u.Flags)
+	if !u.Extended() {
+		return b, nil
+	}
+	u.SamNameOffset, u.SamNameLength, b, err = appendUPNDNSInfoData(b, "SAM name", EncodeUTF16LE(u.SamName))
+	if err != nil {
+		return nil, err
+	}
+	var sid []byte
+	if u.SID != nil {
+		sid, err = u.SID.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+	u.SIDOffset, u.SIDLength, b, err = appendUPNDNSInfoData(b, "SID", sid)
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint16(b[12:], u.SamNameLength)
+	binary.LittleEndian.PutUint16(b[14:], u.SamNameOffset)
+	binary.LittleEndian.PutUint16(b[16:], u.SIDLength)
+	binary.LittleEndian.PutUint16(b[18:], u.SIDOffset)
+	return b, nil
+}
+
+// upnDNSInfoData returns the length bytes at offset of the UPN_DNS_INFO b holding the field name.
+func upnDNSInfoData(b []byte, name string, offset, length uint16) ([]byte, error) {
+	if int(offset)+int(length) > len(b) {
+		return nil, fmt.Errorf("UPN_DNS_INFO %s at offset %d with length %d exceeds the %d bytes of the buffer", name, offset, length, len(b))
+	}
+	return b[offset : int(offset)+int(length)], nil
+}
+
+// upnDNSInfoString returns the UTF-16LE string of length bytes at offset of the UPN_DNS_INFO b.
+func upnDNSInfoString(b []byte, name string, offset, length uint16) (string, error) {
+	if length%SizeUint16 != 0 {
+		return "", fmt.Errorf("UPN_DNS_INFO %s length %d is odd", name, length)
+	}
+	s, err := upnDNSInfoData(b, name, offset, length)
+	if err != nil {
+		return "", err
+	}
+	return DecodeUTF16LE(s), nil
+}
+
+// appendUPNDNSInfoData appends the data of the field name to b at the next multiple of 8 bytes and returns its offset
+// and length. Empty data is not appended and has an offset of zero.
+func appendUPNDNSInfoData(b []byte, name string, data []byte) (offset, length uint16, _ []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, b, nil
+	}
+	start := alignUp(uint64(len(b)), upnDNSInfoAlign)
+	if start+uint64(len(data)) > math.MaxUint16 {
+		return 0, 0, b, fmt.Errorf("UPN_DNS_INFO %s of %d bytes at offset %d exceeds the 16 bit offsets: %w", name, len(data), start, ErrStringTooLong)
+	}
+	b = append(b, make([]byte, int(start)-len(b))...)
+	return uint16(start), uint16(len(data)), append(b, data...), nil
+}
@@ -0,0 +1,92 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// TestUPNDNSInfo is the UPN_DNS_INFO of testuser1@test.gokrb5.
+	TestUPNDNSInfo = "2a001000160040000000000000000000" +
+		"740065007300740075007300650072003100400074006500730074002e0067006f006b00720062003500000000000000" +
+		"54004500530054002e0047004f004b00520042003500"
+	// TestUPNDNSInfoEx is the UPN_DNS_INFO of testuser1@test.gokrb5 with the UPN_DNS_INFO_EX SamName and SID.
+	TestUPNDNSInfoEx = "2a0018001600480002000000120060001c00780000000000" +
+		"740065007300740075007300650072003100400074006500730074002e0067006f006b00720062003500000000000000" +
+		"54004500530054002e0047004f004b005200420035000000" +
+		"740065007300740075007300650072003100000000000000" +
+		"01050000000000051500000001000000020000000300000051040000"
+)
+
+func Test_UPNDNSInfoUnmarshal(t *testing.T) {
+	b, _ := hex.DecodeString(TestUPNDNSInfo)
+	var u UPNDNSInfo
+	err := u.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testuser1@test.gokrb5", u.UPN, "UPN not as expected")
+	assert.Equal(t, "TEST.GOKRB5", u.DNSDomainName, "DNS domain name not as expected")
+	assert.False(t, u.Extended(), "extended flag not as expected")
+	assert.Nil(t, u.SID, "SID not as expected")
+
+	b, _ = hex.DecodeString(TestUPNDNSInfoEx)
+	err = u.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "testuser1@test.gokrb5", u.UPN, "UPN not as expected")
+	assert.Equal(t, "TEST.GOKRB5", u.DNSDomainName, "DNS domain name not as expected")
+	assert.True(t, u.Extended(), "extended flag not as expected")
+	assert.False(t, u.NoUPN(), "no UPN flag not as expected")
+	assert.Equal(t, "testuser1", u.SamName, "SAM name not as expected")
+	assert.Equal(t, "S-1-5-21-1-2-3-1105", u.SID.String(), "SID not as expected")
+	assert.Equal(t, PACBufferTypeUPNDNSInfo, u.PACBufferType(), "PAC buffer type not as expected")
+}
+
+func Test_UPNDNSInfoUnmarshalInvalid(t *testing.T) {
+	var tests = []string{
+		"2a0010001600400000000000",                          // UPN exceeding the buffer
+		"2a001000160040000200000000000000",                  // short UPN_DNS_INFO_EX header
+		"030010000000000000000000000000000000000000",        // odd UPN length
+		"0000000000000000020000000000000008001400" + "0105", // SID exceeding the buffer
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test)
+		var u UPNDNSInfo
+		assert.Error(t, u.UnmarshalBinary(b), "test %d: invalid UPN_DNS_INFO not detected", i+1)
+	}
+}
+
+func Test_UPNDNSInfoMarshal(t *testing.T) {
+	u := UPNDNSInfo{UPN: "testuser1@test.gokrb5", DNSDomainName: "TEST.GOKRB5"}
+	b, err := u.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestUPNDNSInfo, hex.EncodeToString(b), "encoding not as expected")
+
+	sid, _ := ConvertStrToSID("S-1-5-21-1-2-3-1105")
+	u = UPNDNSInfo{
+		Flags:         UPNDNSInfoFlagExtended,
+		UPN:           "testuser1@test.gokrb5",
+		DNSDomainName: "TEST.GOKRB5",
+		SamName:       "testuser1",
+		SID:           sid,
+	}
+	b, err = u.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, TestUPNDNSInfoEx, hex.EncodeToString(b), "encoding not as expected")
+	assert.Equal(t, uint16(0x78), u.SIDOffset, "SID offset not as expected")
+
+	var d UPNDNSInfo
+	err = d.UnmarshalBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, u, d, "decoded value not as expected")
+}
@@ -0,0 +1,149 @@
+package mstypes
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// UserAccountControl are the UF_ flags of the userAccountControl attribute of MS-ADTS. They differ from the
+// USER_ACCOUNT codes of MS-SAMR held by UserAccountFlags; the UserAccountFlags method and NewUserAccountControl
+// convert between the two.
+type UserAccountControl uint32
+
+// UF_ flags
+const (
+	UFScript                             UserAccountControl = 0x00000001 // UF_SCRIPT
+	UFAccountDisable                     UserAccountControl = 0x00000002 // UF_ACCOUNTDISABLE
+	UFHomeDirRequired                    UserAccountControl = 0x00000008 // UF_HOMEDIR_REQUIRED
+	UFLockout                            UserAccountControl = 0x00000010 // UF_LOCKOUT
+	UFPasswordNotRequired                UserAccountControl = 0x00000020 // UF_PASSWD_NOTREQD
+	UFPasswordCantChange                 UserAccountControl = 0x00000040 // UF_PASSWD_CANT_CHANGE
+	UFEncryptedTextPasswordAllowed       UserAccountControl = 0x00000080 // UF_ENCRYPTED_TEXT_PASSWORD_ALLOWED
+	UFTempDuplicateAccount               UserAccountControl = 0x00000100 // UF_TEMP_DUPLICATE_ACCOUNT
+	UFNormalAccount                      UserAccountControl = 0x00000200 // UF_NORMAL_ACCOUNT
+	UFInterdomainTrustAccount            UserAccountControl = 0x00000800 // UF_INTERDOMAIN_TRUST_ACCOUNT
+	UFWorkstationTrustAccount            UserAccountControl = 0x00001000 // UF_WORKSTATION_TRUST_ACCOUNT
+	UFServerTrustAccount                 UserAccountControl = 0x00002000 // UF_SERVER_TRUST_ACCOUNT
+	UFDontExpirePassword                 UserAccountControl = 0x00010000 // UF_DONT_EXPIRE_PASSWD
+	UFMNSLogonAccount                    UserAccountControl = 0x00020000 // UF_MNS_LOGON_ACCOUNT
+	UFSmartcardRequired                  UserAccountControl = 0x00040000 // UF_SMARTCARD_REQUIRED
+	UFTrustedForDelegation               UserAccountControl = 0x00080000 // UF_TRUSTED_FOR_DELEGATION
+	UFNotDelegated                       UserAccountControl = 0x00100000 // UF_NOT_DELEGATED
+	UFUseDESKeyOnly                      UserAccountControl = 0x00200000 // UF_USE_DES_KEY_ONLY
+	UFDontRequirePreauth                 UserAccountControl = 0x00400000 // UF_DONT_REQUIRE_PREAUTH
+	UFPasswordExpired                    UserAccountControl = 0x00800000 // UF_PASSWORD_EXPIRED
+	UFTrustedToAuthenticateForDelegation UserAccountControl = 0x01000000 // UF_TRUSTED_TO_AUTHENTICATE_FOR_DELEGATION
+	UFNoAuthDataRequired                 UserAccountControl = 0x02000000 // UF_NO_AUTH_DATA_REQUIRED
+	UFPartialSecretsAccount              UserAccountControl = 0x04000000 // UF_PARTIAL_SECRETS_ACCOUNT
+	UFUseAESKeys                         UserAccountControl = 0x08000000 // UF_USE_AES_KEYS
+)
+
+var userAccountControlNames = []flagName[UserAccountControl]{
+	{UFScript, "Script"},
+	{UFAccountDisable, "AccountDisable"},
+	{UFHomeDirRequired, "HomeDirRequired"},
+	{UFLockout, "Lockout"},
+	{UFPasswordNotRequired, "PasswordNotRequired"},
+	{UFPasswordCantChange, "PasswordCantChange"},
+	{UFEncryptedTextPasswordAllowed, "EncryptedTextPasswordAllowed"},
+	{UFTempDuplicateAccount, "TempDuplicateAccount"},
+	{UFNormalAccount, "NormalAccount"},
+	{UFInterdomainTrustAccount, "InterdomainTrustAccount"},
+	{UFWorkstationTrustAccount, "WorkstationTrustAccount"},
+	{UFServerTrustAccount, "ServerTrustAccount"},
+	{UFDontExpirePassword, "DontExpirePassword"},
+	{UFMNSLogonAccount, "MNSLogonAccount"},
+	{UFSmartcardRequired, "SmartcardRequired"},
+	{UFTrustedForDelegation, "TrustedForDelegation"},
+	{UFNotDelegated, "NotDelegated"},
+	{UFUseDESKeyOnly, "UseDESKeyOnly"},
+	{UFDontRequirePreauth, "DontRequirePreauth"},
+	{UFPasswordExpired, "PasswordExpired"},
+	{UFTrustedToAuthenticateForDelegation, "TrustedToAuthenticateForDelegation"},
+	{UFNoAuthDataRequired, "NoAuthDataRequired"},
+	{UFPartialSecretsAccount, "PartialSecretsAccount"},
+	{UFUseAESKeys, "UseAESKeys"},
+}
+
+// userAccountControlCodes maps the UF_ flags to the USER_ACCOUNT codes of SAMR. UF_SCRIPT and UF_PASSWD_CANT_CHANGE
+// have no code.
+var userAccountControlCodes = []struct {
+	uf   UserAccountControl
+	code UserAccountFlags
+}{
+	{UFAccountDisable, UserAccountDisabled},
+	{UFHomeDirRequired, UserAccountHomeDirectoryRequired},
+	{UFLockout, UserAccountAutoLocked},
+	{UFPasswordNotRequired, UserAccountPasswordNotRequired},
+	{UFEncryptedTextPasswordAllowed, UserAccountEncryptedTextPasswordAllowed},
+	{UFTempDuplicateAccount, UserAccountTempDuplicate},
+	{UFNormalAccount, UserAccountNormal},
+	{UFInterdomainTrustAccount, UserAccountInterdomainTrust},
+	{UFWorkstationTrustAccount, UserAccountWorkstationTrust},
+	{UFServerTrustAccount, UserAccountServerTrust},
+	{UFDontExpirePassword, UserAccountDontExpirePassword},
+	{UFMNSLogonAccount, UserAccountMNSLogon},
+	{UFSmartcardRequired, UserAccountSmartcardRequired},
+	{UFTrustedForDelegation, UserAccountTrustedForDelegation},
+	{UFNotDelegated, UserAccountNotDelegated},
+	{UFUseDESKeyOnly, UserAccountUseDESKeyOnly},
+	{UFDontRequirePreauth, UserAccountDontRequirePreauth},
+	{UFPasswordExpired, UserAccountPasswordExpired},
+	{UFTrustedToAuthenticateForDelegation, UserAccountTrustedToAuthForDelegation},
+	{UFNoAuthDataRequired, UserAccountNoAuthDataRequired},
+	{UFPartialSecretsAccount, UserAccountPartialSecrets},
+	{UFUseAESKeys, UserAccountUseAESKeys},
+}
+
+// ParseUserAccountControl parses the value of the userAccountControl attribute as returned by LDAP, a decimal
+// integer. Values beyond 2^31-1 are accepted both as unsigned and as the negative 32 bit integer LDAP syntax uses.
+func ParseUserAccountControl(s string) (UserAccountControl, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse userAccountControl %q: %w", s, err)
+	}
+	if v < math.MinInt32 || v > math.MaxUint32 {
+		return 0, fmt.Errorf("userAccountControl %q is not a 32 bit integer", s)
+	}
+	return UserAccountControl(uint32(v)), nil
+}
+
+// NewUserAccountControl returns the UF_ flags of the USER_ACCOUNT codes f. Codes without a flag are dropped.
+func NewUserAccountControl(f UserAccountFlags) UserAccountControl {
+	var u UserAccountControl
+	for _, c := range userAccountControlCodes {
+		if f&c.code != 0 {
+			u |= c.uf
+		}
+	}
+	return u
+}
+
+// Has reports whether all the flags f are set.
+func (u UserAccountControl) Has(f UserAccountControl) bool {
+	return u&f == f
+}
+
+// String returns the names of the flags set joined by "|", with any remaining bits in hexadecimal.
+func (u UserAccountControl) String() string {
+	return formatFlags(u, userAccountControlNames)
+}
+
+// LDAPValue returns the flags as the decimal integer of the userAccountControl attribute, negative if the high bit
+// is set.
+func (u UserAccountControl) LDAPValue() string {
+	return strconv.FormatInt(int64(int32(u)), 10)
+}
+
+// UserAccountFlags returns the USER_ACCOUNT codes of SAMR of the flags. UF_SCRIPT, UF_PASSWD_CANT_CHANGE and unknown
+// flags are dropped.
+func (u UserAccountControl) UserAccountFlags() UserAccountFlags {
+	var f UserAccountFlags
+	for _, c := range userAccountControlCodes {
+		if u&c.uf != 0 {
+			f |= c.code
+		}
+	}
+	return f
+}
@@ -0,0 +1,54 @@
+package mstypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseUserAccountControl(t *testing.T) {
+	var tests = []struct {
+		s    string
+		want UserAccountControl
+	}{
+		{"512", UFNormalAccount},
+		{"66048", UFNormalAccount | UFDontExpirePassword},
+		{"532480", UFServerTrustAccount | UFTrustedForDelegation},
+		{"4096", UFWorkstationTrustAccount},
+		{"-2147483648", 0x80000000},
+		{"2147483648", 0x80000000},
+	}
+	for _, test := range tests {
+		u, err := ParseUserAccountControl(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, test.want, u, "userAccountControl %s not as expected", test.s)
+	}
+	for _, s := range []string{"", "0x200", "4294967296", "-2147483649", "512 "} {
+		_, err := ParseUserAccountControl(s)
+		assert.Error(t, err, "invalid userAccountControl %q not rejected", s)
+	}
+	assert.Equal(t, "66048", (UFNormalAccount | UFDontExpirePassword).LDAPValue(), "LDAP value not as expected")
+	assert.Equal(t, "-2147483648", UserAccountControl(0x80000000).LDAPValue(), "LDAP value not as expected")
+}
+
+func Test_UserAccountControlString(t *testing.T) {
+	assert.Equal(t, "AccountDisable|NormalAccount|DontRequirePreauth",
+		(UFAccountDisable | UFNormalAccount | UFDontRequirePreauth).String(), "string not as expected")
+	assert.Equal(t, "NormalAccount|0x4", (UFNormalAccount | 0x4).String(), "string not as expected")
+	assert.True(t, (UFNormalAccount | UFNotDelegated).Has(UFNotDelegated), "flag not found")
+	assert.False(t, UFNormalAccount.Has(UFAccountDisable), "flag found")
+}
+
+func Test_UserAccountControlFlags(t *testing.T) {
+	u := UFNormalAccount | UFDontExpirePassword | UFLockout | UFUseAESKeys
+	f := u.UserAccountFlags()
+	assert.Equal(t, UserAccountNormal|UserAccountDontExpirePassword|UserAccountAutoLocked|UserAccountUseAESKeys, f,
+		"USER_ACCOUNT codes not as expected")
+	assert.Equal(t, u, NewUserAccountControl(f), "UF_ flags not as expected")
+	assert.Equal(t, DefaultPACUserAccountControl, (UFNormalAccount | UFDontExpirePassword).UserAccountFlags(),
+		"default PAC user account control not as expected")
+	assert.Equal(t, UserAccountFlags(0), (UFScript | UFPasswordCantChange).UserAccountFlags(),
+		"flags without codes not dropped")
+}
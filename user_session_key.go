@@ -1,5 +1,12 @@
 package mstypes
 
+//go:generate go run ./cmd/ndrgen -type CypherBlock,UserSessionKey user_session_key.go
+
+import (
+	"crypto/subtle"
+	"fmt"
+)
+
 // CypherBlock implements https://msdn.microsoft.com/en-us/library/cc237040.aspx
 type CypherBlock struct {
 	Data [8]byte // size = 8
@@ -9,3 +16,42 @@ type CypherBlock struct {
 type UserSessionKey struct {
 	CypherBlock [2]CypherBlock // size = 2
 }
+
+// IsZero reports whether the CypherBlock is all zero, as a session key that was not set.
+func (c CypherBlock) IsZero() bool {
+	return c.Data == [8]byte{}
+}
+
+// Equal reports whether the CypherBlocks c and o hold the same bytes. The comparison takes a time independent of the
+// bytes compared.
+func (c CypherBlock) Equal(o CypherBlock) bool {
+	return subtle.ConstantTimeCompare(c.Data[:], o.Data[:]) == 1
+}
+
+// NewUserSessionKey returns the UserSessionKey holding the 16 bytes of the key b.
+func NewUserSessionKey(b []byte) (UserSessionKey, error) {
+	var k UserSessionKey
+	if len(b) != 16 {
+		return k, fmt.Errorf("user session key is %d bytes, not 16", len(b))
+	}
+	copy(k.CypherBlock[0].Data[:], b)
+	copy(k.CypherBlock[1].Data[:], b[8:])
+	return k, nil
+}
+
+// Bytes returns the 16 bytes of the key.
+func (k UserSessionKey) Bytes() []byte {
+	return append(k.CypherBlock[0].Data[:], k.CypherBlock[1].Data[:]...)
+}
+
+// IsZero reports whether the key is all zero. KERB_VALIDATION_INFO and the SAMR user information carry a zero key
+// when no session key is provided.
+func (k UserSessionKey) IsZero() bool {
+	return k.CypherBlock[0].IsZero() && k.CypherBlock[1].IsZero()
+}
+
+// Equal reports whether the keys k and o hold the same bytes. The comparison takes a time independent of the bytes
+// compared.
+func (k UserSessionKey) Equal(o UserSessionKey) bool {
+	return subtle.ConstantTimeCompare(k.Bytes(), o.Bytes()) == 1
+}
@@ -0,0 +1,74 @@
+// Code generated by ndrgen; DO NOT EDIT.
+
+package mstypes
+
+import (
+	"io"
+)
+
+// FromReader reads the NDR representation of CypherBlock from r.
+func (s *CypherBlock) FromReader(r *Reader) (err error) {
+	err = r.Field("Data", func() (err error) {
+		{
+			b, err := r.ReadBytes(len(s.Data))
+			if err != nil {
+				return err
+			}
+			copy(s.Data[:], b)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of CypherBlock to w.
+func (s *CypherBlock) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	err = nw.WriteBytes(s.Data[:])
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of CypherBlock.
+func (s *CypherBlock) Size() int {
+	return 8
+}
+
+// FromReader reads the NDR representation of UserSessionKey from r.
+func (s *UserSessionKey) FromReader(r *Reader) (err error) {
+	err = r.Field("CypherBlock", func() (err error) {
+		for i := range s.CypherBlock {
+			err = s.CypherBlock[i].FromReader(r)
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ToWriter writes the NDR representation of UserSessionKey to w.
+func (s *UserSessionKey) ToWriter(w io.Writer) (err error) {
+	nw := AsWriter(w)
+	for i := range s.CypherBlock {
+		err = s.CypherBlock[i].ToWriter(nw)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Size returns the number of bytes in the NDR representation of UserSessionKey.
+func (s *UserSessionKey) Size() int {
+	return 16
+}
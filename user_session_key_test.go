@@ -0,0 +1,37 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserSessionKey(t *testing.T) {
+	b, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	k, err := NewUserSessionKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, k.Bytes(), "bytes not as expected")
+	assert.False(t, k.IsZero(), "key is zero")
+	assert.True(t, UserSessionKey{}.IsZero(), "zero key not detected")
+
+	var buf bytes.Buffer
+	err = k.ToWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, buf.Bytes(), "encoding not as expected")
+
+	o, _ := NewUserSessionKey(b)
+	assert.True(t, k.Equal(o), "equal keys not equal")
+	o.CypherBlock[1].Data[7] ^= 1
+	assert.False(t, k.Equal(o), "different keys equal")
+	assert.True(t, k.CypherBlock[0].Equal(o.CypherBlock[0]), "equal blocks not equal")
+	assert.False(t, k.CypherBlock[1].Equal(o.CypherBlock[1]), "different blocks equal")
+
+	_, err = NewUserSessionKey(b[:15])
+	assert.Error(t, err, "key length not checked")
+}
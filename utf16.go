@@ -0,0 +1,153 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+/*
+Windows strings are UTF-16LE. Characters outside the Basic Multilingual Plane are encoded as surrogate pairs. As
+Windows does not validate the strings it stores, decoded data may hold unpaired surrogates or, in flat blobs, an odd
+number of bytes. These are replaced by U+FFFD unless strict decoding is requested, in which case an error wrapping
+ErrInvalidUTF16 is returned. Readers decode strictly when created with WithStrictUTF16.
+*/
+
+// ErrInvalidUTF16 is returned, wrapped, when strictly decoding UTF-16 holding an unpaired surrogate or an odd byte.
+var ErrInvalidUTF16 = errors.New("invalid UTF-16")
+
+// WithStrictUTF16 makes a Reader return an error for strings holding unpaired surrogates instead of replacing them
+// by U+FFFD.
+func WithStrictUTF16() Option {
+	return func(o *options) {
+		o.strictUTF16 = true
+	}
+}
+
+// EncodeUTF16LE returns the UTF-16LE encoding of s.
+func EncodeUTF16LE(s string) []byte {
+	return putUTF16LE(wideChars(s, false))
+}
+
+// DecodeUTF16LE decodes the UTF-16LE b, replacing unpaired surrogates and a trailing odd byte by U+FFFD.
+func DecodeUTF16LE(b []byte) string {
+	s, _ := decodeUTF16LE(b, false)
+	return s
+}
+
+// DecodeUTF16LEStrict decodes the UTF-16LE b, returning an error wrapping ErrInvalidUTF16 if b holds an unpaired
+// surrogate or has an odd length.
+func DecodeUTF16LEStrict(b []byte) (string, error) {
+	return decodeUTF16LE(b, true)
+}
+
+// EncodeUTF16LEZ returns the null terminated UTF-16LE encoding of s, as held by an LPWSTR.
+func EncodeUTF16LEZ(s string) []byte {
+	return putUTF16LE(wideChars(s, true))
+}
+
+// DecodeUTF16LEZ decodes the null terminated UTF-16LE string at the start of b. The string ends at the first null
+// character, so any characters after an embedded terminator are not part of it. consumed is the number of bytes of b
+// used, including the terminator. If b has no terminator the whole of b is decoded, ignoring a trailing odd byte
+// which is not counted as consumed.
+func DecodeUTF16LEZ(b []byte) (s string, consumed int) {
+	u := make([]uint16, 0, len(b)/SizeUint16)
+	for ; consumed+SizeUint16 <= len(b); consumed += SizeUint16 {
+		c := binary.LittleEndian.Uint16(b[consumed:])
+		if c == 0 {
+			consumed += SizeUint16
+			break
+		}
+		u = append(u, c)
+	}
+	s, _ = decodeUTF16(u, false)
+	return
+}
+
+// putUTF16LE returns the UTF-16LE encoding of the UTF-16 characters u.
+func putUTF16LE(u []uint16) []byte {
+	b := make([]byte, len(u)*SizeUint16)
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[i*SizeUint16:], c)
+	}
+	return b
+}
+
+// decodeUTF16LE decodes the UTF-16LE b, replacing or, if strict, rejecting invalid data.
+func decodeUTF16LE(b []byte, strict bool) (string, error) {
+	u := make([]uint16, len(b)/SizeUint16)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*SizeUint16:])
+	}
+	s, err := decodeUTF16(u, strict)
+	if err != nil {
+		return "", err
+	}
+	if len(b)%SizeUint16 != 0 {
+		if strict {
+			return "", fmt.Errorf("odd UTF-16 byte length %d: %w", len(b), ErrInvalidUTF16)
+		}
+		s += string(utf8.RuneError)
+	}
+	return s, nil
+}
+
+// decodeUTF16 decodes the UTF-16 characters u. Unpaired surrogates are replaced by U+FFFD or, if strict, rejected.
+func decodeUTF16(u []uint16, strict bool) (string, error) {
+	var s strings.Builder
+	s.Grow(len(u))
+	for i := 0; i < len(u); i++ {
+		c := rune(u[i])
+		switch {
+		case !utf16.IsSurrogate(c):
+			s.WriteRune(c)
+			continue
+		case c < 0xdc00 && i+1 < len(u):
+			// A high surrogate followed by a low surrogate
+			if r := utf16.DecodeRune(c, rune(u[i+1])); r != utf8.RuneError {
+				s.WriteRune(r)
+				i++
+				continue
+			}
+		}
+		if strict {
+			return "", fmt.Errorf("unpaired surrogate 0x%04x at character %d: %w", c, i, ErrInvalidUTF16)
+		}
+		s.WriteRune(utf8.RuneError)
+	}
+	return s.String(), nil
+}
+
+// EncodeMultiSZ returns the REG_MULTI_SZ encoding of v: each string as null terminated UTF-16LE followed by an empty
+// string ending the list. As an empty string ends the list, v must not hold empty strings or null characters.
+func EncodeMultiSZ(v []string) ([]byte, error) {
+	var u []uint16
+	for i, s := range v {
+		if s == "" {
+			return nil, fmt.Errorf("multi string element %d is empty", i)
+		}
+		if strings.ContainsRune(s, 0) {
+			return nil, fmt.Errorf("multi string element %d holds a null character", i)
+		}
+		u = append(u, wideChars(s, true)...)
+	}
+	return putUTF16LE(append(u, 0)), nil
+}
+
+// DecodeMultiSZ decodes the REG_MULTI_SZ data b into its strings. The list ends at the first empty string; a list
+// missing its final terminators, as stored by some applications, is decoded up to the end of b.
+func DecodeMultiSZ(b []byte) []string {
+	var v []string
+	for len(b) >= SizeUint16 {
+		s, n := DecodeUTF16LEZ(b)
+		if s == "" {
+			break
+		}
+		v = append(v, s)
+		b = b[n:]
+	}
+	return v
+}
@@ -0,0 +1,94 @@
+package mstypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UTF16LEZ(t *testing.T) {
+	b := EncodeUTF16LEZ("test")
+	assert.Equal(t, "74006500730074000000", hex.EncodeToString(b))
+	s, n := DecodeUTF16LEZ(b)
+	assert.Equal(t, "test", s)
+	assert.Equal(t, len(b), n)
+
+	var tests = []struct {
+		Hex      string
+		Value    string
+		Consumed int
+	}{
+		{"610000006200000000", "a", 4},    // embedded terminator
+		{"6100620063", "ab", 4},           // no terminator, odd length
+		{"", "", 0},                       // empty
+		{"3dd800de0000", "\U0001F600", 6}, // surrogate pair
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test.Hex)
+		s, n := DecodeUTF16LEZ(b)
+		assert.Equal(t, test.Value, s, "value not as expected for test %d", i+1)
+		assert.Equal(t, test.Consumed, n, "consumed bytes not as expected for test %d", i+1)
+	}
+}
+
+func Test_UTF16LE(t *testing.T) {
+	b := EncodeUTF16LE("a\U0001F600")
+	assert.Equal(t, "61003dd800de", hex.EncodeToString(b), "surrogate pair not encoded")
+	assert.Equal(t, "a\U0001F600", DecodeUTF16LE(b))
+
+	var tests = []struct {
+		Hex   string
+		Value string
+	}{
+		{"3dd861", "��"},                // high surrogate followed by an odd byte
+		{"3dd86100", "�a"},              // unpaired high surrogate
+		{"00de6100", "�a"},              // unpaired low surrogate
+		{"61003dd8", "a�"},              // high surrogate at the end
+		{"3dd83dd800de", "�\U0001F600"}, // high surrogate followed by a pair
+	}
+	for i, test := range tests {
+		b, _ := hex.DecodeString(test.Hex)
+		assert.Equal(t, test.Value, DecodeUTF16LE(b), "value not as expected for test %d", i+1)
+		_, err := DecodeUTF16LEStrict(b)
+		assert.ErrorIs(t, err, ErrInvalidUTF16, "invalid UTF-16 not rejected for test %d", i+1)
+	}
+}
+
+func Test_ReaderStrictUTF16(t *testing.T) {
+	b, _ := hex.DecodeString("02000000" + "00000000" + "02000000" + "3dd86100")
+	s, err := NewReader(bytes.NewReader(b)).ConformantVaryingString(false)
+	assert.NoError(t, err)
+	assert.Equal(t, "�a", s)
+	_, err = NewReader(bytes.NewReader(b), WithStrictUTF16()).ConformantVaryingString(false)
+	assert.ErrorIs(t, err, ErrInvalidUTF16)
+
+	b, _ = hex.DecodeString("3dd800de")
+	s, err = NewReader(bytes.NewReader(b)).UTF16String(len(b))
+	assert.NoError(t, err)
+	assert.Equal(t, "\U0001F600", s, "surrogate pair not decoded")
+}
+
+func Test_MultiSZ(t *testing.T) {
+	b, err := EncodeMultiSZ([]string{"ab", "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "6100620000006300"+"00000000", hex.EncodeToString(b))
+	assert.Equal(t, []string{"ab", "c"}, DecodeMultiSZ(b))
+
+	b, err = EncodeMultiSZ(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "0000", hex.EncodeToString(b))
+	assert.Nil(t, DecodeMultiSZ(b))
+
+	_, err = EncodeMultiSZ([]string{"a", ""})
+	assert.Error(t, err, "empty string not rejected")
+	_, err = EncodeMultiSZ([]string{"a\x00b"})
+	assert.Error(t, err, "null character not rejected")
+
+	// Missing terminators and trailing data after the list
+	b, _ = hex.DecodeString("610000006200")
+	assert.Equal(t, []string{"a", "b"}, DecodeMultiSZ(b))
+	b, _ = hex.DecodeString("6100000000006200")
+	assert.Equal(t, []string{"a"}, DecodeMultiSZ(b))
+}
@@ -0,0 +1,141 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Writer writes Go representations into a simple byte stream
+type Writer struct {
+	w        io.Writer              // destination of the data, nil when appending to buf
+	buf      []byte                 // buffer the data is appended to when w is nil
+	scratch  [SizeUint64]byte       // encoding space for integers written to w
+	opts     options                // encoding options
+	n        int                    // number of bytes written, used for alignment
+	nextRef  uint32                 // referent ID to assign to the next non-null pointer
+	refs     map[interface{}]uint32 // referent IDs assigned to full pointers
+	deferred []func() error         // referents of embedded pointers waiting to be written
+}
+
+// zeros holds the zero padding written by Align.
+var zeros [SizeUint64]byte
+
+// NewWriter creates a new instance of a simple Writer.
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	writer := new(Writer)
+	writer.w = w
+	writer.opts = newOptions(opts)
+	writer.nextRef = firstReferentID
+	return writer
+}
+
+// AsWriter returns w if it is a *Writer, otherwise a new Writer writing to w. It lets ToWriter methods be called both
+// on a plain io.Writer and from the ToWriter method of an enclosing type.
+func AsWriter(w io.Writer) *Writer {
+	if nw, ok := w.(*Writer); ok {
+		return nw
+	}
+	return NewWriter(w)
+}
+
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.w == nil {
+		w.buf = append(w.buf, p...)
+		w.n += len(p)
+		return len(p), nil
+	}
+	n, err = w.w.Write(p)
+	w.n += n
+	return
+}
+
+// ByteOrder returns the byte order used to encode integers.
+func (w *Writer) ByteOrder() binary.ByteOrder {
+	return w.opts.order
+}
+
+// Offset returns the number of bytes written to the stream.
+func (w *Writer) Offset() int {
+	return w.n
+}
+
+// Align writes the zero padding needed to place the next value on a multiple of n bytes. It does nothing if the Writer
+// is packed.
+func (w *Writer) Align(n int) error {
+	if w.opts.packed {
+		return nil
+	}
+	pad := (n - w.n%n) % n
+	if pad == 0 {
+		return nil
+	}
+	if pad > len(zeros) {
+		_, err := w.Write(make([]byte, pad))
+		return err
+	}
+	_, err := w.Write(zeros[:pad])
+	return err
+}
+
+func (w *Writer) Uint8(v uint8) error {
+	w.scratch[0] = v
+	_, err := w.Write(w.scratch[:SizeUint8])
+	return err
+}
+
+func (w *Writer) Uint16(v uint16) error {
+	w.opts.order.PutUint16(w.scratch[:], v)
+	_, err := w.Write(w.scratch[:SizeUint16])
+	return err
+}
+
+func (w *Writer) Uint32(v uint32) error {
+	w.opts.order.PutUint32(w.scratch[:], v)
+	_, err := w.Write(w.scratch[:SizeUint32])
+	return err
+}
+
+func (w *Writer) Uint64(v uint64) error {
+	w.opts.order.PutUint64(w.scratch[:], v)
+	_, err := w.Write(w.scratch[:SizeUint64])
+	return err
+}
+
+// FileTime writes a FILETIME as its LowDateTime and HighDateTime without aligning the stream. FileTime.ToWriter
+// aligns it to 4 bytes as NDR does.
+func (w *Writer) FileTime(ft FileTime) error {
+	err := w.Uint32(ft.LowDateTime)
+	if err != nil {
+		return err
+	}
+	return w.Uint32(ft.HighDateTime)
+}
+
+// RPCSid writes the RPC_SID sid without the max count of its conformant SubAuthority array.
+func (w *Writer) RPCSid(sid RPCSID) error {
+	err := CheckRange("RPC_SID SubAuthorityCount", uint64(sid.SubAuthorityCount), 0, MaxSubAuthorities)
+	if err != nil {
+		return err
+	}
+	if len(sid.SubAuthority) != int(sid.SubAuthorityCount) {
+		return fmt.Errorf("RPC_SID sub authority count %d does not match the %d sub authorities", sid.SubAuthorityCount, len(sid.SubAuthority))
+	}
+	_, err = w.Write(append([]byte{sid.Revision, sid.SubAuthorityCount}, sid.IdentifierAuthority[:]...))
+	if err != nil {
+		return err
+	}
+	for _, v := range sid.SubAuthority {
+		err = w.Uint32(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBytes writes the bytes of b to the stream.
+func (w *Writer) WriteBytes(b []byte) error {
+	_, err := w.Write(b)
+	return err
+}
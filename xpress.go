@@ -0,0 +1,178 @@
+package mstypes
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// decompressXPress decompresses the Plain LZ77 stream b of MS-XCA to size bytes. Each 32 bit flag word, read most
+// significant bit first, selects a literal byte or a 16 bit match holding a 13 bit offset and 3 bit length, with
+// longer lengths continued in shared nibbles, bytes and wider integers.
+func decompressXPress(b []byte, size int) ([]byte, error) {
+	out := newDecompressBuffer(size)
+	var flags uint32
+	flagCount := 0
+	pos := 0
+	halfByte := -1 // position of the byte whose high nibble holds the next match length nibble
+	for len(out) < size {
+		if flagCount == 0 {
+			if pos+SizeUint32 > len(b) {
+				return out, fmt.Errorf("%w: flags truncated at input position %d", ErrCompressedData, pos)
+			}
+			flags = binary.LittleEndian.Uint32(b[pos:])
+			pos += SizeUint32
+			flagCount = 32
+		}
+		flagCount--
+		if flags&(1<<flagCount) == 0 {
+			if pos >= len(b) {
+				return out, fmt.Errorf("%w: literal truncated at input position %d", ErrCompressedData, pos)
+			}
+			out = append(out, b[pos])
+			pos++
+			continue
+		}
+		if pos+SizeUint16 > len(b) {
+			return out, fmt.Errorf("%w: match truncated at input position %d", ErrCompressedData, pos)
+		}
+		match := int(binary.LittleEndian.Uint16(b[pos:]))
+		pos += SizeUint16
+		length := match % 8
+		offset := match/8 + 1
+		if length == 7 {
+			if halfByte < 0 {
+				if pos >= len(b) {
+					return out, fmt.Errorf("%w: match length truncated at input position %d", ErrCompressedData, pos)
+				}
+				length = int(b[pos] % 16)
+				halfByte = pos
+				pos++
+			} else {
+				length = int(b[halfByte] / 16)
+				halfByte = -1
+			}
+			if length == 15 {
+				var err error
+				length, pos, err = readExtendedMatchLength(b, pos, 15+7)
+				if err != nil {
+					return out, err
+				}
+				length += 15
+			}
+			length += 7
+		}
+		length += 3
+		var err error
+		out, err = copyMatch(out, offset, length, size)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// readExtendedMatchLength reads the byte at pos continuing a match length, escaping to a 16 bit and then a 32 bit
+// length when it is all ones, which hold the length including the bias. It returns the length less the bias and the
+// position after it.
+func readExtendedMatchLength(b []byte, pos, bias int) (int, int, error) {
+	if pos >= len(b) {
+		return 0, pos, fmt.Errorf("%w: match length truncated at input position %d", ErrCompressedData, pos)
+	}
+	length := int(b[pos])
+	pos++
+	if length < 255 {
+		return length, pos, nil
+	}
+	if pos+SizeUint16 > len(b) {
+		return 0, pos, fmt.Errorf("%w: match length truncated at input position %d", ErrCompressedData, pos)
+	}
+	length = int(binary.LittleEndian.Uint16(b[pos:]))
+	pos += SizeUint16
+	if length == 0 {
+		if pos+SizeUint32 > len(b) {
+			return 0, pos, fmt.Errorf("%w: match length truncated at input position %d", ErrCompressedData, pos)
+		}
+		length = int(binary.LittleEndian.Uint32(b[pos:]))
+		pos += SizeUint32
+	}
+	if length < bias {
+		return 0, pos, fmt.Errorf("%w: match length %d shorter than %d", ErrCompressedData, length, bias)
+	}
+	return length - bias, pos, nil
+}
+
+// Plain LZ77 limits
+const (
+	xpressMaxOffset = 1 << 13 // offsets are held in 13 bits less 1
+	xpressMaxLength = 1<<16 + lz77MinMatch - 1
+)
+
+// compressXPress compresses b to a Plain LZ77 stream of MS-XCA.
+func compressXPress(b []byte) []byte {
+	out := make([]byte, SizeUint32, SizeUint32+len(b)+len(b)/8+SizeUint32)
+	m := newLZ77Matcher(b)
+	flagPos := 0
+	var flags uint32
+	flagCount := 0
+	halfByte := -1
+	token := func(match bool) {
+		flags <<= 1
+		if match {
+			flags |= 1
+		}
+		flagCount++
+		if flagCount == 32 {
+			binary.LittleEndian.PutUint32(out[flagPos:], flags)
+			flagPos = len(out)
+			out = append(out, 0, 0, 0, 0)
+			flags, flagCount = 0, 0
+		}
+	}
+	for pos := 0; pos < len(b); {
+		offset, length := m.find(pos, 0, xpressMaxOffset, xpressMaxLength)
+		if length == 0 {
+			m.insert(pos)
+			out = append(out, b[pos])
+			token(false)
+			pos++
+			continue
+		}
+		l := length - lz77MinMatch
+		out = binary.LittleEndian.AppendUint16(out, uint16((offset-1)<<3|min(l, 7)))
+		if l >= 7 {
+			l -= 7
+			if halfByte < 0 {
+				halfByte = len(out)
+				out = append(out, byte(min(l, 15)))
+			} else {
+				out[halfByte] |= byte(min(l, 15)) << 4
+				halfByte = -1
+			}
+			if l >= 15 {
+				out = appendExtendedMatchLength(out, l-15, length-lz77MinMatch)
+			}
+		}
+		token(true)
+		for end := pos + length; pos < end; pos++ {
+			m.insert(pos)
+		}
+	}
+	// The remaining flags are set, matches without input ending the stream.
+	flags = flags<<(32-flagCount) | (1<<(32-flagCount) - 1)
+	binary.LittleEndian.PutUint32(out[flagPos:], flags)
+	return out
+}
+
+// appendExtendedMatchLength appends the byte continuing a match length, or all ones followed by the 16 bit or 32 bit
+// length including the bias when the remaining length n does not fit.
+func appendExtendedMatchLength(out []byte, n, length int) []byte {
+	if n < 255 {
+		return append(out, byte(n))
+	}
+	out = append(out, 255)
+	if length <= 0xffff {
+		return binary.LittleEndian.AppendUint16(out, uint16(length))
+	}
+	out = binary.LittleEndian.AppendUint16(out, 0)
+	return binary.LittleEndian.AppendUint32(out, uint32(length))
+}
@@ -0,0 +1,337 @@
+package mstypes
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// LZ77+Huffman layout
+const (
+	xpressHuffmanSymbols   = 512      // 256 literals and 256 matches of 16 lengths by 16 offset bit lengths
+	xpressHuffmanTableSize = 256      // 4 bit code lengths of the symbols
+	xpressHuffmanMaxBits   = 15       // longest code
+	xpressHuffmanBlockSize = 64 << 10 // output bytes decoded with the code of a block
+	xpressHuffmanNoSymbol  = 0xffff   // decoding table entry not reached by any code
+	xpressHuffmanMinMatch  = 3        // length of a match of length nibble 0
+)
+
+// xpressHuffmanBits reads the bit stream of an LZ77+Huffman block: 16 bit little-endian words most significant bit
+// first, interleaved with the bytes of long match lengths.
+type xpressHuffmanBits struct {
+	b     []byte
+	pos   int    // position of the next word or byte in b
+	bits  uint32 // the next bits, most significant first
+	extra int    // number of bits held in bits beyond 16
+}
+
+// word reads the next 16 bit word, zero beyond the end of the stream where an encoder pads the last code.
+func (r *xpressHuffmanBits) word() uint32 {
+	var v uint32
+	if r.pos+SizeUint16 <= len(r.b) {
+		v = uint32(binary.LittleEndian.Uint16(r.b[r.pos:]))
+	}
+	r.pos += SizeUint16
+	return v
+}
+
+// init starts reading the bits of a block at pos.
+func (r *xpressHuffmanBits) init(pos int) {
+	r.pos = pos
+	r.bits = r.word() << 16
+	r.bits |= r.word()
+	r.extra = 16
+}
+
+// peek returns the next n bits, n at most 16.
+func (r *xpressHuffmanBits) peek(n int) uint32 {
+	if n == 0 {
+		return 0
+	}
+	return r.bits >> (32 - n)
+}
+
+// skip consumes n bits, n at most 16, refilling a word when fewer than 16 remain.
+func (r *xpressHuffmanBits) skip(n int) {
+	r.bits <<= n
+	r.extra -= n
+	if r.extra < 0 {
+		r.bits |= r.word() << -r.extra
+		r.extra += 16
+	}
+}
+
+// xpressHuffmanTable returns the table decoding the next 15 bits of the stream to a symbol from the code lengths of
+// the 512 symbols held in the first 256 bytes of a block, low nibble first, and the code lengths.
+func xpressHuffmanTable(b []byte) ([]uint16, []uint8, error) {
+	lengths := make([]uint8, xpressHuffmanSymbols)
+	for i, v := range b[:xpressHuffmanTableSize] {
+		lengths[2*i] = v & 0xf
+		lengths[2*i+1] = v >> 4
+	}
+	table := make([]uint16, 1<<xpressHuffmanMaxBits)
+	n := 0
+	for bits := 1; bits <= xpressHuffmanMaxBits; bits++ {
+		for s, l := range lengths {
+			if int(l) != bits {
+				continue
+			}
+			count := 1 << (xpressHuffmanMaxBits - bits)
+			if n+count > len(table) {
+				return nil, nil, fmt.Errorf("%w: Huffman code lengths oversubscribed", ErrCompressedData)
+			}
+			for i := 0; i < count; i++ {
+				table[n+i] = uint16(s)
+			}
+			n += count
+		}
+	}
+	for ; n < len(table); n++ {
+		table[n] = xpressHuffmanNoSymbol
+	}
+	return table, lengths, nil
+}
+
+// decompressXPressHuffman decompresses the LZ77+Huffman stream b of MS-XCA to size bytes. Each block of 64 KiB of
+// output starts with the Huffman code lengths of its symbols, followed by the codes of literals and matches.
+func decompressXPressHuffman(b []byte, size int) ([]byte, error) {
+	out := newDecompressBuffer(size)
+	r := xpressHuffmanBits{b: b}
+	start := 0
+	for len(out) < size {
+		if start+xpressHuffmanTableSize > len(b) {
+			return out, fmt.Errorf("%w: Huffman table truncated at input position %d", ErrCompressedData, start)
+		}
+		table, lengths, err := xpressHuffmanTable(b[start:])
+		if err != nil {
+			return out, err
+		}
+		r.init(start + xpressHuffmanTableSize)
+		end := len(out) + xpressHuffmanBlockSize
+		for len(out) < size && len(out) < end {
+			if r.pos > len(b)+SizeUint32 {
+				return out, fmt.Errorf("%w: Huffman stream truncated", ErrCompressedData)
+			}
+			symbol := table[r.peek(xpressHuffmanMaxBits)]
+			if symbol == xpressHuffmanNoSymbol {
+				return out, fmt.Errorf("%w: invalid Huffman code at input position %d", ErrCompressedData, r.pos)
+			}
+			r.skip(int(lengths[symbol]))
+			if symbol < 256 {
+				out = append(out, byte(symbol))
+				continue
+			}
+			symbol -= 256
+			length := int(symbol % 16)
+			offsetBits := int(symbol / 16)
+			if length == 15 {
+				length, r.pos, err = readExtendedMatchLength(b, r.pos, 15)
+				if err != nil {
+					return out, err
+				}
+				length += 15
+			}
+			length += xpressHuffmanMinMatch
+			offset := int(r.peek(offsetBits)) + 1<<offsetBits
+			r.skip(offsetBits)
+			out, err = copyMatch(out, offset, length, size)
+			if err != nil {
+				return out, err
+			}
+		}
+		start = r.pos
+	}
+	return out, nil
+}
+
+// LZ77+Huffman compressor limits
+const (
+	xpressHuffmanMaxOffset = 1<<16 - 1 // offsets of 15 offset bits
+	xpressHuffmanMaxLength = 1<<16 + xpressHuffmanMinMatch - 1
+	xpressHuffmanEOF       = 256 // symbol ending the stream
+)
+
+// xpressHuffmanToken is a literal or match of the LZ77 pass of the LZ77+Huffman compressor.
+type xpressHuffmanToken struct {
+	symbol uint16
+	length int // length of a match, 0 for a literal
+	offset int
+}
+
+// compressXPressHuffman compresses b to an LZ77+Huffman stream of MS-XCA, one block of Huffman codes for each 64 KiB of
+// input, the last ended by the EOF symbol.
+func compressXPressHuffman(b []byte) []byte {
+	m := newLZ77Matcher(b)
+	var out []byte
+	pos := 0
+	for {
+		end := pos + xpressHuffmanBlockSize
+		var tokens []xpressHuffmanToken
+		for pos < len(b) && pos < end {
+			offset, length := m.find(pos, 0, xpressHuffmanMaxOffset, xpressHuffmanMaxLength)
+			if length == 0 {
+				m.insert(pos)
+				tokens = append(tokens, xpressHuffmanToken{symbol: uint16(b[pos])})
+				pos++
+				continue
+			}
+			offsetBits := bits.Len(uint(offset)) - 1
+			symbol := 256 + offsetBits*16 + min(length-xpressHuffmanMinMatch, 15)
+			tokens = append(tokens, xpressHuffmanToken{symbol: uint16(symbol), length: length, offset: offset})
+			for e := pos + length; pos < e; pos++ {
+				m.insert(pos)
+			}
+		}
+		// The EOF symbol is decoded within the block, in a block of its own if the input ends at a block boundary.
+		eof := pos < end
+		if eof {
+			tokens = append(tokens, xpressHuffmanToken{symbol: xpressHuffmanEOF})
+		}
+		out = appendXPressHuffmanBlock(out, tokens)
+		if eof {
+			return out
+		}
+	}
+}
+
+// appendXPressHuffmanBlock appends the block of the tokens, the code lengths of its symbols followed by the codes and
+// the bytes of long match lengths, in the order the decoder reads them.
+func appendXPressHuffmanBlock(out []byte, tokens []xpressHuffmanToken) []byte {
+	freq := make([]int, xpressHuffmanSymbols)
+	for _, t := range tokens {
+		freq[t.symbol]++
+	}
+	lengths := huffmanCodeLengths(freq, xpressHuffmanMaxBits)
+	codes := make([]uint16, xpressHuffmanSymbols)
+	code := uint16(0)
+	for n := uint8(1); n <= xpressHuffmanMaxBits; n++ {
+		for s, l := range lengths {
+			if l == n {
+				codes[s] = code
+				code++
+			}
+		}
+		code <<= 1
+	}
+	table := make([]byte, xpressHuffmanTableSize)
+	for s, l := range lengths {
+		table[s/2] |= l << (4 * (s % 2))
+	}
+	w := xpressHuffmanWriter{out: append(out, table...)}
+	w.reserve()
+	for _, t := range tokens {
+		w.write(uint32(codes[t.symbol]), int(lengths[t.symbol]))
+		if t.length == 0 {
+			continue
+		}
+		if l := t.length - xpressHuffmanMinMatch; l >= 15 {
+			w.out = appendExtendedMatchLength(w.out, l-15, l)
+		}
+		offsetBits := int(t.symbol-256) / 16
+		w.write(uint32(t.offset-1<<offsetBits), offsetBits)
+	}
+	return w.flush()
+}
+
+// xpressHuffmanWriter writes the bit stream of an LZ77+Huffman block. The 16 bit words are placed where the decoder,
+// reading two words ahead, reads them among the bytes of long match lengths.
+type xpressHuffmanWriter struct {
+	out   []byte
+	words []uint16 // the words of the block
+	slots []int    // position in out of each word
+	n     int      // number of bits written
+}
+
+// reserve adds the slots of the words the decoder has read after consuming the bits written.
+func (w *xpressHuffmanWriter) reserve() {
+	for len(w.slots) < max(2, 1+(w.n+15)/16) {
+		w.slots = append(w.slots, len(w.out))
+		w.words = append(w.words, 0)
+		w.out = append(w.out, 0, 0)
+	}
+}
+
+// write writes the n low bits of v, most significant first.
+func (w *xpressHuffmanWriter) write(v uint32, n int) {
+	for n > 0 {
+		free := 16 - w.n%16
+		k := min(n, free)
+		part := uint16(v>>(n-k)) & (1<<k - 1)
+		w.words[w.n/16] |= part << (free - k)
+		w.n += k
+		n -= k
+		w.reserve()
+	}
+}
+
+// flush stores the words in their slots and returns the output.
+func (w *xpressHuffmanWriter) flush() []byte {
+	for i, pos := range w.slots {
+		binary.LittleEndian.PutUint16(w.out[pos:], w.words[i])
+	}
+	return w.out
+}
+
+// huffmanCodeLengths returns the lengths of a Huffman code of the symbols of non-zero frequency of at most maxBits
+// bits, flattening the frequencies until the code fits.
+func huffmanCodeLengths(freq []int, maxBits int) []uint8 {
+	lengths := make([]uint8, len(freq))
+	var symbols []int
+	for s, f := range freq {
+		if f > 0 {
+			symbols = append(symbols, s)
+		}
+	}
+	switch len(symbols) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[symbols[0]] = 1
+		return lengths
+	}
+	f := slices.Clone(freq)
+	n := len(symbols)
+	for {
+		slices.SortFunc(symbols, func(a, b int) int {
+			return cmp.Or(cmp.Compare(f[a], f[b]), cmp.Compare(a, b))
+		})
+		// The leaves in order of frequency and the internal nodes in the order they are created are both sorted, so
+		// the two lightest nodes are at the front of the two queues.
+		weight := make([]int, n, 2*n-1)
+		for i, s := range symbols {
+			weight[i] = f[s]
+		}
+		parent := make([]int, 2*n-1)
+		leaf, node := 0, n
+		pick := func() int {
+			if leaf < n && (node >= len(weight) || weight[leaf] <= weight[node]) {
+				leaf++
+				return leaf - 1
+			}
+			node++
+			return node - 1
+		}
+		for len(weight) < 2*n-1 {
+			a, b := pick(), pick()
+			parent[a], parent[b] = len(weight), len(weight)
+			weight = append(weight, weight[a]+weight[b])
+		}
+		depth := make([]int, 2*n-1)
+		longest := 0
+		for i := 2*n - 3; i >= 0; i-- {
+			depth[i] = depth[parent[i]] + 1
+		}
+		for i, s := range symbols {
+			lengths[s] = uint8(depth[i])
+			longest = max(longest, depth[i])
+		}
+		if longest <= maxBits {
+			return lengths
+		}
+		for _, s := range symbols {
+			f[s] = (f[s] + 1) / 2
+		}
+	}
+}
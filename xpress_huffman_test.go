@@ -0,0 +1,53 @@
+package mstypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xpressHuffmanBlock returns an LZ77+Huffman block of the code lengths of the symbols followed by the stream.
+func xpressHuffmanBlock(lengths map[int]byte, stream ...byte) []byte {
+	b := make([]byte, xpressHuffmanTableSize, xpressHuffmanTableSize+len(stream))
+	for s, l := range lengths {
+		b[s/2] |= l << (4 * (s % 2))
+	}
+	return append(b, stream...)
+}
+
+func Test_DecompressXPressHuffman(t *testing.T) {
+	var tests = []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		// 'a' is coded 0 and a match of length 5 at offset 1 is coded 1.
+		{"literal and match", xpressHuffmanBlock(map[int]byte{'a': 1, 256 + 2: 1}, 0x00, 0x40, 0, 0), "aaaaaa"},
+		// 'a' 00, 'b' 01, 256 10 and a match of length 3 with 1 offset bit 11; the offset bit 0 is offset 2.
+		{"offset bits", xpressHuffmanBlock(map[int]byte{'a': 2, 'b': 2, 256: 2, 256 + 16: 2}, 0x00, 0x1c, 0, 0),
+			"ababa"},
+		// A match of length nibble 15 continued by the byte 2 after the two words read ahead: 2+15+3 bytes.
+		{"long match", xpressHuffmanBlock(map[int]byte{'a': 1, 256 + 15: 1}, 0x00, 0x40, 0, 0, 2), "aaaaaaaaaaaaaaaaaaaaa"},
+	}
+	for _, test := range tests {
+		out, err := decompressXPressHuffman(test.in, len(test.want))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		assert.Equal(t, test.want, string(out), "%s: decompression not as expected", test.name)
+	}
+}
+
+func Test_DecompressXPressHuffmanInvalid(t *testing.T) {
+	for name, in := range map[string][]byte{
+		"truncated table": make([]byte, 100),
+		"no codes":        xpressHuffmanBlock(nil, 0, 0, 0, 0),
+		"oversubscribed":  xpressHuffmanBlock(map[int]byte{'a': 1, 'b': 1, 'c': 1}, 0, 0, 0, 0),
+		"offset":          xpressHuffmanBlock(map[int]byte{'a': 1, 256 + 2: 1}, 0x00, 0x80, 0, 0),
+		"size exceeded":   xpressHuffmanBlock(map[int]byte{'a': 1, 256 + 15: 1}, 0x00, 0x40, 200, 0, 0),
+	} {
+		_, err := decompressXPressHuffman(in, 10)
+		assert.True(t, errors.Is(err, ErrCompressedData), "%s: error not as expected: %v", name, err)
+	}
+}
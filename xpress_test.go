@@ -0,0 +1,51 @@
+package mstypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecompressXPress(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want string
+	}{
+		// The examples of MS-XCA.
+		{"3f000000" + hex.EncodeToString([]byte("abcdefghijklmnopqrstuvwxyz")), "abcdefghijklmnopqrstuvwxyz"},
+		{"ffffff1f61626317000fff2601", strings.Repeat("abc", 100)},
+		// Two matches sharing the nibbles of a length byte: lengths 7+3+2 and 7+3+1.
+		{"ffffff1f616263170012" + "1f00", strings.Repeat("abc", 5) + "cabccabccab"},
+	}
+	for _, test := range tests {
+		in, _ := hex.DecodeString(test.in)
+		out, err := decompressXPress(in, len(test.want))
+		if err != nil {
+			t.Fatalf("error decompressing %s: %v", test.in, err)
+		}
+		assert.Equal(t, test.want, string(out), "decompression of %s not as expected", test.in)
+	}
+}
+
+func Test_CompressXPress(t *testing.T) {
+	// The example of MS-XCA.
+	out := compressXPress([]byte(strings.Repeat("abc", 100)))
+	assert.Equal(t, "ffffff1f61626317000fff2601", hex.EncodeToString(out), "compression not as expected")
+}
+
+func Test_DecompressXPressInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",                  // no flags
+		"00000000",          // literal missing
+		"0000008061",        // match missing
+		"00000080" + "0800", // offset before the output
+		"ffffff1f61626317000fff2601",
+	} {
+		in, _ := hex.DecodeString(s)
+		_, err := decompressXPress(in, 200)
+		assert.True(t, errors.Is(err, ErrCompressedData), "error of %s not as expected: %v", s, err)
+	}
+}